@@ -13,33 +13,83 @@ import (
 // It provides detailed error messages showing both values and their types when they differ.
 func Equal[T any](t testing.TB, actual, expected T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	compare(t, expected, actual, msg...)
 }
 
+// EqualDeref checks if two values are equal after dereferencing pointers,
+// through as many levels as needed. It is convenient for pointer-optional
+// fields, which can be compared to plain values:
+//
+//	assert.EqualDeref(t, user.Nickname, "gopher") // user.Nickname is a *string
+//
+// Two nil pointers are equal; a nil pointer on only one side fails clearly.
+func EqualDeref(t testing.TB, actual, expected any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	a, actualNil := deref(actual)
+	e, expectedNil := deref(expected)
+
+	switch {
+	case actualNil && expectedNil:
+	case actualNil:
+		failCompare(t, actual, e, withMessage("actual is a nil pointer", msg)...)
+	case expectedNil:
+		failCompare(t, a, expected, withMessage("expected is a nil pointer", msg)...)
+	case !isEqual(a, e):
+		failCompareNote(t, a, e, explainUnequal(e, a), msg...)
+	}
+}
+
 // EqualError checks if an error matches the expected error.
 // It handles nil errors appropriately and provides clear error messages.
-func EqualError(t testing.TB, actual, expected error) {
+func EqualError(t testing.TB, actual, expected error, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if actual == nil && expected != nil {
-		failCompare(t, expected, actual, "expected error but got nil")
+		failCompare(t, actual, expected, withMessage("expected error but got nil", msg)...)
+		return
 	}
 	if actual != nil && expected == nil {
-		failCompare(t, expected, actual, "expected nil error")
+		failCompareNote(t, actual, expected, typedNilNote(actual), withMessage("expected nil error", msg)...)
+		return
 	}
 	if actual != expected {
-		failCompare(t, expected, actual)
+		failCompare(t, actual, expected, msg...)
+	}
+}
+
+// EqualErrorMessage checks if err is not nil and its message is expected.
+// Unlike EqualError, distinct error values with the same message are equal,
+// as guaranteed by many APIs:
+//
+//	assert.EqualErrorMessage(t, err, "connection refused")
+func EqualErrorMessage(t testing.TB, err error, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if isNil(err) {
+		failCompareNote[any](t, err, expected, typedNilNote(err), withMessage("expected error but got nil", msg)...)
+		return
+	}
+	if actual := err.Error(); actual != expected {
+		failCompare(t, actual, expected, withMessage("unexpected error message", msg)...)
 	}
 }
 
 // Error asserts that an error occurred (i.e., the error is not nil).
 // It fails the test if the error is nil, providing a clear error message.
+// An error interface holding a typed nil pointer is considered nil, and the
+// failure explains the pitfall.
 func Error(t testing.TB, err error, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if isNil(err) {
-		failCompare[any](t, "non-nil error", nil, append([]string{"expected an error"}, msg...)...)
+		failCompareNote[any](t, err, "non-nil error", typedNilNote(err), withMessage("expected an error", msg)...)
 	}
 }
 
@@ -47,6 +97,7 @@ func Error(t testing.TB, err error, msg ...string) {
 // The target must be a pointer to an error type.
 func ErrorAs(t testing.TB, err error, target any, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !errors.As(err, target) {
 		failCompare[any](t, err, fmt.Sprintf("error matching type %T", target), msg...)
@@ -57,6 +108,7 @@ func ErrorAs(t testing.TB, err error, target any, msg ...string) {
 // This is particularly useful when working with wrapped errors.
 func ErrorIs(t testing.TB, err, target error, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !errors.Is(err, target) {
 		failCompare[any](t, err, fmt.Sprintf("error chain containing %v", target), msg...)
@@ -67,30 +119,33 @@ func ErrorIs(t testing.TB, err, target error, msg ...string) {
 // It provides a clear error message with the source location and optional custom message.
 func False(t testing.TB, value bool, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if value {
-		// Using failCompare with any to handle mixed type comparisons in error message
-		failCompare(t, false, value, msg...)
+		failCompare(t, value, false, msg...)
 	}
 }
 
 // Nil checks if a value is nil, handling different types appropriately
 // including interfaces, slices, maps, and pointers.
-func Nil(t testing.TB, value any) {
+func Nil(t testing.TB, value any, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !isNil(value) {
-		failCompare(t, value, nil)
+		failCompare(t, value, nil, msg...)
 	}
 }
 
 // NoError asserts that no error occurred (i.e., the error is nil).
 // It fails the test if an error is not nil, providing a clear error message showing the unexpected error.
+// Like Error, it considers an error interface holding a typed nil pointer as nil.
 func NoError(t testing.TB, err error, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !isNil(err) {
-		failCompare[any](t, nil, err, append([]string{"unexpected error"}, msg...)...)
+		failCompare[any](t, err, nil, withMessage("unexpected error", msg)...)
 	}
 }
 
@@ -99,11 +154,12 @@ func NoError(t testing.TB, err error, msg ...string) {
 // or that distinct objects remain separate.
 func NotEqual[T any](t testing.TB, actual, expected T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if isEqual(actual, expected) {
 		failCompare(t,
-			"values to be different",
 			fmt.Sprintf("both values are equal: %v", actual),
+			"values to be different",
 			msg...,
 		)
 	}
@@ -111,39 +167,56 @@ func NotEqual[T any](t testing.TB, actual, expected T, msg ...string) {
 
 // NotNil checks if a value is not nil, providing a clear error message
 // when the value is unexpectedly nil.
-func NotNil(t testing.TB, value any) {
+func NotNil(t testing.TB, value any, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if isNil(value) {
-		t.Error("\nexpected value to not be nil")
+		var note string
+		if _, ok := value.(error); ok {
+			note = typedNilNote(value)
+		}
+		failCompareNote(t, value, "non-nil value", note, withMessage("expected value to not be nil", msg)...)
 	}
 }
 
 // Panics verifies that a function panics with an expected message.
-func Panics(t testing.TB, fn func(), expectedMsg string) {
+func Panics(t testing.TB, fn func(), expectedMsg string, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	defer func() {
 		if r := recover(); r != nil {
 			actualMsg := fmt.Sprint(r)
 			if actualMsg != expectedMsg {
-				failCompare(t, expectedMsg, actualMsg, "unexpected panic message")
+				failCompare(t, actualMsg, expectedMsg, withMessage("unexpected panic message", msg)...)
 			}
 		} else {
-			t.Errorf("\nExpected panic: %v\n  Actual: no panic", expectedMsg)
+			failCompare(t, "no panic", "panic: "+expectedMsg, withMessage("expected a panic", msg)...)
 		}
 	}()
 
 	fn()
 }
 
+// Satisfies asserts that a value satisfies a predicate described by desc.
+// It gives custom checks a clear failure message instead of a bare True(t, expr).
+func Satisfies[T any](t testing.TB, value T, pred func(T) bool, desc string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if !pred(value) {
+		failCompare[any](t, value, desc, withMessage("value did not satisfy: "+desc, msg)...)
+	}
+}
+
 // True asserts that a boolean value is true.
 // It provides a clear error message with the source location and optional custom message.
 func True(t testing.TB, value bool, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !value {
-		// Using failCompare with any to handle mixed type comparisons in error message
-		failCompare(t, true, value, msg...)
+		failCompare(t, value, true, msg...)
 	}
 }
@@ -6,6 +6,9 @@ package assert
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -98,6 +101,91 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestEqualDeref(t *testing.T) {
+	name := "gopher"
+	other := "alice"
+	count := 3
+	pname := &name
+	var nilName *string
+
+	tests := []struct {
+		name      string
+		actual    any
+		expected  any
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "pointer and value",
+			actual:    &name,
+			expected:  "gopher",
+			wantError: false,
+		},
+		{
+			name:      "two pointers",
+			actual:    &name,
+			expected:  &name,
+			wantError: false,
+		},
+		{
+			name:      "pointer to pointer",
+			actual:    &pname,
+			expected:  "gopher",
+			wantError: false,
+		},
+		{
+			name:      "plain values",
+			actual:    3,
+			expected:  &count,
+			wantError: false,
+		},
+		{
+			name:      "different values",
+			actual:    &name,
+			expected:  &other,
+			wantError: true,
+			wantParts: []string{`Expected: (string) "alice"`, `Actual: (string) "gopher"`},
+		},
+		{
+			name:      "both nil",
+			actual:    nilName,
+			expected:  (*int)(nil),
+			wantError: false,
+		},
+		{
+			name:      "actual nil pointer",
+			actual:    nilName,
+			expected:  "gopher",
+			wantError: true,
+			wantParts: []string{"actual is a nil pointer", "(*string)(nil)"},
+		},
+		{
+			name:      "expected nil pointer",
+			actual:    &name,
+			expected:  nilName,
+			wantError: true,
+			wantParts: []string{"expected is a nil pointer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualDeref(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualDeref() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualDeref() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
 func TestEqualError(t *testing.T) {
 	errOne := errors.New("error one")
 	errTwo := errors.New("error two")
@@ -151,6 +239,60 @@ func TestEqualError(t *testing.T) {
 	}
 }
 
+func TestEqualErrorMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		expected  string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:     "distinct errors with the same message",
+			err:      fmt.Errorf("dial tcp: %w", errors.New("connection refused")),
+			expected: "dial tcp: connection refused",
+		},
+		{
+			name:      "different message",
+			err:       errors.New("timeout"),
+			expected:  "connection refused",
+			wantError: true,
+			wantParts: []string{"unexpected error message", `Actual: (string) "timeout"`},
+		},
+		{
+			name:      "nil error",
+			err:       nil,
+			expected:  "connection refused",
+			wantError: true,
+			wantParts: []string{"expected error but got nil"},
+		},
+		{
+			name:      "typed nil error",
+			err:       (*testError)(nil),
+			expected:  "error code: 0",
+			wantError: true,
+			wantParts: []string{"did you return a typed nil?"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualErrorMessage(rec, tt.err, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualErrorMessage() error %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualErrorMessage() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
 func TestError(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -421,6 +563,53 @@ func TestNoError(t *testing.T) {
 	}
 }
 
+func TestTypedNilExplanations(t *testing.T) {
+	var typedNil *testError
+	var err error = typedNil
+
+	tests := []struct {
+		name string
+		run  func(t testing.TB)
+	}{
+		{
+			name: "Error",
+			run:  func(t testing.TB) { Error(t, err) },
+		},
+		{
+			name: "NotNil",
+			run:  func(t testing.TB) { NotNil(t, err) },
+		},
+		{
+			name: "EqualError",
+			run:  func(t testing.TB) { EqualError(t, err, nil) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.run(rec)
+
+			want := "value is a non-nil interface containing a nil *assert.testError"
+			if !strings.Contains(rec.ErrorMessage(), want) {
+				t.Errorf("%s() message missing typed nil note\ngot: %s", tt.name, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestTypedNilErrorIsNil(t *testing.T) {
+	var err error = (*testError)(nil)
+
+	errorRec, noErrorRec := NewTestRecorder(t), NewTestRecorder(t)
+	Error(errorRec, err)
+	NoError(noErrorRec, err)
+
+	True(t, errorRec.HasError(), "Error() passed on a typed nil error")
+	False(t, noErrorRec.HasError(), "NoError() failed on a typed nil error")
+}
+
 func TestNotEqual(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -578,6 +767,43 @@ func TestPanics(t *testing.T) {
 	}
 }
 
+func TestSatisfies(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		name      string
+		value     int
+		wantError bool
+	}{
+		{
+			name:      "predicate holds",
+			value:     4,
+			wantError: false,
+		},
+		{
+			name:      "predicate fails",
+			value:     3,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			Satisfies(rec, tt.value, isEven, "is even")
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("Satisfies() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+
+			if tt.wantError && !strings.Contains(rec.ErrorMessage(), "value did not satisfy: is even") {
+				t.Errorf("Satisfies() message missing description\ngot: %s", rec.ErrorMessage())
+			}
+		})
+	}
+}
+
 func TestTrue(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -619,3 +845,193 @@ func TestTrue(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB, msg string)
+	}{
+		{"Equal", func(t testing.TB, msg string) { Equal(t, 1, 2, msg) }},
+		{"EqualDeref", func(t testing.TB, msg string) { EqualDeref(t, 1, 2, msg) }},
+		{"EqualError", func(t testing.TB, msg string) { EqualError(t, nil, errors.New("boom"), msg) }},
+		{"Error", func(t testing.TB, msg string) { Error(t, nil, msg) }},
+		{"ErrorAs", func(t testing.TB, msg string) {
+			var target *testError
+			ErrorAs(t, errors.New("boom"), &target, msg)
+		}},
+		{"ErrorIs", func(t testing.TB, msg string) { ErrorIs(t, nil, errors.New("boom"), msg) }},
+		{"False", func(t testing.TB, msg string) { False(t, true, msg) }},
+		{"Nil", func(t testing.TB, msg string) { Nil(t, 1, msg) }},
+		{"NoError", func(t testing.TB, msg string) { NoError(t, errors.New("boom"), msg) }},
+		{"NotEqual", func(t testing.TB, msg string) { NotEqual(t, 1, 1, msg) }},
+		{"NotNil", func(t testing.TB, msg string) { NotNil(t, nil, msg) }},
+		{"Panics", func(t testing.TB, msg string) { Panics(t, func() {}, "boom", msg) }},
+		{"Satisfies", func(t testing.TB, msg string) {
+			Satisfies(t, 1, func(n int) bool { return n > 1 }, "greater than one", msg)
+		}},
+		{"True", func(t testing.TB, msg string) { True(t, false, msg) }},
+		{"Contains", func(t testing.TB, msg string) { Contains(t, []int{1}, 2, msg) }},
+		{"Empty", func(t testing.TB, msg string) { Empty(t, []int{1}, msg) }},
+		{"HasKey", func(t testing.TB, msg string) { HasKey(t, map[string]int{}, "a", msg) }},
+		{"Len", func(t testing.TB, msg string) { Len(t, []int{1}, 2, msg) }},
+		{"LenOf", func(t testing.TB, msg string) { LenOf(t, []int{1}, []int{1, 2}, msg) }},
+		{"StringContains", func(t testing.TB, msg string) { StringContains(t, "hello", "x", msg) }},
+		{"StringContainsFold", func(t testing.TB, msg string) { StringContainsFold(t, "hello", "x", msg) }},
+		{"HasPrefixFold", func(t testing.TB, msg string) { HasPrefixFold(t, "hello", "x", msg) }},
+		{"HasSuffixFold", func(t testing.TB, msg string) { HasSuffixFold(t, "hello", "x", msg) }},
+		{"Between", func(t testing.TB, msg string) { Between(t, 5, 1, 3, msg) }},
+		{"Greater", func(t testing.TB, msg string) { Greater(t, 1, 3, msg) }},
+		{"GreaterOrEqual", func(t testing.TB, msg string) { GreaterOrEqual(t, 1, 3, msg) }},
+		{"AtomicEquals", func(t testing.TB, msg string) { AtomicEquals(t, &atomicCounter{}, int64(1), msg) }},
+		{"FileExists", func(t testing.TB, msg string) { FileExists(t, "no-such-file", msg) }},
+		{"FileContentEquals", func(t testing.TB, msg string) { FileContentEquals(t, "no-such-file", "", msg) }},
+		{"GraphQLNoErrors", func(t testing.TB, msg string) { GraphQLNoErrors(t, graphQLFailed, msg) }},
+		{"GraphQLDataPath", func(t testing.TB, msg string) { GraphQLDataPath(t, graphQLOK, "user.name", "eve", msg) }},
+		{"GraphQLErrorCode", func(t testing.TB, msg string) { GraphQLErrorCode(t, graphQLOK, "NOT_FOUND", msg) }},
+		{"XMLPath", func(t testing.TB, msg string) { XMLPath(t, orderXML, "/order/customer", "bob", msg) }},
+		{"XMLPathExists", func(t testing.TB, msg string) { XMLPathExists(t, orderXML, "/order/invoice", msg) }},
+		{"FormFieldEquals", func(t testing.TB, msg string) { FormFieldEquals(t, tokenForm, "scope", "admin", msg) }},
+		{"FormFieldsMatch", func(t testing.TB, msg string) { FormFieldsMatch(t, tokenForm, map[string]string{"a": "b"}, msg) }},
+		{"MatchesJSONSchema", func(t testing.TB, msg string) { MatchesJSONSchema(t, `1`, `{"type": "string"}`, msg) }},
+		{"MatchesOpenAPI", func(t testing.TB, msg string) { MatchesOpenAPI(t, "testdata/none.json", "GET", "/", 200, "", msg) }},
+		{"TOMLEq", func(t testing.TB, msg string) { TOMLEq(t, "a = 1", "a = 2", msg) }},
+		{"CBOREq", func(t testing.TB, msg string) { CBOREq(t, []byte{1}, []byte{2}, msg) }},
+		{"MsgPackEq", func(t testing.TB, msg string) { MsgPackEq(t, []byte{1}, []byte{2}, msg) }},
+		{"KeySetEquals", func(t testing.TB, msg string) { KeySetEquals(t, map[int]int{}, []int{1}, msg) }},
+		{"EqualKeyed", func(t testing.TB, msg string) {
+			EqualKeyed(t, []int{1}, []int{2}, func(i int) int { return i }, msg)
+		}},
+		{"EqualErrorMessage", func(t testing.TB, msg string) { EqualErrorMessage(t, nil, "boom", msg) }},
+		{"ErrorCountIs", func(t testing.TB, msg string) { ErrorCountIs(t, nil, 1, msg) }},
+		{"EachError", func(t testing.TB, msg string) { EachError(t, nil, func(error, *Assert) {}, msg) }},
+		{"ExactlyOnce", func(t testing.TB, msg string) { ExactlyOnce(t, &EventRecorder{}, "flush", msg) }},
+		{"CalledTimes", func(t testing.TB, msg string) { CalledTimes(t, NewSpy(func() {}), 1, msg) }},
+		{"Deterministic", func(t testing.TB, msg string) {
+			n := 0
+			Deterministic(t, 2, func() int { n++; return n }, msg)
+		}},
+		{"TryLockEventually", func(t testing.TB, msg string) {
+			var mu sync.Mutex
+			mu.Lock()
+			TryLockEventually(t, &mu, 0, msg)
+		}},
+		{"MinElementEquals", func(t testing.TB, msg string) { MinElementEquals(t, []int{2, 3}, 1, msg) }},
+		{"MaxElementEquals", func(t testing.TB, msg string) { MaxElementEquals(t, []int{2, 3}, 1, msg) }},
+		{"AllGreater", func(t testing.TB, msg string) { AllGreater(t, []int{2, 3}, 2, msg) }},
+		{"AllLess", func(t testing.TB, msg string) { AllLess(t, []int{2, 3}, 3, msg) }},
+		{"RedirectsTo", func(t testing.TB, msg string) { RedirectsTo(t, &http.Response{StatusCode: 200}, "/", msg) }},
+		{"IntEquals", func(t testing.TB, msg string) { IntEquals(t, uint64(1), 2, msg) }},
+		{"IntGreater", func(t testing.TB, msg string) { IntGreater(t, uint64(1), 2, msg) }},
+		{"IntLess", func(t testing.TB, msg string) { IntLess(t, uint64(3), 2, msg) }},
+		{"Less", func(t testing.TB, msg string) { Less(t, 3, 1, msg) }},
+		{"LessOrEqual", func(t testing.TB, msg string) { LessOrEqual(t, 3, 1, msg) }},
+		{"Match", func(t testing.TB, msg string) { Match(t, 1, BeEmpty(), msg) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec, "custom")
+
+			if !rec.HasError() {
+				t.Fatalf("%s() did not fail", tt.name)
+			}
+			if !strings.Contains(rec.ErrorMessage(), "Message: custom") {
+				t.Errorf("%s() message missing custom message\ngot: %s", tt.name, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), "Expected: ") || !strings.Contains(rec.ErrorMessage(), "Actual: ") {
+				t.Errorf("%s() message not in the common format\ngot: %s", tt.name, rec.ErrorMessage())
+			}
+			if !rec.HelperCalled() {
+				t.Error("Helper() was not called")
+			}
+		})
+	}
+}
+
+func TestSuccessPathAllocations(t *testing.T) {
+	ints := []int{1, 2, 3}
+	words := map[string]int{"a": 1}
+	x, y := 12345, 12345
+
+	Allocates(t, 0, func() { Equal(t, x, y) }, "Equal on int")
+	Allocates(t, 0, func() { Equal(t, "hello", "hello") }, "Equal on string")
+	Allocates(t, 0, func() { Equal(t, ints, ints) }, "Equal on []int")
+	Allocates(t, 0, func() { True(t, x == y) }, "True")
+	Allocates(t, 0, func() { Len(t, ints, 3) }, "Len on slice")
+	Allocates(t, 0, func() { Len(t, words, 1) }, "Len on map")
+}
+
+func BenchmarkEqual(b *testing.B) {
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(b, i, i)
+		}
+	})
+
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(b, "value", "value")
+		}
+	})
+
+	b.Run("slice", func(b *testing.B) {
+		s := []string{"a", "b", "c"}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(b, s, s)
+		}
+	})
+
+	b.Run("struct", func(b *testing.B) {
+		v := struct{ A, B int }{1, 2}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(b, v, v)
+		}
+	})
+}
+
+func BenchmarkTrue(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		True(b, i >= 0)
+	}
+}
+
+// discardTB is a TB discarding failures, to benchmark failing assertions.
+type discardTB struct {
+	testing.TB
+}
+
+func (discardTB) Error(...any)          {}
+func (discardTB) Errorf(string, ...any) {}
+
+func BenchmarkEqualFailure(b *testing.B) {
+	t := discardTB{b}
+
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(t, i, i+1)
+		}
+	})
+
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(t, "actual", "expected")
+		}
+	})
+
+	b.Run("struct", func(b *testing.B) {
+		x, y := struct{ A, B int }{1, 2}, struct{ A, B int }{1, 3}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(t, x, y)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+// Package asserttest provides test doubles for testing.TB, so that authors
+// of custom assertions can unit-test them the same way the assert package
+// tests itself.
+//
+// A Recorder captures failures, logs, skips and cleanups without failing
+// the surrounding test:
+//
+//	func TestMyAssertion(t *testing.T) {
+//	    rec := asserttest.NewRecorder(t)
+//
+//	    MyAssertion(rec, "bad input")
+//
+//	    if rec.FailureCount() != 1 {
+//	        t.Errorf("got %d failures, want 1", rec.FailureCount())
+//	    }
+//	}
+//
+// A MockTB adds expectations that are verified when the test ends:
+//
+//	func TestMyAssertion(t *testing.T) {
+//	    mock := asserttest.NewMockTB(t).ExpectFailures(1).ExpectFailureContaining("invalid")
+//
+//	    MyAssertion(mock, "bad input")
+//	}
+package asserttest
@@ -0,0 +1,96 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package asserttest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// MockTB is a Recorder with expectations about what should be reported.
+// Expectations are verified automatically when the test ends,
+// or explicitly with Verify.
+type MockTB struct {
+	*Recorder
+	parent testing.TB
+	checks []func() string
+}
+
+// NewMockTB creates a MockTB wrapping t and registers its verification
+// as a cleanup of t.
+func NewMockTB(t *testing.T) *MockTB {
+	t.Helper()
+
+	m := &MockTB{Recorder: NewRecorder(t), parent: t}
+	t.Cleanup(m.Verify)
+
+	return m
+}
+
+// ExpectFailures expects exactly n failures to be reported.
+func (m *MockTB) ExpectFailures(n int) *MockTB {
+	m.checks = append(m.checks, func() string {
+		if got := m.FailureCount(); got != n {
+			return fmt.Sprintf("expected %d failures, got %d: %q", n, got, m.Failures())
+		}
+		return ""
+	})
+	return m
+}
+
+// ExpectNoFailures expects no failure to be reported.
+func (m *MockTB) ExpectNoFailures() *MockTB {
+	return m.ExpectFailures(0)
+}
+
+// ExpectFailureContaining expects at least one failure containing substr.
+func (m *MockTB) ExpectFailureContaining(substr string) *MockTB {
+	m.checks = append(m.checks, func() string {
+		for _, f := range m.Failures() {
+			if strings.Contains(f, substr) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("expected a failure containing %q, got: %q", substr, m.Failures())
+	})
+	return m
+}
+
+// ExpectHelper expects Helper to have been called.
+func (m *MockTB) ExpectHelper() *MockTB {
+	m.checks = append(m.checks, func() string {
+		if !m.HelperCalled() {
+			return "expected Helper() to be called"
+		}
+		return ""
+	})
+	return m
+}
+
+// ExpectSkip expects the test to have been skipped.
+func (m *MockTB) ExpectSkip() *MockTB {
+	m.checks = append(m.checks, func() string {
+		if !m.Skipped() {
+			return "expected test to be skipped"
+		}
+		return ""
+	})
+	return m
+}
+
+// Verify checks every expectation and reports unmet ones on the wrapped test.
+// Each expectation is verified once, even if Verify is called again.
+func (m *MockTB) Verify() {
+	m.parent.Helper()
+
+	checks := m.checks
+	m.checks = nil
+
+	for _, check := range checks {
+		if msg := check(); msg != "" {
+			m.parent.Error(msg)
+		}
+	}
+}
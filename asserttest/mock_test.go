@@ -0,0 +1,58 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package asserttest
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkPositive is a sample custom assertion under test.
+func checkPositive(t testing.TB, n int) {
+	t.Helper()
+
+	if n <= 0 {
+		t.Errorf("expected a positive number, got %d", n)
+	}
+}
+
+func TestMockTB(t *testing.T) {
+	t.Run("met expectations", func(t *testing.T) {
+		mock := NewMockTB(t).
+			ExpectFailures(1).
+			ExpectFailureContaining("positive number, got -1").
+			ExpectHelper()
+
+		checkPositive(mock, -1)
+	})
+
+	t.Run("no failures", func(t *testing.T) {
+		mock := NewMockTB(t).ExpectNoFailures()
+
+		checkPositive(mock, 1)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		mock := NewMockTB(t).ExpectSkip()
+
+		mock.Call(func() { mock.Skip("later") })
+	})
+
+	t.Run("unmet expectations are reported", func(t *testing.T) {
+		parent := NewRecorder(t)
+		mock := &MockTB{Recorder: NewRecorder(t), parent: parent}
+
+		mock.ExpectFailures(2).ExpectFailureContaining("missing").ExpectHelper().ExpectSkip()
+		mock.Error("only one")
+		mock.Verify()
+		mock.Verify()
+
+		if parent.FailureCount() != 4 {
+			t.Errorf("Verify() reported %q, want 4 failures", parent.Failures())
+		}
+		if !strings.Contains(parent.Failures()[0], "expected 2 failures, got 1") {
+			t.Errorf("Verify() first failure = %q", parent.Failures()[0])
+		}
+	})
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package asserttest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// Recorder wraps a testing.T instance and records error messages
+// without failing the actual test. This allows assertion helpers
+// to be unit-tested by checking what they reported.
+//
+// It implements the whole testing.TB surface: failures, skips, logs and
+// cleanups are recorded instead of being forwarded to the wrapped test,
+// along with a chronological log of every call.
+// Methods stopping the test (FailNow, Fatal, SkipNow...) call runtime.Goexit,
+// so code using them should be run through Call. Recorder is safe for
+// concurrent use.
+type Recorder struct {
+	*testing.T
+	mu           sync.Mutex
+	errorCalled  bool
+	errorMessage string
+	helperCalled bool
+	failed       bool
+	skipped      bool
+	failures     []string
+	logs         []string
+	calls        []string
+	cleanups     []func()
+}
+
+// NewRecorder creates a new Recorder instance.
+// It automatically marks itself as a test helper to maintain
+// accurate line numbers in test output.
+func NewRecorder(t *testing.T) *Recorder {
+	t.Helper()
+
+	return &Recorder{T: t}
+}
+
+// Call runs fn in a separate goroutine and waits for it to return.
+// FailNow, Fatal and Skip called by fn stop only that goroutine,
+// leaving the calling test running.
+func (r *Recorder) Call(fn func()) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	<-done
+}
+
+// Cleanup records a cleanup function; see RunCleanups
+func (r *Recorder) Cleanup(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "Cleanup")
+	r.cleanups = append(r.cleanups, fn)
+}
+
+// Error records that an error occurred with the given arguments
+func (r *Recorder) Error(args ...interface{}) {
+	r.recordError("Error", fmt.Sprint(args...))
+}
+
+// Errorf records that an error occurred with the formatted message
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.recordError("Errorf", fmt.Sprintf(format, args...))
+}
+
+// Fail records that the test failed without stopping it
+func (r *Recorder) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "Fail")
+	r.failed = true
+}
+
+// FailNow records that the test failed and stops the calling goroutine
+func (r *Recorder) FailNow() {
+	r.mu.Lock()
+	r.calls = append(r.calls, "FailNow")
+	r.failed = true
+	r.mu.Unlock()
+
+	runtime.Goexit()
+}
+
+// Failed reports whether a failure was recorded
+func (r *Recorder) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failed
+}
+
+// Fatal records an error with the given arguments and stops the calling goroutine
+func (r *Recorder) Fatal(args ...interface{}) {
+	r.recordError("Fatal", fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+// Fatalf records an error with the formatted message and stops the calling goroutine
+func (r *Recorder) Fatalf(format string, args ...interface{}) {
+	r.recordError("Fatalf", fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+// Helper records that Helper() was called, which is useful
+// for verifying our assertions maintain proper stack traces
+func (r *Recorder) Helper() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "Helper")
+	r.helperCalled = true
+}
+
+// Log records a log entry with the given arguments
+func (r *Recorder) Log(args ...interface{}) {
+	r.recordLog("Log", fmt.Sprint(args...))
+}
+
+// Logf records a log entry with the formatted message
+func (r *Recorder) Logf(format string, args ...interface{}) {
+	r.recordLog("Logf", fmt.Sprintf(format, args...))
+}
+
+// Skip records a log entry and skips the test
+func (r *Recorder) Skip(args ...interface{}) {
+	r.recordSkip("Skip", fmt.Sprint(args...))
+}
+
+// Skipf records a formatted log entry and skips the test
+func (r *Recorder) Skipf(format string, args ...interface{}) {
+	r.recordSkip("Skipf", fmt.Sprintf(format, args...))
+}
+
+// SkipNow records that the test was skipped and stops the calling goroutine
+func (r *Recorder) SkipNow() {
+	r.mu.Lock()
+	r.calls = append(r.calls, "SkipNow")
+	r.skipped = true
+	r.mu.Unlock()
+
+	runtime.Goexit()
+}
+
+// Skipped reports whether the test was skipped
+func (r *Recorder) Skipped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.skipped
+}
+
+// HasError checks if any error method was called
+func (r *Recorder) HasError() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.errorCalled
+}
+
+// ErrorMessage returns the last recorded error message
+func (r *Recorder) ErrorMessage() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.errorMessage
+}
+
+// HelperCalled checks if Helper() was called
+func (r *Recorder) HelperCalled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.helperCalled
+}
+
+// Failures returns every recorded error message, in order
+func (r *Recorder) Failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.failures...)
+}
+
+// FailureCount returns the number of recorded error messages
+func (r *Recorder) FailureCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.failures)
+}
+
+// Calls returns the chronological log of recorded calls, such as
+// "Helper", "Error: message" or "FailNow"
+func (r *Recorder) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+// Logs returns the recorded log entries
+func (r *Recorder) Logs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.logs...)
+}
+
+// RunCleanups runs the recorded cleanup functions in last-added,
+// first-called order, then forgets them
+func (r *Recorder) RunCleanups() {
+	r.mu.Lock()
+	cleanups := r.cleanups
+	r.cleanups = nil
+	r.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+func (r *Recorder) recordError(call, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call+": "+msg)
+	r.failed = true
+	r.errorCalled = true
+	r.errorMessage = msg
+	r.failures = append(r.failures, msg)
+}
+
+func (r *Recorder) recordLog(call, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call+": "+msg)
+	r.logs = append(r.logs, msg)
+}
+
+func (r *Recorder) recordSkip(call, msg string) {
+	r.mu.Lock()
+	r.calls = append(r.calls, call+": "+msg)
+	r.logs = append(r.logs, msg)
+	r.skipped = true
+	r.mu.Unlock()
+
+	runtime.Goexit()
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package asserttest
+
+import "testing"
+
+func TestRecorder(t *testing.T) {
+	t.Run("fatal stops the called function", func(t *testing.T) {
+		rec := NewRecorder(t)
+		reached := false
+
+		rec.Call(func() {
+			rec.Fatalf("stop at %d", 1)
+			reached = true
+		})
+
+		if reached {
+			t.Error("Fatalf() did not stop the function")
+		}
+		if !rec.Failed() || !rec.HasError() || rec.ErrorMessage() != "stop at 1" {
+			t.Errorf("Fatalf() recorded failed=%v error=%q", rec.Failed(), rec.ErrorMessage())
+		}
+	})
+
+	t.Run("fail does not record an error", func(t *testing.T) {
+		rec := NewRecorder(t)
+
+		rec.Fail()
+
+		if !rec.Failed() || rec.HasError() {
+			t.Errorf("Fail() recorded failed=%v hasError=%v", rec.Failed(), rec.HasError())
+		}
+	})
+
+	t.Run("skip is recorded with its reason", func(t *testing.T) {
+		rec := NewRecorder(t)
+
+		rec.Call(func() {
+			rec.Skipf("needs %s", "network")
+		})
+
+		if !rec.Skipped() || rec.Failed() {
+			t.Errorf("Skipf() recorded skipped=%v failed=%v", rec.Skipped(), rec.Failed())
+		}
+		if logs := rec.Logs(); len(logs) != 1 || logs[0] != "needs network" {
+			t.Errorf("Logs() = %q, want [needs network]", logs)
+		}
+	})
+
+	t.Run("logs are captured", func(t *testing.T) {
+		rec := NewRecorder(t)
+
+		rec.Log("first")
+		rec.Logf("second %d", 2)
+
+		logs := rec.Logs()
+		if len(logs) != 2 || logs[0] != "first" || logs[1] != "second 2" {
+			t.Errorf("Logs() = %q", logs)
+		}
+	})
+
+	t.Run("cleanups run in reverse order once", func(t *testing.T) {
+		rec := NewRecorder(t)
+		var order []int
+
+		rec.Cleanup(func() { order = append(order, 1) })
+		rec.Cleanup(func() { order = append(order, 2) })
+
+		rec.RunCleanups()
+		rec.RunCleanups()
+
+		if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+			t.Errorf("RunCleanups() order = %v, want [2 1]", order)
+		}
+	})
+
+	t.Run("all failures are kept in order", func(t *testing.T) {
+		rec := NewRecorder(t)
+
+		rec.Error("first")
+		rec.Error("second")
+		rec.Errorf("third %d", 3)
+
+		failures := rec.Failures()
+		if rec.FailureCount() != 3 || len(failures) != 3 {
+			t.Fatalf("FailureCount() = %d, want 3", rec.FailureCount())
+		}
+		if failures[0] != "first" || failures[1] != "second" || failures[2] != "third 3" {
+			t.Errorf("Failures() = %q", failures)
+		}
+		if rec.ErrorMessage() != "third 3" {
+			t.Errorf("ErrorMessage() = %q, want last failure", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("call log is chronological", func(t *testing.T) {
+		rec := NewRecorder(t)
+
+		rec.Call(func() {
+			rec.Helper()
+			rec.Log("start")
+			rec.Cleanup(func() {})
+			rec.Error("boom")
+			rec.Fail()
+			rec.Fatal("stop")
+		})
+
+		want := []string{"Helper", "Log: start", "Cleanup", "Error: boom", "Fail", "Fatal: stop"}
+		calls := rec.Calls()
+
+		if len(calls) != len(want) {
+			t.Fatalf("Calls() = %q, want %q", calls, want)
+		}
+		for i := range want {
+			if calls[i] != want[i] {
+				t.Errorf("Calls()[%d] = %q, want %q", i, calls[i], want[i])
+			}
+		}
+	})
+
+	t.Run("concurrent errors", func(t *testing.T) {
+		rec := NewRecorder(t)
+		done := make(chan struct{})
+
+		for i := 0; i < 10; i++ {
+			go func() {
+				rec.Error("boom")
+				done <- struct{}{}
+			}()
+		}
+		for i := 0; i < 10; i++ {
+			<-done
+		}
+
+		if rec.FailureCount() != 10 {
+			t.Errorf("FailureCount() = %d, want 10", rec.FailureCount())
+		}
+	})
+}
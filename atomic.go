@@ -0,0 +1,63 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Loader is implemented by values holding a value loaded atomically,
+// such as atomic.Int64, atomic.Uint64, atomic.Bool, atomic.Pointer
+// and atomic.Value (with T being any).
+type Loader[T any] interface {
+	Load() T
+}
+
+// pollInterval is the delay between two loads of a polled value.
+const pollInterval = 10 * time.Millisecond
+
+// AtomicEquals loads a value once and checks if it is equal to expected.
+// It saves the Load calls otherwise needed to assert on counters:
+//
+//	var hits atomic.Int64
+//	// ...
+//	assert.AtomicEquals(t, &hits, int64(3))
+func AtomicEquals[T any, L Loader[T]](t testing.TB, v L, expected T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if actual := v.Load(); !isEqual(actual, expected) {
+		failCompareNote(t, actual, expected, explainUnequal(expected, actual), msg...)
+	}
+}
+
+// AtomicEventually loads a value until it is equal to expected, and fails
+// if it is still different after timeout. It waits for concurrent code to
+// reach a state, such as all workers being done:
+//
+//	assert.AtomicEventually(t, &done, int64(workers), time.Second)
+func AtomicEventually[T any, L Loader[T]](t testing.TB, v L, expected T, timeout time.Duration, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	clk := lookupClock(t)
+	deadline := clk.Now().Add(timeout)
+	loads := 0
+
+	for {
+		actual := v.Load()
+		loads++
+		if isEqual(actual, expected) {
+			return
+		}
+		if clk.Now().Add(pollInterval).After(deadline) {
+			failCompare(t, actual, expected,
+				withMessage(fmt.Sprintf("value not reached after %d loads in %v", loads, timeout), msg)...)
+			return
+		}
+		clk.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// atomicCounter mimics atomic.Int64, which requires a more recent Go
+// version than the one supported by this module.
+type atomicCounter struct {
+	v int64
+}
+
+func (c *atomicCounter) Load() int64   { return atomic.LoadInt64(&c.v) }
+func (c *atomicCounter) Add(n int64)   { atomic.AddInt64(&c.v, n) }
+func (c *atomicCounter) Store(n int64) { atomic.StoreInt64(&c.v, n) }
+
+func TestAtomicEquals(t *testing.T) {
+	var config atomic.Value
+	config.Store("v1")
+
+	counter := &atomicCounter{}
+	counter.Store(3)
+
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"counter equal", func(t testing.TB) { AtomicEquals(t, counter, int64(3)) }, false},
+		{"counter different", func(t testing.TB) { AtomicEquals(t, counter, int64(4)) }, true},
+		{"value equal", func(t testing.TB) { AtomicEquals[any](t, &config, "v1") }, false},
+		{"value different", func(t testing.TB) { AtomicEquals[any](t, &config, "v2") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("AtomicEquals() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestAtomicEventually(t *testing.T) {
+	t.Run("value reached", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		counter := &atomicCounter{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				time.Sleep(20 * time.Millisecond)
+				counter.Add(1)
+			}()
+		}
+
+		AtomicEventually(rec, counter, int64(4), time.Second)
+		wg.Wait()
+
+		if rec.HasError() {
+			t.Errorf("AtomicEventually() recorded error\n%s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("value not reached", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		counter := &atomicCounter{}
+		counter.Store(1)
+
+		AtomicEventually(rec, counter, int64(2), 50*time.Millisecond, "workers")
+
+		if !rec.HasError() {
+			t.Fatal("AtomicEventually() did not fail")
+		}
+		for _, part := range []string{"Message: workers: value not reached after", "Actual: (int64) 1", "Expected: (int64) 2"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("AtomicEventually() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
@@ -0,0 +1,346 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// CBOREq checks if two CBOR payloads (RFC 8949) encode equal values.
+// Both are decoded and compared as trees, so that equal values encoded
+// differently, such as with indefinite lengths or shorter floats, are
+// equal. Differences are reported by path in diagnostic notation:
+//
+//	assert.CBOREq(t, payload, expected)
+//	// Note: $.user.roles[1]: expected "admin", got "guest"
+//
+// When a payload cannot be decoded, their hex dumps are compared instead.
+func CBOREq(t testing.TB, actual, expected []byte, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	binaryEq(t, actual, expected, "CBOR", decodeCBOR, msg)
+}
+
+// MsgPackEq checks if two MessagePack payloads encode equal values,
+// like CBOREq. Timestamps (extension type -1) are decoded as times.
+func MsgPackEq(t testing.TB, actual, expected []byte, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	binaryEq(t, actual, expected, "MessagePack", decodeMsgPack, msg)
+}
+
+// binaryEq decodes and compares two payloads of the given format.
+func binaryEq(t testing.TB, actual, expected []byte, format string, decode func([]byte) (any, error), msg []string) {
+	t.Helper()
+
+	if bytes.Equal(actual, expected) {
+		return
+	}
+
+	e, err := decode(expected)
+	if err == nil {
+		var a any
+		if a, err = decode(actual); err == nil {
+			if diffs := diffDecoded(a, e, "$"); len(diffs) > 0 {
+				if len(diffs) > maxSliceDiffs {
+					diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+				}
+				failCompareNote(t, formatDecoded(a), formatDecoded(e), strings.Join(diffs, "; "),
+					withMessage(format+" payloads differ", msg)...)
+			}
+			return
+		}
+		err = fmt.Errorf("invalid actual %s: %v", format, err)
+	} else {
+		err = fmt.Errorf("invalid expected %s: %v", format, err)
+	}
+
+	failCompareDiff(t, actual, expected, diffText(t, hex.Dump(expected), hex.Dump(actual)), withMessage(err.Error(), msg)...)
+}
+
+// The values decoded from binary payloads, besides nil, bool, float64,
+// string, []byte, []any and time.Time. Integers are *big.Int.
+type (
+	// decodedMap is a map, which keeps its entries in encoding order
+	// as their keys may be of any type.
+	decodedMap []decodedEntry
+
+	decodedEntry struct {
+		key, value any
+	}
+
+	// decodedTag is a CBOR tagged value.
+	decodedTag struct {
+		number uint64
+		value  any
+	}
+
+	// decodedExt is a MessagePack extension.
+	decodedExt struct {
+		typ  int8
+		data []byte
+	}
+
+	// decodedSimple is a CBOR simple value, other than booleans and null.
+	decodedSimple uint8
+)
+
+// diffDecoded compares two decoded values, and describes their
+// differences prefixed by their path.
+func diffDecoded(a, e any, path string) []string {
+	switch ev := e.(type) {
+	case decodedMap:
+		av, ok := a.(decodedMap)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected map, got %s", path, decodedKind(a))}
+		}
+		return diffDecodedMaps(av, ev, path)
+	case []any:
+		av, ok := a.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %s", path, decodedKind(a))}
+		}
+		var diffs []string
+		if len(av) != len(ev) {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(ev), len(av)))
+		}
+		for i := 0; i < len(av) && i < len(ev); i++ {
+			diffs = append(diffs, diffDecoded(av[i], ev[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+	case decodedTag:
+		av, ok := a.(decodedTag)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected tag %d, got %s", path, ev.number, decodedKind(a))}
+		}
+		if av.number != ev.number {
+			return []string{fmt.Sprintf("%s: expected tag %d, got tag %d", path, ev.number, av.number)}
+		}
+		return diffDecoded(av.value, ev.value, path)
+	}
+
+	if decodedKind(a) != decodedKind(e) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, decodedKind(e), decodedKind(a))}
+	}
+	if !equalDecoded(a, e) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, formatDecoded(e), formatDecoded(a))}
+	}
+	return nil
+}
+
+// diffDecodedMaps compares maps by key, in the order of the expected
+// entries, then of the unexpected ones.
+func diffDecodedMaps(a, e decodedMap, path string) []string {
+	actual := make(map[string]any, len(a))
+	for _, entry := range a {
+		actual[formatDecoded(entry.key)] = entry.value
+	}
+
+	var diffs []string
+	seen := make(map[string]bool, len(e))
+	for _, entry := range e {
+		k := formatDecoded(entry.key)
+		seen[k] = true
+		p := decodedPath(path, entry.key)
+
+		if av, ok := actual[k]; ok {
+			diffs = append(diffs, diffDecoded(av, entry.value, p)...)
+		} else {
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", p, formatDecoded(entry.value)))
+		}
+	}
+	for _, entry := range a {
+		if !seen[formatDecoded(entry.key)] {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected, got %s",
+				decodedPath(path, entry.key), formatDecoded(entry.value)))
+		}
+	}
+	return diffs
+}
+
+// decodedPath appends a map key to a path: string keys like JSON object
+// keys, others in brackets.
+func decodedPath(path string, key any) string {
+	if s, ok := key.(string); ok {
+		return jsonPath(path, s)
+	}
+	return fmt.Sprintf("%s[%s]", path, formatDecoded(key))
+}
+
+// equalDecoded reports whether two scalar values of the same kind are
+// equal. NaN floats are equal, as they encode the same.
+func equalDecoded(a, e any) bool {
+	switch ev := e.(type) {
+	case *big.Int:
+		return a.(*big.Int).Cmp(ev) == 0
+	case float64:
+		av := a.(float64)
+		return av == ev || (math.IsNaN(av) && math.IsNaN(ev))
+	case []byte:
+		return bytes.Equal(a.([]byte), ev)
+	case time.Time:
+		return a.(time.Time).Equal(ev)
+	case decodedExt:
+		av := a.(decodedExt)
+		return av.typ == ev.typ && bytes.Equal(av.data, ev.data)
+	}
+	return a == e
+}
+
+// decodedKind returns the type of a decoded value.
+func decodedKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case *big.Int:
+		return "integer"
+	case float64:
+		return "float"
+	case string:
+		return "text string"
+	case []byte:
+		return "byte string"
+	case []any:
+		return "array"
+	case decodedMap:
+		return "map"
+	case decodedTag:
+		return "tag"
+	case decodedExt:
+		return "extension"
+	case decodedSimple:
+		return "simple value"
+	case time.Time:
+		return "timestamp"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// formatDecoded formats a decoded value in the diagnostic notation
+// of CBOR, such as {"id": 1, "data": h'cafe'}.
+func formatDecoded(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return "h'" + hex.EncodeToString(v) + "'"
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatDecoded(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case decodedMap:
+		parts := make([]string, len(v))
+		for i, entry := range v {
+			parts[i] = formatDecoded(entry.key) + ": " + formatDecoded(entry.value)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case decodedTag:
+		return fmt.Sprintf("%d(%s)", v.number, formatDecoded(v.value))
+	case decodedExt:
+		return fmt.Sprintf("ext(%d, h'%s')", v.typ, hex.EncodeToString(v.data))
+	case decodedSimple:
+		return fmt.Sprintf("simple(%d)", v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// maxDecodeDepth is the maximum nesting of decoded arrays and maps.
+const maxDecodeDepth = 512
+
+// errUnexpectedEnd reports a truncated payload.
+var errUnexpectedEnd = errors.New("unexpected end of data")
+
+// binaryReader reads binary payloads.
+type binaryReader struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// next returns the next n bytes.
+func (r *binaryReader) next(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of n bytes.
+func (r *binaryReader) uint(n int) (uint64, error) {
+	b, err := r.next(uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+// text returns the text string of b, which must be valid UTF-8.
+func (r *binaryReader) text(b []byte) (string, error) {
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("invalid UTF-8 in text string at offset %d", r.pos-len(b))
+	}
+	return string(b), nil
+}
+
+// nest records the start of an array or map.
+func (r *binaryReader) nest() error {
+	r.depth++
+	if r.depth > maxDecodeDepth {
+		return fmt.Errorf("more than %d nested arrays or maps", maxDecodeDepth)
+	}
+	return nil
+}
+
+// decodeAll decodes a single value with decode, rejecting trailing data.
+func decodeAll(data []byte, decode func(*binaryReader) (any, error)) (any, error) {
+	r := &binaryReader{data: data}
+	v, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("unexpected data after value at offset %d", r.pos)
+	}
+	return v, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unhex decodes a payload written in hex, ignoring spaces.
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestFormatDecoded(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, "null"},
+		{big.NewInt(-3), "-3"},
+		{2.0, "2.0"},
+		{math.Inf(-1), "-Infinity"},
+		{[]byte{0xca, 0xfe}, "h'cafe'"},
+		{[]any{"a", true}, `["a", true]`},
+		{decodedMap{{big.NewInt(1), "a"}, {"b", nil}}, `{1: "a", "b": null}`},
+		{decodedTag{number: 1, value: big.NewInt(0)}, "1(0)"},
+		{decodedExt{typ: 5, data: []byte{1}}, "ext(5, h'01')"},
+		{decodedSimple(16), "simple(16)"},
+		{time.Unix(1, 0).UTC(), "1970-01-01T00:00:01Z"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDecoded(tt.value); got != tt.want {
+			t.Errorf("formatDecoded(%#v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDiffDecoded(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   any
+		expected any
+		want     string
+	}{
+		{"equal", decodedMap{{"a", big.NewInt(1)}}, decodedMap{{"a", big.NewInt(1)}}, ""},
+		{"NaN", math.NaN(), math.NaN(), ""},
+		{"integer", big.NewInt(1), big.NewInt(2), "$: expected 2, got 1"},
+		{"kinds", big.NewInt(1), 1.0, "$: expected float, got integer"},
+		{"keys", decodedMap{{"a", nil}, {"c", nil}}, decodedMap{{"b", nil}, {"a", nil}}, "$.b: missing, expected null; $.c: unexpected, got null"},
+		{"tags", decodedTag{1, nil}, decodedTag{0, nil}, "$: expected tag 0, got tag 1"},
+		{"arrays", []any{nil}, []any{nil, true}, "$: expected 2 elements, got 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(diffDecoded(tt.actual, tt.expected, "$"), "; "); got != tt.want {
+				t.Errorf("diffDecoded() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import "testing"
+
+// WithCallerSkip returns a copy of a locating its failures skip more
+// frames above the caller of the assertions, for shared helpers wrapping
+// them, like the skip argument of runtime.Caller:
+//
+//	func checkUser(t *testing.T, u User) {
+//	    a := assert.New(t).WithCallerSkip(1)
+//	    assert.Equal(a, u.Name, "alice")
+//	}
+//
+// The testing package locates failures on the line of the helper unless
+// it calls t.Helper, so the line of the test is prefixed to the failure
+// message, such as "user_test.go:42: ". It is also the Location of the
+// failure templates. Skips add up across nested Assert values.
+func (a *Assert) WithCallerSkip(skip int) *Assert {
+	child := *a
+	child.callerSkip += skip
+	return &child
+}
+
+// lookupCallerSkip returns the number of frames skipped above the caller
+// of the assertions reported to t.
+func lookupCallerSkip(t testing.TB) int {
+	skip := 0
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			return skip
+		}
+		skip += a.callerSkip
+		t = a.TB
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// checkPositive is a shared helper wrapping an assertion.
+func checkPositive(t testing.TB, n int) {
+	a := New(t).WithCallerSkip(1)
+	Greater(a, n, 0)
+}
+
+// nextLine returns the location of the line after its caller.
+func nextLine() string {
+	_, file, n, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), n+1)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	t.Run("location of the test", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		want := nextLine()
+		checkPositive(rec, -1)
+
+		if !strings.HasPrefix(rec.ErrorMessage(), want+"\n") {
+			t.Errorf("message = %q, want prefix %q", rec.ErrorMessage(), want)
+		}
+	})
+
+	t.Run("with labels", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		want := nextLine()
+		checkPositive(New(rec).WithLabel("user 42"), -1)
+
+		if !strings.HasPrefix(rec.ErrorMessage(), want+": user 42\n") {
+			t.Errorf("message = %q, want prefix %q", rec.ErrorMessage(), want)
+		}
+	})
+
+	t.Run("skips add up", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec).WithCallerSkip(1)
+
+		want := nextLine()
+		func() { checkPositive(a, -1) }()
+
+		Equal(t, lookupCallerSkip(New(a).WithCallerSkip(1)), 2)
+		if !strings.HasPrefix(rec.ErrorMessage(), want+"\n") {
+			t.Errorf("message = %q, want prefix %q", rec.ErrorMessage(), want)
+		}
+	})
+
+	t.Run("no skip", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Greater(New(rec), -1, 0)
+
+		if !strings.HasPrefix(rec.ErrorMessage(), "\n") {
+			t.Errorf("message = %q, want no location", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("template location", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(template.Must(template.New("").Parse("at {{.Location}}")))
+
+		want := nextLine()
+		checkPositive(a, -1)
+
+		Equal(t, rec.ErrorMessage(), want+": at "+want)
+	})
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// The major types of CBOR data items.
+const (
+	cborUnsigned = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// cborIndefinite is the additional information of indefinite lengths,
+// and of the break code when used with the simple major type.
+const cborIndefinite = 31
+
+// errCBORBreak is returned when a break code ends an indefinite length item.
+var errCBORBreak = errors.New("unexpected break code")
+
+// decodeCBOR decodes a CBOR payload.
+func decodeCBOR(data []byte) (any, error) {
+	return decodeAll(data, readCBOR)
+}
+
+// readCBOR reads a single data item.
+func readCBOR(r *binaryReader) (any, error) {
+	start := r.pos
+	head, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	major, info := head[0]>>5, head[0]&0x1f
+
+	if major == cborSimple {
+		return readCBORSimple(r, info)
+	}
+
+	if info == cborIndefinite {
+		switch major {
+		case cborBytes, cborText:
+			return readCBORChunks(r, major)
+		case cborArray, cborMap:
+			return readCBORContainer(r, major, 0, true)
+		}
+		return nil, fmt.Errorf("invalid indefinite length at offset %d", start)
+	}
+
+	arg, err := readCBORArgument(r, info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return new(big.Int).SetUint64(arg), nil
+	case cborNegative:
+		n := new(big.Int).SetUint64(arg)
+		return n.Neg(n.Add(n, big.NewInt(1))), nil
+	case cborBytes:
+		b, err := r.next(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case cborText:
+		b, err := r.next(arg)
+		if err != nil {
+			return nil, err
+		}
+		return r.text(b)
+	case cborArray, cborMap:
+		return readCBORContainer(r, major, arg, false)
+	default:
+		v, err := readCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		// Bignums are integers.
+		if b, ok := v.([]byte); ok && (arg == 2 || arg == 3) {
+			n := new(big.Int).SetBytes(b)
+			if arg == 3 {
+				n.Neg(n.Add(n, big.NewInt(1)))
+			}
+			return n, nil
+		}
+		return decodedTag{number: arg, value: v}, nil
+	}
+}
+
+// readCBORArgument reads the argument of a data item, given by its
+// additional information.
+func readCBORArgument(r *binaryReader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return r.uint(1 << (info - 24))
+	}
+	return 0, fmt.Errorf("invalid additional information %d at offset %d", info, r.pos-1)
+}
+
+func readCBORSimple(r *binaryReader, info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22:
+		return nil, nil
+	case 24:
+		n, err := r.uint(1)
+		if err != nil {
+			return nil, err
+		}
+		return decodedSimple(n), nil
+	case 25:
+		bits, err := r.uint(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfFloat(uint16(bits)), nil
+	case 26:
+		bits, err := r.uint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(bits))), nil
+	case 27:
+		bits, err := r.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(bits), nil
+	case cborIndefinite:
+		return nil, errCBORBreak
+	}
+	if info < 24 {
+		return decodedSimple(info), nil
+	}
+	return nil, fmt.Errorf("invalid simple value %d at offset %d", info, r.pos-1)
+}
+
+// readCBORChunks reads an indefinite length string, made of definite
+// length chunks of the same major type.
+func readCBORChunks(r *binaryReader, major byte) (any, error) {
+	var b []byte
+	for {
+		v, err := readCBOR(r)
+		if err == errCBORBreak {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch chunk := v.(type) {
+		case []byte:
+			if major != cborBytes {
+				return nil, fmt.Errorf("invalid chunk of indefinite length string at offset %d", r.pos)
+			}
+			b = append(b, chunk...)
+		case string:
+			if major != cborText {
+				return nil, fmt.Errorf("invalid chunk of indefinite length string at offset %d", r.pos)
+			}
+			b = append(b, chunk...)
+		default:
+			return nil, fmt.Errorf("invalid chunk of indefinite length string at offset %d", r.pos)
+		}
+	}
+	if major == cborText {
+		return string(b), nil
+	}
+	if b == nil {
+		b = []byte{}
+	}
+	return b, nil
+}
+
+// readCBORContainer reads the n items of an array, or the n entries of
+// a map, up to a break code when indefinite.
+func readCBORContainer(r *binaryReader, major byte, n uint64, indefinite bool) (any, error) {
+	if err := r.nest(); err != nil {
+		return nil, err
+	}
+	defer func() { r.depth-- }()
+
+	list := []any{}
+	var m decodedMap
+	for i := uint64(0); indefinite || i < n; i++ {
+		v, err := readCBOR(r)
+		if err == errCBORBreak && indefinite {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if major == cborArray {
+			list = append(list, v)
+			continue
+		}
+		value, err := readCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, decodedEntry{key: v, value: value})
+	}
+
+	if major == cborArray {
+		return list, nil
+	}
+	if m == nil {
+		m = decodedMap{}
+	}
+	return m, nil
+}
+
+// halfFloat converts an IEEE 754 half-precision float.
+func halfFloat(bits uint16) float64 {
+	exp, mant := int(bits>>10)&0x1f, float64(bits&0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if bits&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
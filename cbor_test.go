@@ -0,0 +1,92 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCBOREq(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		expected  string
+		wantError bool
+		wantNote  string
+	}{
+		{"same bytes", "a1 61 61 01", "a1 61 61 01", false, ""},
+		{"indefinite lengths", "bf 61 61 01 61 62 9f 02 03 ff ff", "a2 61 61 01 61 62 82 02 03", false, ""},
+		{"key order", "a2 61 62 02 61 61 01", "a2 61 61 01 61 62 02", false, ""},
+		{"float sizes", "f9 3e 00", "fb 3f f8 00 00 00 00 00 00", false, ""},
+		{"bignum", "c2 48 ff ff ff ff ff ff ff ff", "1b ff ff ff ff ff ff ff ff", false, ""},
+		{"negative", "39 03 e7", "3b 00 00 00 00 00 00 03 e7", false, ""},
+		{"indefinite text", "7f 62 73 74 62 72 65 ff", "64 73 74 72 65", false, ""},
+		{"different value", "a1 61 61 01", "a1 61 61 02", true, "$.a: expected 2, got 1"},
+		{"integer keys", "a1 01 f5", "a1 01 f4", true, "$[1]: expected false, got true"},
+		{"tags", "c1 1a 51 4b 67 b0", "c0 1a 51 4b 67 b0", true, "$: expected tag 0, got tag 1"},
+		{"bytes and text", "42 68 69", "62 68 69", true, "$: expected text string, got byte string"},
+		{"simple values", "f7", "f6", true, "$: expected null, got simple value"},
+		{"truncated", "82 01", "82 01 02", true, "invalid actual CBOR: unexpected end of data"},
+		{"trailing data", "01", "01 01", true, "invalid expected CBOR: unexpected data after value at offset 1"},
+		{"invalid text", "61 ff", "61 61", true, "invalid UTF-8 in text string at offset 1"},
+		{"unexpected break", "81 ff", "80", true, "unexpected break code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CBOREq(rec, unhex(tt.actual), unhex(tt.expected))
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CBOREq() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("CBOREq() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("hex dump of undecodable payloads", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		CBOREq(rec, unhex("82 01"), unhex("82 01 02"))
+
+		for _, want := range []string{"-00000000  82 01 02", "+00000000  82 01 "} {
+			if !strings.Contains(rec.ErrorMessage(), want) {
+				t.Errorf("CBOREq() message missing %q\ngot: %s", want, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("diagnostic notation", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		CBOREq(rec, unhex("a1 61 61 42 ca fe"), unhex("a1 61 61 f9 3c 00"))
+
+		if !strings.Contains(rec.ErrorMessage(), `"{\"a\": h'cafe'}"`) {
+			t.Errorf("CBOREq() message missing diagnostic notation\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestHalfFloat(t *testing.T) {
+	tests := []struct {
+		bits uint16
+		want float64
+	}{
+		{0x0000, 0},
+		{0x3c00, 1},
+		{0xc400, -4},
+		{0x0001, 5.960464477539063e-08},
+		{0x7bff, 65504},
+	}
+
+	for _, tt := range tests {
+		if got := halfFloat(tt.bits); got != tt.want {
+			t.Errorf("halfFloat(%#04x) = %v, want %v", tt.bits, got, tt.want)
+		}
+	}
+}
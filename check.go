@@ -0,0 +1,217 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Check runs assertions against a TB that records failures instead of
+// reporting them, and returns those failures as an error (nil on success).
+// It lets the comparison and formatting logic be reused in retry loops
+// and custom helpers:
+//
+//	err := assert.Check(func(t testing.TB) {
+//	    assert.Equal(t, got, want)
+//	    assert.Len(t, items, 3)
+//	})
+//
+// Fatal assertions stop fn, as they would stop a test.
+func Check(fn func(t testing.TB)) error {
+	c := &checkTB{}
+	c.run(fn)
+
+	if len(c.failures) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(c.failures, "\n"))
+}
+
+// CheckEqual reports whether actual and expected are equal, as Equal would.
+func CheckEqual[T any](actual, expected T) error {
+	return Check(func(t testing.TB) { Equal(t, actual, expected) })
+}
+
+// CheckNotEqual reports whether actual and expected differ, as NotEqual would.
+func CheckNotEqual[T any](actual, expected T) error {
+	return Check(func(t testing.TB) { NotEqual(t, actual, expected) })
+}
+
+// CheckTrue reports whether value is true, as True would.
+func CheckTrue(value bool) error {
+	return Check(func(t testing.TB) { True(t, value) })
+}
+
+// CheckFalse reports whether value is false, as False would.
+func CheckFalse(value bool) error {
+	return Check(func(t testing.TB) { False(t, value) })
+}
+
+// CheckNil reports whether value is nil, as Nil would.
+func CheckNil(value any) error {
+	return Check(func(t testing.TB) { Nil(t, value) })
+}
+
+// CheckNotNil reports whether value is not nil, as NotNil would.
+func CheckNotNil(value any) error {
+	return Check(func(t testing.TB) { NotNil(t, value) })
+}
+
+// CheckNoError reports whether err is nil, as NoError would.
+func CheckNoError(err error) error {
+	return Check(func(t testing.TB) { NoError(t, err) })
+}
+
+// CheckErrorIs reports whether err matches target, as ErrorIs would.
+func CheckErrorIs(err, target error) error {
+	return Check(func(t testing.TB) { ErrorIs(t, err, target) })
+}
+
+// CheckContains reports whether slice contains element, as Contains would.
+func CheckContains[T any](slice []T, element T) error {
+	return Check(func(t testing.TB) { Contains(t, slice, element) })
+}
+
+// CheckLen reports whether collection has the expected length, as Len would.
+func CheckLen(collection any, expected int) error {
+	return Check(func(t testing.TB) { Len(t, collection, expected) })
+}
+
+// CheckBetween reports whether actual is within [min, max], as Between would.
+func CheckBetween[T Ordered](actual, min, max T) error {
+	return Check(func(t testing.TB) { Between(t, actual, min, max) })
+}
+
+// checkStop is the panic value used by checkTB to stop a check early.
+type checkStop struct{}
+
+// checkTB is a testing.TB recording failures instead of reporting them.
+// The embedded TB is always nil; it only satisfies the unexported
+// method of the testing.TB interface.
+type checkTB struct {
+	testing.TB
+	failures []string
+	logs     []string
+	cleanups []func()
+	skipped  bool
+	failed   bool
+}
+
+func (c *checkTB) run(fn func(t testing.TB)) {
+	defer func() {
+		for i := len(c.cleanups) - 1; i >= 0; i-- {
+			c.cleanups[i]()
+		}
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(checkStop); !ok {
+				panic(r)
+			}
+		}
+	}()
+
+	fn(c)
+}
+
+func (c *checkTB) Cleanup(f func()) {
+	c.cleanups = append(c.cleanups, f)
+}
+
+func (c *checkTB) Error(args ...any) {
+	c.fail(fmt.Sprint(args...))
+}
+
+func (c *checkTB) Errorf(format string, args ...any) {
+	c.fail(fmt.Sprintf(format, args...))
+}
+
+func (c *checkTB) Fail() {
+	c.failed = true
+}
+
+func (c *checkTB) FailNow() {
+	c.failed = true
+	panic(checkStop{})
+}
+
+func (c *checkTB) Failed() bool {
+	return c.failed
+}
+
+func (c *checkTB) Fatal(args ...any) {
+	c.Error(args...)
+	c.FailNow()
+}
+
+func (c *checkTB) Fatalf(format string, args ...any) {
+	c.Errorf(format, args...)
+	c.FailNow()
+}
+
+func (c *checkTB) Helper() {}
+
+func (c *checkTB) Log(args ...any) {
+	c.logs = append(c.logs, fmt.Sprint(args...))
+}
+
+func (c *checkTB) Logf(format string, args ...any) {
+	c.logs = append(c.logs, fmt.Sprintf(format, args...))
+}
+
+func (c *checkTB) Name() string {
+	return "Check"
+}
+
+func (c *checkTB) Setenv(key, value string) {
+	prev, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		c.Fatal(err)
+	}
+	c.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func (c *checkTB) Skip(args ...any) {
+	c.Log(args...)
+	c.SkipNow()
+}
+
+func (c *checkTB) SkipNow() {
+	c.skipped = true
+	panic(checkStop{})
+}
+
+func (c *checkTB) Skipf(format string, args ...any) {
+	c.Logf(format, args...)
+	c.SkipNow()
+}
+
+func (c *checkTB) Skipped() bool {
+	return c.skipped
+}
+
+func (c *checkTB) TempDir() string {
+	dir, err := os.MkdirTemp("", "assert-check-")
+	if err != nil {
+		c.Fatal(err)
+	}
+	c.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+// fail records a failure message without its leading and trailing blank lines.
+func (c *checkTB) fail(msg string) {
+	c.failed = true
+	c.failures = append(c.failures, strings.TrimSpace(msg))
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("success returns nil", func(t *testing.T) {
+		err := Check(func(t testing.TB) {
+			Equal(t, 1, 1)
+			Len(t, []int{1, 2}, 2)
+		})
+
+		if err != nil {
+			t.Errorf("Check() = %v, want nil", err)
+		}
+	})
+
+	t.Run("failures are collected", func(t *testing.T) {
+		err := Check(func(t testing.TB) {
+			Equal(t, 1, 2)
+			True(t, false, "must be true")
+		})
+
+		if err == nil {
+			t.Fatal("Check() = nil, want error")
+		}
+		for _, part := range []string{"Expected: (int) 2", "Message: must be true"} {
+			if !strings.Contains(err.Error(), part) {
+				t.Errorf("Check() error missing %q\ngot: %s", part, err)
+			}
+		}
+	})
+
+	t.Run("fatal stops the check", func(t *testing.T) {
+		reached := false
+
+		err := Check(func(t testing.TB) {
+			t.Fatal("stop")
+			reached = true
+		})
+
+		if err == nil || err.Error() != "stop" {
+			t.Errorf("Check() = %v, want stop", err)
+		}
+		if reached {
+			t.Error("Check() continued after Fatal")
+		}
+	})
+
+	t.Run("skip stops without failure", func(t *testing.T) {
+		err := Check(func(t testing.TB) {
+			t.Skip("not relevant")
+			t.Error("unreachable")
+		})
+
+		if err != nil {
+			t.Errorf("Check() = %v, want nil", err)
+		}
+	})
+
+	t.Run("cleanups run in reverse order", func(t *testing.T) {
+		var order []int
+
+		_ = Check(func(t testing.TB) {
+			t.Cleanup(func() { order = append(order, 1) })
+			t.Cleanup(func() { order = append(order, 2) })
+		})
+
+		Equal(t, order, []int{2, 1})
+	})
+
+	t.Run("setenv and tempdir are restored", func(t *testing.T) {
+		var dir string
+
+		_ = Check(func(t testing.TB) {
+			t.Setenv("ASSERT_CHECK_TEST", "1")
+			dir = t.TempDir()
+		})
+
+		_, ok := os.LookupEnv("ASSERT_CHECK_TEST")
+		False(t, ok)
+
+		_, err := os.Stat(dir)
+		True(t, os.IsNotExist(err))
+	})
+
+	t.Run("other panics propagate", func(t *testing.T) {
+		Panics(t, func() {
+			_ = Check(func(t testing.TB) { panic("boom") })
+		}, "boom")
+	})
+}
+
+func TestCheckFunctions(t *testing.T) {
+	errBase := errors.New("base")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "CheckEqual pass", err: CheckEqual("a", "a")},
+		{name: "CheckEqual fail", err: CheckEqual("a", "b"), wantErr: true},
+		{name: "CheckNotEqual pass", err: CheckNotEqual(1, 2)},
+		{name: "CheckNotEqual fail", err: CheckNotEqual(1, 1), wantErr: true},
+		{name: "CheckTrue fail", err: CheckTrue(false), wantErr: true},
+		{name: "CheckFalse pass", err: CheckFalse(false)},
+		{name: "CheckNil pass", err: CheckNil(nil)},
+		{name: "CheckNotNil fail", err: CheckNotNil(nil), wantErr: true},
+		{name: "CheckNoError fail", err: CheckNoError(errBase), wantErr: true},
+		{name: "CheckErrorIs pass", err: CheckErrorIs(errBase, errBase)},
+		{name: "CheckContains fail", err: CheckContains([]int{1}, 2), wantErr: true},
+		{name: "CheckLen pass", err: CheckLen("abc", 3)},
+		{name: "CheckBetween fail", err: CheckBetween(11, 1, 10), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", tt.err, tt.wantErr)
+			}
+		})
+	}
+}
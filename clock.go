@@ -0,0 +1,119 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock is the source of time of the assertions waiting for time to pass,
+// such as AtomicEventually, Retry and DialSucceeds. Setting a FakeClock
+// makes them deterministic and instant. RunsWithin measures real execution
+// time, and always reads the system clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep pauses for at least d.
+	Sleep(d time.Duration)
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+var (
+	clockMu sync.RWMutex
+	clock   Clock
+)
+
+// SetClock sets the clock of the assertions of all tests, or restores the
+// system clock when c is nil. It returns the previous clock, nil for the
+// system clock. Assert.SetClock sets the clock of a single Assert.
+func SetClock(c Clock) Clock {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	prev := clock
+	clock = c
+	return prev
+}
+
+// SetClock sets the clock of the assertions made through a, and the Assert
+// of its subtests, like the package-level SetClock. A nil clock uses the
+// package-level clock:
+//
+//	clk := assert.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+//	a := assert.New(t)
+//	a.SetClock(clk)
+//	assert.AtomicEventually(a, &ready, true, time.Minute) // no real wait
+func (a *Assert) SetClock(c Clock) {
+	a.clock = c
+}
+
+// lookupClock returns the clock of the assertions made through t.
+func lookupClock(t testing.TB) Clock {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			break
+		}
+		if a.clock != nil {
+			return a.clock
+		}
+		t = a.TB
+	}
+
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+
+	if clock == nil {
+		return systemClock{}
+	}
+	return clock
+}
+
+// FakeClock is a Clock whose time only moves when told to. Sleep advances
+// the time instead of waiting. It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Sleep advances the clock by d, without waiting.
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
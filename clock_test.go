@@ -0,0 +1,137 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var clockStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClock(t *testing.T) {
+	clk := NewFakeClock(clockStart)
+
+	clk.Advance(time.Minute)
+	Equal(t, clk.Now(), clockStart.Add(time.Minute))
+
+	clk.Sleep(time.Second)
+	Equal(t, clk.Now(), clockStart.Add(time.Minute+time.Second))
+
+	clk.Set(clockStart)
+	Equal(t, clk.Now(), clockStart)
+}
+
+// loader counts its loads, and returns true from the nth one.
+type loader struct {
+	loads int32
+	n     int32
+}
+
+func (l *loader) Load() bool {
+	return atomic.AddInt32(&l.loads, 1) >= l.n
+}
+
+func TestClockConsumers(t *testing.T) {
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB, clk *FakeClock)
+		wantError bool
+		wantParts []string
+		wantTime  time.Duration
+	}{
+		{
+			name: "AtomicEventually reached",
+			assert: func(t testing.TB, clk *FakeClock) {
+				AtomicEventually[bool](t, &loader{n: 3}, true, time.Hour)
+			},
+			wantTime: 2 * pollInterval,
+		},
+		{
+			name: "AtomicEventually timeout",
+			assert: func(t testing.TB, clk *FakeClock) {
+				AtomicEventually[bool](t, &loader{n: 1000}, true, time.Second)
+			},
+			wantError: true,
+			wantParts: []string{"value not reached after 101 loads in 1s"},
+			wantTime:  100 * pollInterval,
+		},
+		{
+			name: "Retry",
+			assert: func(t testing.TB, clk *FakeClock) {
+				Retry(t, 3, time.Minute, func(a *Assert) { False(a, true) })
+			},
+			wantError: true,
+			wantParts: []string{"failed after 3 attempts, 1m0s apart"},
+			wantTime:  2 * time.Minute,
+		},
+		{
+			name: "RunsWithin ignores the clock",
+			assert: func(t testing.TB, clk *FakeClock) {
+				RunsWithin(t, time.Second, func() { clk.Sleep(2 * time.Second) })
+			},
+			wantTime: 2 * time.Second,
+		},
+		{
+			name: "RunsWithin measures real time",
+			assert: func(t testing.TB, clk *FakeClock) {
+				RunsWithin(t, time.Millisecond, func() { time.Sleep(10 * time.Millisecond) })
+			},
+			wantError: true,
+			wantParts: []string{"completion within 1ms"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			clk := NewFakeClock(clockStart)
+			a := New(rec)
+			a.SetClock(clk)
+
+			start := time.Now()
+			tt.assert(a, clk)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+			Equal(t, clk.Now().Sub(clockStart), tt.wantTime)
+			Less(t, time.Since(start), time.Second, "fake clock waited")
+		})
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	global := NewFakeClock(clockStart)
+	defer SetClock(SetClock(global))
+
+	t.Run("package-level clock", func(t *testing.T) {
+		Equal(t, lookupClock(t), Clock(global))
+	})
+
+	t.Run("Assert clock", func(t *testing.T) {
+		local := NewFakeClock(clockStart)
+		a := New(t)
+		a.SetClock(local)
+
+		Equal(t, lookupClock(New(a)), Clock(local))
+		a.Run("child", func(a *Assert) {
+			Equal(t, lookupClock(a), Clock(local))
+		})
+	})
+
+	t.Run("system clock", func(t *testing.T) {
+		SetClock(nil)
+		defer SetClock(global)
+
+		Equal(t, lookupClock(t), Clock(systemClock{}))
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	closersMu sync.Mutex
+	closers   = map[testing.TB]*closerSet{}
+)
+
+// closerSet holds the closers tracked for a test.
+type closerSet struct {
+	mu      sync.Mutex
+	tracked []*trackedCloser
+}
+
+// trackedCloser is a Closer returned by TrackCloser.
+type trackedCloser struct {
+	io.Closer
+	location string
+
+	mu     sync.Mutex
+	closes int
+}
+
+func (c *trackedCloser) Close() error {
+	c.mu.Lock()
+	c.closes++
+	c.mu.Unlock()
+
+	return c.Closer.Close()
+}
+
+// trackedReadCloser is a ReadCloser returned by TrackReadCloser.
+type trackedReadCloser struct {
+	io.Reader
+	*trackedCloser
+}
+
+// TrackCloser returns a Closer closing c, whose calls are counted for
+// AllClosed. The code under test is given the returned Closer instead of c.
+// The Closer hides the other methods of c; TrackReadCloser keeps reading.
+func TrackCloser(t testing.TB, c io.Closer) io.Closer {
+	t.Helper()
+
+	return trackCloser(t, c)
+}
+
+// TrackReadCloser is like TrackCloser for a ReadCloser, such as a request
+// or response body, which the returned ReadCloser reads from:
+//
+//	assert.AllClosed(t)
+//	body := assert.TrackReadCloser(t, io.NopCloser(strings.NewReader(data)))
+//	Consume(body)
+func TrackReadCloser(t testing.TB, rc io.ReadCloser) io.ReadCloser {
+	t.Helper()
+
+	return trackedReadCloser{Reader: rc, trackedCloser: trackCloser(t, rc)}
+}
+
+// trackCloser registers c with the closers tracked for t.
+func trackCloser(t testing.TB, c io.Closer) *trackedCloser {
+	tc := &trackedCloser{Closer: c, location: callerLocation(t)}
+	set := testClosers(t)
+	set.mu.Lock()
+	set.tracked = append(set.tracked, tc)
+	set.mu.Unlock()
+	return tc
+}
+
+// AllClosed checks at the end of the test, once its other cleanups have
+// run, that every Closer returned by TrackCloser or TrackReadCloser was
+// closed exactly once.
+// Closers never closed and closed several times are reported with the
+// location where they were tracked. AllClosed is called at the start of
+// the test, so that the cleanups closing resources run before the check.
+func AllClosed(t testing.TB, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	set := testClosers(t)
+	t.Cleanup(func() {
+		t.Helper()
+
+		set.mu.Lock()
+		tracked := append([]*trackedCloser(nil), set.tracked...)
+		set.mu.Unlock()
+
+		var notes []string
+		for _, c := range tracked {
+			c.mu.Lock()
+			closes := c.closes
+			c.mu.Unlock()
+
+			switch {
+			case closes == 0:
+				notes = append(notes, fmt.Sprintf("%T tracked at %s never closed", c.Closer, c.location))
+			case closes > 1:
+				notes = append(notes, fmt.Sprintf("%T tracked at %s closed %d times", c.Closer, c.location, closes))
+			}
+		}
+		if len(notes) == 0 {
+			return
+		}
+
+		closed := len(tracked) - len(notes)
+		if len(notes) > maxSliceDiffs {
+			notes = append(notes[:maxSliceDiffs], fmt.Sprintf("and %d more", len(notes)-maxSliceDiffs))
+		}
+		failCompareNote(t, fmt.Sprintf("%d of %d closers closed once", closed, len(tracked)),
+			fmt.Sprintf("%d of %d closers closed once", len(tracked), len(tracked)),
+			strings.Join(notes, "; "), withMessage("closers not closed exactly once", msg)...)
+	})
+}
+
+// testClosers returns the closers tracked for the test t, forgotten when
+// the test completes.
+func testClosers(t testing.TB) *closerSet {
+	root := rootTB(t)
+	closersMu.Lock()
+	defer closersMu.Unlock()
+
+	set, ok := closers[root]
+	if !ok {
+		set = &closerSet{}
+		closers[root] = set
+		root.Cleanup(func() {
+			closersMu.Lock()
+			defer closersMu.Unlock()
+
+			delete(closers, root)
+		})
+	}
+	return set
+}
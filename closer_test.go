@@ -0,0 +1,120 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// errCloser is a Closer returning err.
+type errCloser struct{ err error }
+
+func (c errCloser) Close() error { return c.err }
+
+func TestAllClosed(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(t testing.TB)
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name: "nothing tracked",
+			run:  func(t testing.TB) {},
+		},
+		{
+			name: "closed once",
+			run: func(t testing.TB) {
+				_ = TrackCloser(t, errCloser{}).Close()
+			},
+		},
+		{
+			name: "closed by a later cleanup",
+			run: func(t testing.TB) {
+				c := TrackCloser(t, errCloser{})
+				t.Cleanup(func() { _ = c.Close() })
+			},
+		},
+		{
+			name: "never closed",
+			run: func(t testing.TB) {
+				TrackCloser(t, errCloser{})
+				_ = TrackCloser(t, errCloser{}).Close()
+			},
+			wantError: true,
+			wantParts: []string{
+				"closers not closed exactly once",
+				"1 of 2 closers closed once",
+				"assert.errCloser tracked at closer_test.go:",
+				"never closed",
+			},
+		},
+		{
+			name: "closed twice",
+			run: func(t testing.TB) {
+				c := TrackCloser(New(t), io.NopCloser(strings.NewReader("")))
+				_ = c.Close()
+				_ = c.Close()
+			},
+			wantError: true,
+			wantParts: []string{"closed 2 times", "0 of 1 closers closed once"},
+		},
+		{
+			name: "read closer never closed",
+			run: func(t testing.TB) {
+				_, _ = io.ReadAll(TrackReadCloser(t, io.NopCloser(strings.NewReader("body"))))
+			},
+			wantError: true,
+			wantParts: []string{"0 of 1 closers closed once", "tracked at closer_test.go:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			AllClosed(rec)
+			tt.run(rec)
+			rec.RunCleanups()
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("AllClosed() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestTrackCloser(t *testing.T) {
+	rec := NewTestRecorder(t)
+	want := errors.New("close failed")
+
+	c := TrackCloser(rec, errCloser{err: want})
+
+	ErrorIs(t, c.Close(), want)
+	rec.RunCleanups()
+	_, tracked := closers[rec]
+	False(t, tracked, "closers forgotten at cleanup")
+}
+
+func TestTrackReadCloser(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	AllClosed(rec)
+	body := TrackReadCloser(rec, io.NopCloser(strings.NewReader("body")))
+	data, err := io.ReadAll(body)
+	NoError(t, err)
+	Equal(t, string(data), "body")
+	NoError(t, body.Close())
+	rec.RunCleanups()
+
+	False(t, rec.HasError(), rec.ErrorMessage())
+}
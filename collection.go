@@ -7,14 +7,17 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 // Contains checks if a slice contains a specific element.
 // The comparison is done using reflection.DeepEqual.
-func Contains[T any](t testing.TB, slice []T, element T) {
+func Contains[T any](t testing.TB, slice []T, element T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	for _, v := range slice {
 		if isEqual(v, element) {
@@ -22,61 +25,342 @@ func Contains[T any](t testing.TB, slice []T, element T) {
 		}
 	}
 
-	failCompare[any](t, element, slice, "slice does not contain expected element")
+	failCompare[any](t, slice, fmt.Sprintf("should contain %#v", element),
+		withMessage("slice does not contain expected element", msg)...)
+}
+
+// EqualBy checks if two slices hold elements with the same keys, index by index.
+// It compares slices of structs by ID, ignoring the other fields:
+//
+//	assert.EqualBy(t, got, want, func(u User) int { return u.ID })
+func EqualBy[T any, K comparable](t testing.TB, actual, expected []T, key func(T) K, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	note := sliceDiff(len(actual), len(expected),
+		func(i int) bool { return key(actual[i]) == key(expected[i]) },
+		func(i int) string {
+			return fmt.Sprintf("expected key %#v, got %#v", key(expected[i]), key(actual[i]))
+		},
+	)
+	if note != "" {
+		failCompareNote(t, actual, expected, note, withMessage("slices differ by key", msg)...)
+	}
+}
+
+// EqualKeyed checks if two slices hold equal elements, matching them by
+// key in any order. Matched elements are compared field by field, and
+// elements without a match are reported separately, so that long lists of
+// records show only what differs:
+//
+//	assert.EqualKeyed(t, got, want, func(u User) int { return u.ID })
+//
+// Failures read like "key 2: Name: expected "alice", got "bob"".
+func EqualKeyed[T any, K comparable](t testing.TB, actual, expected []T, key func(T) K, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	actualByKey := make(map[K]int, len(actual))
+	var diffs []string
+	for i, v := range actual {
+		k := key(v)
+		if _, ok := actualByKey[k]; ok {
+			diffs = append(diffs, fmt.Sprintf("key %#v: duplicate in actual", k))
+			continue
+		}
+		actualByKey[k] = i
+	}
+
+	matched := make(map[K]bool, len(expected))
+	for _, e := range expected {
+		k := key(e)
+		if matched[k] {
+			diffs = append(diffs, fmt.Sprintf("key %#v: duplicate in expected", k))
+			continue
+		}
+		matched[k] = true
+
+		i, ok := actualByKey[k]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("key %#v: missing, expected %#v", k, e))
+			continue
+		}
+		if isEqual(actual[i], e) {
+			continue
+		}
+		for _, d := range diffFields(&equalizer{}, reflect.ValueOf(e), reflect.ValueOf(actual[i]), "", 0) {
+			diffs = append(diffs, fmt.Sprintf("key %#v: %s", k, d))
+		}
+	}
+	for _, a := range actual {
+		if k := key(a); !matched[k] {
+			matched[k] = true
+			diffs = append(diffs, fmt.Sprintf("key %#v: unexpected, got %#v", k, a))
+		}
+	}
+
+	if len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, actual, expected, strings.Join(diffs, "; "),
+			withMessage("keyed elements differ", msg)...)
+	}
+}
+
+// diffFields lists the differences between the expected value x and the
+// actual value y, down to the fields of structs and the elements of slices
+// of equal lengths. Each difference is prefixed by its path, such as
+// "Address.City" or "Tags[1]".
+func diffFields(e *equalizer, x, y reflect.Value, path string, depth int) []string {
+	e.visited = map[visit]bool{}
+	if e.deepEqual(x, y) {
+		return nil
+	}
+
+	if depth < maxExplainDepth && x.IsValid() && y.IsValid() && x.Type() == y.Type() {
+		var diffs []string
+		switch x.Kind() {
+		case reflect.Struct:
+			for i := 0; i < x.NumField(); i++ {
+				field := x.Type().Field(i)
+				if e.skipField(x.Type(), field) {
+					continue
+				}
+				name := strings.TrimPrefix(path+"."+field.Name, ".")
+				diffs = append(diffs, diffFields(e, x.Field(i), y.Field(i), name, depth+1)...)
+			}
+			return diffs
+		case reflect.Ptr, reflect.Interface:
+			if !x.IsNil() && !y.IsNil() {
+				return diffFields(e, x.Elem(), y.Elem(), path, depth+1)
+			}
+		case reflect.Slice, reflect.Array:
+			if x.Len() == y.Len() && (x.Kind() == reflect.Array || x.IsNil() == y.IsNil()) {
+				for i := 0; i < x.Len(); i++ {
+					name := fmt.Sprintf("%s[%d]", path, i)
+					diffs = append(diffs, diffFields(e, x.Index(i), y.Index(i), name, depth+1)...)
+				}
+				return diffs
+			}
+		}
+	}
+
+	if path == "" {
+		return []string{fmt.Sprintf("expected %s, got %s", formatField(x), formatField(y))}
+	}
+	return []string{fmt.Sprintf("%s: expected %s, got %s", path, formatField(x), formatField(y))}
+}
+
+// formatField formats a value compared by diffFields, which is invalid
+// for nil interfaces.
+func formatField(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// ContainsFunc checks if at least one element of a slice satisfies a
+// predicate described by desc. It matches elements by a single field
+// without building a full expected element:
+//
+//	assert.ContainsFunc(t, users, func(u User) bool { return u.Email == "alice@example.com" }, "has alice")
+func ContainsFunc[T any](t testing.TB, slice []T, pred func(T) bool, desc string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	for _, v := range slice {
+		if pred(v) {
+			return
+		}
+	}
+
+	failCompare[any](t, slice, "element to satisfy: "+desc,
+		withMessage(fmt.Sprintf("none of %d elements satisfied: %s", len(slice), desc), msg)...)
+}
+
+// CountEquals checks if an element occurs exactly n times in a slice.
+// On failure, the observed count and the indexes of the occurrences are reported.
+func CountEquals[T any](t testing.TB, slice []T, element T, n int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	var positions []int
+	for i, v := range slice {
+		if isEqual(v, element) {
+			positions = append(positions, i)
+		}
+	}
+
+	if len(positions) != n {
+		failCompare(t, len(positions), n,
+			withMessage(fmt.Sprintf("unexpected count of %#v (at indexes %v)", element, positions), msg)...)
+	}
 }
 
 // Empty checks if a collection (slice, map, string, or array) is empty.
 // It provides a clear error message if the collection contains elements.
+//
+// Values with a Len() int method (such as *bytes.Buffer) are empty when
+// their length is zero, and values with an IsZero() bool method (such as
+// time.Time) are empty when zero, so domain types can be checked as well.
 func Empty(t testing.TB, collection any, msg ...string) {
 	t.Helper()
-	v := reflect.ValueOf(collection)
+	defer observe(t)()
 
-	switch v.Kind() {
-	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
-		if v.Len() != 0 {
-			failCompare(t,
-				"empty collection",
-				fmt.Sprintf("collection with length %d", v.Len()),
-				msg...,
-			)
-		}
-	default:
+	empty, desc, ok := emptiness(collection)
+	if !ok {
 		t.Errorf("\nEmpty() called with unsupported type: (%T)", collection)
+		return
+	}
+	if !empty {
+		failCompare(t, desc, "empty collection", msg...)
+	}
+}
+
+// EmptyMap checks if a map is empty.
+// Unlike Empty, the argument type is checked at compile time.
+func EmptyMap[K comparable, V any](t testing.TB, m map[K]V, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if len(m) != 0 {
+		failCompare(t, fmt.Sprintf("map with length %d", len(m)), "empty map", msg...)
+	}
+}
+
+// EmptySlice checks if a slice is empty.
+// Unlike Empty, the argument type is checked at compile time.
+func EmptySlice[T any](t testing.TB, slice []T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if len(slice) != 0 {
+		failCompare(t, fmt.Sprintf("slice with length %d", len(slice)), "empty slice", msg...)
 	}
 }
 
 // HasKey checks if a map contains a specific key.
-func HasKey[K comparable, V any](t testing.TB, m map[K]V, key K) {
+func HasKey[K comparable, V any](t testing.TB, m map[K]V, key K, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if _, ok := m[key]; !ok {
-		failCompare[any](t, key, m, "map does not contain expected key")
+		failCompare[any](t, m, fmt.Sprintf("should contain key %#v", key),
+			withMessage("map does not contain expected key", msg)...)
 	}
 }
 
+// KeySetEquals checks if a map has exactly the expected keys, in any order,
+// whatever their values. Missing and extra keys are listed on failure:
+//
+//	assert.KeySetEquals(t, registry.Metrics(), []string{"requests_total", "latency_seconds"})
+func KeySetEquals[K comparable, V any](t testing.TB, m map[K]V, expectedKeys []K, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	expected := make(map[K]bool, len(expectedKeys))
+	var missing []string
+	for _, k := range expectedKeys {
+		if expected[k] {
+			continue
+		}
+		expected[k] = true
+		if _, ok := m[k]; !ok {
+			missing = append(missing, fmt.Sprintf("%#v", k))
+		}
+	}
+
+	var extra []string
+	for k := range m {
+		if !expected[k] {
+			extra = append(extra, fmt.Sprintf("%#v", k))
+		}
+	}
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return
+	}
+
+	var notes []string
+	if len(missing) > 0 {
+		notes = append(notes, "missing keys: "+joinSorted(missing))
+	}
+	if len(extra) > 0 {
+		notes = append(notes, "extra keys: "+joinSorted(extra))
+	}
+
+	actual := make([]K, 0, len(m))
+	for k := range m {
+		actual = append(actual, k)
+	}
+	sort.Slice(actual, func(i, j int) bool {
+		return fmt.Sprintf("%#v", actual[i]) < fmt.Sprintf("%#v", actual[j])
+	})
+
+	failCompareNote(t, actual, expectedKeys, strings.Join(notes, "; "),
+		withMessage("map keys differ", msg)...)
+}
+
+// joinSorted sorts the formatted values and joins them, limited to
+// maxSliceDiffs values.
+func joinSorted(values []string) string {
+	sort.Strings(values)
+	if len(values) > maxSliceDiffs {
+		values = append(values[:maxSliceDiffs], fmt.Sprintf("and %d more", len(values)-maxSliceDiffs))
+	}
+	return strings.Join(values, ", ")
+}
+
 // HasPrefix checks if a string starts with an expected prefix.
 // Useful for testing string formatting, paths, or URLs.
 func HasPrefix(t testing.TB, s, prefix string, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !strings.HasPrefix(s, prefix) {
 		failCompare(t, s, fmt.Sprintf("should start with %q", prefix), msg...)
 	}
 }
 
+// HasPrefixFold checks if a string starts with an expected prefix,
+// under Unicode case-folding. Useful for headers and user input.
+func HasPrefixFold(t testing.TB, s, prefix string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if !hasPrefixFold(s, prefix) {
+		failCompare(t, s, fmt.Sprintf("should start with %q (case-insensitive)", prefix), msg...)
+	}
+}
+
 // HasSuffix checks if a string ends with an expected suffix.
 // Useful for testing file extensions, domains, etc.
 func HasSuffix(t testing.TB, s, suffix string, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !strings.HasSuffix(s, suffix) {
 		failCompare(t, s, fmt.Sprintf("should end with %q", suffix), msg...)
 	}
 }
 
+// HasSuffixFold checks if a string ends with an expected suffix,
+// under Unicode case-folding. Useful for file extensions and domains.
+func HasSuffixFold(t testing.TB, s, suffix string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if !hasSuffixFold(s, suffix) {
+		failCompare(t, s, fmt.Sprintf("should end with %q (case-insensitive)", suffix), msg...)
+	}
+}
+
 // Len checks if a collection (slice, array, map, or string) has the expected length.
-func Len(t testing.TB, collection any, expected int) {
+func Len(t testing.TB, collection any, expected int, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	v := reflect.ValueOf(collection)
 	switch v.Kind() {
@@ -85,10 +369,96 @@ func Len(t testing.TB, collection any, expected int) {
 		reflect.Map,
 		reflect.String:
 		if v.Len() != expected {
-			failCompare(t, expected, v.Len(), "unexpected length")
+			failCompareNote(t, v.Len(), expected, previewElements(v), withMessage("unexpected length", msg)...)
 		}
 	default:
-		t.Errorf("\nLen called with unsupported type: %T", collection)
+		t.Errorf("\nLen called with unsupported type: %s", typeName(collection))
+	}
+}
+
+// LenOf checks if a collection (slice, array or map) has the same length as
+// an expected one. On failure, the elements (or map keys) of actual missing
+// from expected are reported as surplus, and those of expected missing from
+// actual as missing:
+//
+//	assert.LenOf(t, got.Items, want.Items)
+func LenOf(t testing.TB, actual, expected any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	a := reflect.ValueOf(actual)
+	e := reflect.ValueOf(expected)
+	if !isCollection(a) || !isCollection(e) {
+		t.Errorf("\nLenOf called with unsupported types: %s, %s", typeName(actual), typeName(expected))
+		return
+	}
+
+	if a.Len() != e.Len() {
+		ae, ee := elementsOf(a), elementsOf(e)
+		var notes []string
+		if surplus := subtractElements(ae, ee); len(surplus) > 0 {
+			notes = append(notes, "surplus: "+formatElements(surplus, len(surplus)))
+		}
+		if missing := subtractElements(ee, ae); len(missing) > 0 {
+			notes = append(notes, "missing: "+formatElements(missing, len(missing)))
+		}
+		failCompareNote(t, a.Len(), e.Len(), strings.Join(notes, "; "), withMessage("unexpected length", msg)...)
+	}
+}
+
+// LenMap checks if a map has the expected length.
+// Unlike Len, the argument type is checked at compile time.
+func LenMap[K comparable, V any](t testing.TB, m map[K]V, expected int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if len(m) != expected {
+		failCompareNote(t, len(m), expected, previewElements(reflect.ValueOf(m)), withMessage("unexpected length", msg)...)
+	}
+}
+
+// LenSlice checks if a slice has the expected length.
+// Unlike Len, the argument type is checked at compile time.
+func LenSlice[T any](t testing.TB, slice []T, expected int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if len(slice) != expected {
+		failCompareNote(t, len(slice), expected, previewElements(reflect.ValueOf(slice)), withMessage("unexpected length", msg)...)
+	}
+}
+
+// MapsEqualFunc checks if two maps have the same keys, using eq to compare
+// the values of each key. It is useful to compare floats or timestamps:
+//
+//	assert.MapsEqualFunc(t, got, want, func(a, b time.Time) bool { return a.Equal(b) })
+func MapsEqualFunc[K comparable, V any](t testing.TB, actual, expected map[K]V, eq func(a, b V) bool, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	var diffs []string
+
+	for k, e := range expected {
+		a, ok := actual[k]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("key %#v: missing, expected %#v", k, e))
+		case !eq(a, e):
+			diffs = append(diffs, fmt.Sprintf("key %#v: expected %#v, got %#v", k, e, a))
+		}
+	}
+	for k, a := range actual {
+		if _, ok := expected[k]; !ok {
+			diffs = append(diffs, fmt.Sprintf("key %#v: unexpected, got %#v", k, a))
+		}
+	}
+
+	if len(diffs) > 0 {
+		sort.Strings(diffs)
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, actual, expected, strings.Join(diffs, "; "), withMessage("maps are not equal", msg)...)
 	}
 }
 
@@ -96,11 +466,11 @@ func Len(t testing.TB, collection any, expected int) {
 // Powerful for testing string patterns and formats.
 func MatchRegexp(t testing.TB, s, pattern string, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	matched, err := regexp.MatchString(pattern, s)
 	if err != nil {
-		failCompare(t, pattern, "valid regexp pattern",
-			append([]string{fmt.Sprintf("invalid regexp: %v", err)}, msg...)...)
+		failCompare(t, pattern, "valid regexp pattern", withMessage(fmt.Sprintf("invalid regexp: %v", err), msg)...)
 		return
 	}
 
@@ -113,6 +483,7 @@ func MatchRegexp(t testing.TB, s, pattern string, msg ...string) {
 // Useful for ensuring exclusion of specific values.
 func NotContains[T any](t testing.TB, slice []T, element T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	for _, v := range slice {
 		if isEqual(v, element) {
@@ -122,11 +493,334 @@ func NotContains[T any](t testing.TB, slice []T, element T, msg ...string) {
 	}
 }
 
+// NotContainsFunc checks that no element of a slice satisfies a predicate
+// described by desc, and reports the first element that does.
+func NotContainsFunc[T any](t testing.TB, slice []T, pred func(T) bool, desc string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	for i, v := range slice {
+		if pred(v) {
+			failCompare[any](t, v, "no element to satisfy: "+desc,
+				withMessage(fmt.Sprintf("element at index %d satisfied: %s", i, desc), msg)...)
+			return
+		}
+	}
+}
+
+// NotEmpty checks if a collection is not empty.
+// It supports the same types as Empty.
+func NotEmpty(t testing.TB, collection any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	empty, _, ok := emptiness(collection)
+	if !ok {
+		t.Errorf("\nNotEmpty() called with unsupported type: (%T)", collection)
+		return
+	}
+	if empty {
+		failCompare[any](t, collection, "non-empty collection", msg...)
+	}
+}
+
+// SlicesEqualFunc checks if two slices are equal, using eq to compare
+// elements at the same index. It is useful to compare with a tolerance:
+//
+//	assert.SlicesEqualFunc(t, got, want, func(a, b float64) bool {
+//	    return math.Abs(a-b) < 1e-9
+//	})
+func SlicesEqualFunc[T any](t testing.TB, actual, expected []T, eq func(a, b T) bool, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	note := sliceDiff(len(actual), len(expected),
+		func(i int) bool { return eq(actual[i], expected[i]) },
+		func(i int) string { return fmt.Sprintf("expected %#v, got %#v", expected[i], actual[i]) },
+	)
+	if note != "" {
+		failCompareNote(t, actual, expected, note, withMessage("slices are not equal", msg)...)
+	}
+}
+
 // StringContains checks if a string contains an expected substring.
-func StringContains(t testing.TB, s, substr string) {
+func StringContains(t testing.TB, s, substr string, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if !strings.Contains(s, substr) {
-		failCompare(t, substr, s, "string does not contain expected substring")
+		failCompare(t, s, fmt.Sprintf("should contain %q", substr),
+			withMessage("string does not contain expected substring", msg)...)
+	}
+}
+
+// StringCount checks if a substring occurs exactly n times in a string,
+// counting non-overlapping occurrences like strings.Count. On failure,
+// the observed count and the byte offsets of the occurrences are reported.
+func StringCount(t testing.TB, s, substr string, n int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	var positions []int
+	if substr != "" {
+		for i := 0; ; {
+			j := strings.Index(s[i:], substr)
+			if j < 0 {
+				break
+			}
+			positions = append(positions, i+j)
+			i += j + len(substr)
+		}
+	}
+
+	if count := strings.Count(s, substr); count != n {
+		failCompare(t, count, n,
+			withMessage(fmt.Sprintf("unexpected count of %q (at offsets %v)", substr, positions), msg)...)
+	}
+}
+
+// StringContainsFold checks if a string contains an expected substring,
+// under Unicode case-folding.
+func StringContainsFold(t testing.TB, s, substr string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if !containsFold(s, substr) {
+		failCompare(t, s, fmt.Sprintf("should contain %q (case-insensitive)", substr),
+			withMessage("string does not contain expected substring", msg)...)
+	}
+}
+
+// hasPrefixFold is strings.HasPrefix under Unicode case-folding.
+// Runes are compared one by one, as their encodings may differ in length.
+func hasPrefixFold(s, prefix string) bool {
+	for prefix != "" {
+		if s == "" {
+			return false
+		}
+		_, n := utf8.DecodeRuneInString(s)
+		_, m := utf8.DecodeRuneInString(prefix)
+		if !strings.EqualFold(s[:n], prefix[:m]) {
+			return false
+		}
+		s, prefix = s[n:], prefix[m:]
+	}
+	return true
+}
+
+// hasSuffixFold is strings.HasSuffix under Unicode case-folding.
+func hasSuffixFold(s, suffix string) bool {
+	for suffix != "" {
+		if s == "" {
+			return false
+		}
+		_, n := utf8.DecodeLastRuneInString(s)
+		_, m := utf8.DecodeLastRuneInString(suffix)
+		if !strings.EqualFold(s[len(s)-n:], suffix[len(suffix)-m:]) {
+			return false
+		}
+		s, suffix = s[:len(s)-n], suffix[:len(suffix)-m]
+	}
+	return true
+}
+
+// containsFold is strings.Contains under Unicode case-folding.
+func containsFold(s, substr string) bool {
+	for {
+		if hasPrefixFold(s, substr) {
+			return true
+		}
+		if s == "" {
+			return false
+		}
+		_, n := utf8.DecodeRuneInString(s)
+		s = s[n:]
+	}
+}
+
+// maxSliceDiffs is the number of differences detailed in a failure.
+const maxSliceDiffs = 5
+
+// sliceDiff describes the differences between two slices of lengths n and m,
+// or returns an empty string when they are equal. The elements at index i
+// are compared with same and described with describe.
+func sliceDiff(n, m int, same func(i int) bool, describe func(i int) string) string {
+	var diffs []string
+
+	if n != m {
+		diffs = append(diffs, fmt.Sprintf("length %d, expected %d", n, m))
+	}
+
+	count := 0
+	for i := 0; i < n && i < m; i++ {
+		if same(i) {
+			continue
+		}
+		if count < maxSliceDiffs {
+			diffs = append(diffs, fmt.Sprintf("index %d: %s", i, describe(i)))
+		}
+		count++
+	}
+	if count > maxSliceDiffs {
+		diffs = append(diffs, fmt.Sprintf("and %d more", count-maxSliceDiffs))
+	}
+
+	return strings.Join(diffs, "; ")
+}
+
+// maxPreviewElements is the number of elements shown in a preview.
+const maxPreviewElements = 5
+
+// isCollection reports whether v is a slice, an array or a map.
+func isCollection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// elementsOf returns the elements of a slice or an array, or the keys of
+// a map sorted by their formatted value, so that previews are stable.
+func elementsOf(v reflect.Value) []any {
+	var elems []any
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elems = append(elems, v.Index(i).Interface())
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			elems = append(elems, k.Interface())
+		}
+		sort.Slice(elems, func(i, j int) bool {
+			return fmt.Sprintf("%#v", elems[i]) < fmt.Sprintf("%#v", elems[j])
+		})
+	}
+	return elems
+}
+
+// previewElements describes the first elements of a slice or an array,
+// or the first entries of a map. It returns "" for other kinds.
+func previewElements(v reflect.Value) string {
+	// The elements are copied before being inspected, which keeps v from
+	// escaping, so that successful length assertions do not allocate.
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(elems, v)
+		return "elements: " + formatElements(elementsOf(elems), v.Len())
+	case reflect.Map:
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, k := range v.MapKeys() {
+			m.SetMapIndex(k, v.MapIndex(k))
+		}
+		keys := elementsOf(m)
+		if len(keys) > maxPreviewElements {
+			keys = keys[:maxPreviewElements]
+		}
+		entries := make([]any, len(keys))
+		for i, k := range keys {
+			entries[i] = mapEntry{k, m.MapIndex(reflect.ValueOf(k)).Interface()}
+		}
+		return "entries: " + formatElements(entries, v.Len())
+	}
+	return ""
+}
+
+// mapEntry is a map entry formatted as key: value.
+type mapEntry struct {
+	key, value any
+}
+
+func (e mapEntry) GoString() string {
+	return fmt.Sprintf("%#v: %#v", e.key, e.value)
+}
+
+// formatElements formats the first elements of a collection of n elements,
+// followed by the number of elements left out.
+func formatElements(elems []any, n int) string {
+	if len(elems) > maxPreviewElements {
+		elems = elems[:maxPreviewElements]
+	}
+
+	parts := make([]string, 0, len(elems)+1)
+	for _, e := range elems {
+		parts = append(parts, fmt.Sprintf("%#v", e))
+	}
+	if n > len(elems) {
+		parts = append(parts, fmt.Sprintf("and %d more", n-len(elems)))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// subtractElements returns the elements of x left once each element
+// of y has been matched with an equal one, keeping duplicates.
+func subtractElements(x, y []any) []any {
+	used := make([]bool, len(y))
+	var rest []any
+
+	for _, xe := range x {
+		found := false
+		for j, ye := range y {
+			if !used[j] && isEqual(xe, ye) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			rest = append(rest, xe)
+		}
+	}
+	return rest
+}
+
+// lener is implemented by collections exposing their length.
+type lener interface {
+	Len() int
+}
+
+// zeroer is implemented by values knowing whether they are zero.
+type zeroer interface {
+	IsZero() bool
+}
+
+// emptiness reports whether value is empty, along with a description of
+// the value when it is not. A Len() int method takes precedence over an
+// IsZero() bool method, which takes precedence over the kind of the value.
+// A nil pointer implementing either method is empty.
+// The last result is false when emptiness is not defined for value.
+func emptiness(value any) (empty bool, desc string, ok bool) {
+	v := reflect.ValueOf(value)
+
+	switch x := value.(type) {
+	case lener:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return true, "", true
+		}
+		if n := x.Len(); n != 0 {
+			return false, fmt.Sprintf("%s with length %d", typeName(value), n), true
+		}
+		return true, "", true
+	case zeroer:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return true, "", true
+		}
+		if !x.IsZero() {
+			return false, fmt.Sprintf("non-zero %s", typeName(value)), true
+		}
+		return true, "", true
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		if n := v.Len(); n != 0 {
+			return false, fmt.Sprintf("collection with length %d", n), true
+		}
+		return true, "", true
+	default:
+		return false, "", false
 	}
 }
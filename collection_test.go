@@ -3,7 +3,13 @@
 // license that can be found in the LICENSE file.
 package assert
 
-import "testing"
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestContains(t *testing.T) {
 	t.Run("string slice", func(t *testing.T) {
@@ -62,6 +68,98 @@ func TestContains(t *testing.T) {
 	})
 }
 
+func TestContainsFunc(t *testing.T) {
+	type user struct {
+		ID    int
+		Email string
+	}
+	users := []user{{1, "alice@example.com"}, {2, "bob@example.com"}}
+	isBob := func(u user) bool { return u.Email == "bob@example.com" }
+	isCarol := func(u user) bool { return u.Email == "carol@example.com" }
+
+	tests := []struct {
+		name          string
+		slice         []user
+		pred          func(user) bool
+		wantContains  bool
+		wantNotFailed bool
+	}{
+		{"matching element", users, isBob, true, false},
+		{"no matching element", users, isCarol, false, true},
+		{"empty slice", nil, isBob, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			ContainsFunc(rec, tt.slice, tt.pred, "has user")
+			if rec.HasError() == tt.wantContains {
+				t.Errorf("ContainsFunc() error = %v, want %v", rec.HasError(), !tt.wantContains)
+			}
+
+			rec = NewTestRecorder(t)
+			NotContainsFunc(rec, tt.slice, tt.pred, "has user")
+			if rec.HasError() == tt.wantNotFailed {
+				t.Errorf("NotContainsFunc() error = %v, want %v", rec.HasError(), !tt.wantNotFailed)
+			}
+		})
+	}
+
+	t.Run("messages", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		ContainsFunc(rec, users, isCarol, "email is carol")
+		if msg := rec.ErrorMessage(); !strings.Contains(msg, "none of 2 elements satisfied: email is carol") ||
+			!strings.Contains(msg, "bob@example.com") {
+			t.Errorf("ContainsFunc() message missing details\ngot: %s", msg)
+		}
+
+		rec = NewTestRecorder(t)
+		NotContainsFunc(rec, users, isBob, "email is bob")
+		if msg := rec.ErrorMessage(); !strings.Contains(msg, "element at index 1 satisfied: email is bob") {
+			t.Errorf("NotContainsFunc() message missing details\ngot: %s", msg)
+		}
+	})
+}
+
+func TestCountEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     []string
+		element   string
+		n         int
+		wantError bool
+	}{
+		{"exact count", []string{"a", "b", "a"}, "a", 2, false},
+		{"absent", []string{"a", "b"}, "c", 0, false},
+		{"too many", []string{"a", "a", "a"}, "a", 2, true},
+		{"too few", []string{"a"}, "a", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CountEquals(rec, tt.slice, tt.element, tt.n)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CountEquals() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("positions are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		CountEquals(rec, []int{7, 1, 7, 7}, 7, 2)
+
+		for _, part := range []string{"unexpected count of 7 (at indexes [0 2 3])", "Actual: (int) 3", "Expected: (int) 2"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("CountEquals() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
 func TestEmpty(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -113,6 +211,41 @@ func TestEmpty(t *testing.T) {
 			collection: 42,
 			wantError:  true,
 		},
+		{
+			name:       "zero time",
+			collection: time.Time{},
+			wantError:  false,
+		},
+		{
+			name:       "non-zero time",
+			collection: time.Unix(1, 0),
+			wantError:  true,
+		},
+		{
+			name:       "empty buffer",
+			collection: &bytes.Buffer{},
+			wantError:  false,
+		},
+		{
+			name:       "non-empty buffer",
+			collection: bytes.NewBufferString("data"),
+			wantError:  true,
+		},
+		{
+			name:       "nil buffer",
+			collection: (*bytes.Buffer)(nil),
+			wantError:  false,
+		},
+		{
+			name:       "empty custom collection",
+			collection: stack{},
+			wantError:  false,
+		},
+		{
+			name:       "non-empty custom collection",
+			collection: stack{items: []int{1}},
+			wantError:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -128,6 +261,294 @@ func TestEmpty(t *testing.T) {
 	}
 }
 
+// stack is a custom collection exposing its length with a Len method.
+type stack struct {
+	items []int
+}
+
+func (s stack) Len() int { return len(s.items) }
+
+func TestEmptyMessage(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	Empty(rec, stack{items: []int{1, 2}})
+
+	if !strings.Contains(rec.ErrorMessage(), "assert.stack with length 2") {
+		t.Errorf("Empty() message missing length\ngot: %s", rec.ErrorMessage())
+	}
+}
+
+func TestNotEmpty(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection any
+		wantError  bool
+	}{
+		{
+			name:       "non-empty slice",
+			collection: []int{1},
+			wantError:  false,
+		},
+		{
+			name:       "empty string",
+			collection: "",
+			wantError:  true,
+		},
+		{
+			name:       "non-zero time",
+			collection: time.Unix(1, 0),
+			wantError:  false,
+		},
+		{
+			name:       "zero time",
+			collection: time.Time{},
+			wantError:  true,
+		},
+		{
+			name:       "empty custom collection",
+			collection: stack{},
+			wantError:  true,
+		},
+		{
+			name:       "invalid type",
+			collection: 42,
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			NotEmpty(rec, tt.collection)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("NotEmpty() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestEmptySlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     []int
+		wantError bool
+	}{
+		{
+			name:      "nil slice",
+			slice:     nil,
+			wantError: false,
+		},
+		{
+			name:      "empty slice",
+			slice:     []int{},
+			wantError: false,
+		},
+		{
+			name:      "non-empty slice",
+			slice:     []int{1},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EmptySlice(rec, tt.slice)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EmptySlice() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestEmptyMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         map[string]int
+		wantError bool
+	}{
+		{
+			name:      "nil map",
+			m:         nil,
+			wantError: false,
+		},
+		{
+			name:      "empty map",
+			m:         map[string]int{},
+			wantError: false,
+		},
+		{
+			name:      "non-empty map",
+			m:         map[string]int{"a": 1},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EmptyMap(rec, tt.m)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EmptyMap() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestEqualBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	id := func(u user) int { return u.ID }
+
+	tests := []struct {
+		name      string
+		actual    []user
+		expected  []user
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "same keys",
+			actual:    []user{{1, "alice"}, {2, "bob"}},
+			expected:  []user{{1, "Alice"}, {2, "Bob"}},
+			wantError: false,
+		},
+		{
+			name:      "both empty",
+			actual:    nil,
+			expected:  []user{},
+			wantError: false,
+		},
+		{
+			name:      "different key",
+			actual:    []user{{1, "alice"}, {3, "carol"}},
+			expected:  []user{{1, "alice"}, {2, "bob"}},
+			wantError: true,
+			wantParts: []string{"slices differ by key", "index 1: expected key 2, got 3"},
+		},
+		{
+			name:      "different length",
+			actual:    []user{{1, "alice"}},
+			expected:  []user{{1, "alice"}, {2, "bob"}},
+			wantError: true,
+			wantParts: []string{"length 1, expected 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualBy(rec, tt.actual, tt.expected, id)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualBy() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualBy() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestEqualKeyed(t *testing.T) {
+	type address struct {
+		City string
+	}
+	type user struct {
+		ID      int
+		Name    string
+		Tags    []string
+		Address *address
+		seen    int
+	}
+	id := func(u user) int { return u.ID }
+
+	tests := []struct {
+		name      string
+		actual    []user
+		expected  []user
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:     "same elements in any order",
+			actual:   []user{{ID: 2, Name: "bob"}, {ID: 1, Name: "alice"}},
+			expected: []user{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}},
+		},
+		{
+			name:     "both empty",
+			actual:   nil,
+			expected: []user{},
+		},
+		{
+			name:      "different fields",
+			actual:    []user{{ID: 1, Name: "alice"}, {ID: 2, Name: "robert", Tags: []string{"a", "c"}}},
+			expected:  []user{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob", Tags: []string{"a", "b"}}},
+			wantError: true,
+			wantParts: []string{
+				"keyed elements differ",
+				`key 2: Name: expected "bob", got "robert"; key 2: Tags[1]: expected "b", got "c"`,
+			},
+		},
+		{
+			name:      "nested and unexported fields",
+			actual:    []user{{ID: 1, Address: &address{"Paris"}, seen: 2}},
+			expected:  []user{{ID: 1, Address: &address{"Lyon"}, seen: 1}},
+			wantError: true,
+			wantParts: []string{`key 1: Address.City: expected "Lyon", got "Paris"; key 1: seen: expected 1, got 2`},
+		},
+		{
+			name:      "unmatched keys",
+			actual:    []user{{ID: 1}, {ID: 3}},
+			expected:  []user{{ID: 1}, {ID: 2}},
+			wantError: true,
+			wantParts: []string{"key 2: missing, expected", "key 3: unexpected, got"},
+		},
+		{
+			name:      "duplicate keys",
+			actual:    []user{{ID: 1}, {ID: 1}},
+			expected:  []user{{ID: 1}},
+			wantError: true,
+			wantParts: []string{"key 1: duplicate in actual"},
+		},
+		{
+			name:      "many differences",
+			actual:    nil,
+			expected:  []user{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}, {ID: 7}},
+			wantError: true,
+			wantParts: []string{"and 2 more"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualKeyed(rec, tt.actual, tt.expected, id)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualKeyed() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualKeyed() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
 func TestHasKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -168,6 +589,68 @@ func TestHasKey(t *testing.T) {
 	}
 }
 
+func TestKeySetEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         map[string]int
+		keys      []string
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name: "same keys in any order",
+			m:    map[string]int{"a": 1, "b": 2, "c": 3},
+			keys: []string{"c", "a", "b"},
+		},
+		{
+			name: "duplicate expected keys",
+			m:    map[string]int{"a": 1},
+			keys: []string{"a", "a"},
+		},
+		{
+			name: "empty",
+			m:    nil,
+			keys: nil,
+		},
+		{
+			name:      "missing keys",
+			m:         map[string]int{"a": 1},
+			keys:      []string{"c", "a", "b"},
+			wantError: true,
+			wantNote:  `Note: missing keys: "b", "c"`,
+		},
+		{
+			name:      "missing and extra keys",
+			m:         map[string]int{"a": 1, "x": 2},
+			keys:      []string{"a", "b"},
+			wantError: true,
+			wantNote:  `Note: missing keys: "b"; extra keys: "x"`,
+		},
+		{
+			name:      "many extra keys",
+			m:         map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7},
+			keys:      nil,
+			wantError: true,
+			wantNote:  `extra keys: "a", "b", "c", "d", "e", and 2 more`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			KeySetEquals(rec, tt.m, tt.keys)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("KeySetEquals() error %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("KeySetEquals() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
 func TestHasPrefix(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -262,6 +745,50 @@ func TestHasSuffix(t *testing.T) {
 	}
 }
 
+func TestFoldAssertions(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		sub        string
+		wantPrefix bool
+		wantSuffix bool
+		wantSub    bool
+	}{
+		{"exact", "Content-Type", "Content-Type", true, true, true},
+		{"different case", "content-type", "CONTENT-TYPE", true, true, true},
+		{"prefix", "Bearer TOKEN", "bearer ", true, false, true},
+		{"suffix", "REPORT.PDF", ".pdf", false, true, true},
+		{"inside", "Hello World!", "WORLD", false, false, true},
+		{"unicode folding", "ÉCOLE", "école", true, true, true},
+		{"kelvin sign", "\u212a-value", "k-", true, false, true},
+		{"empty substring", "abc", "", true, true, true},
+		{"missing", "hello", "bye", false, false, false},
+		{"longer substring", "go", "gopher", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			HasPrefixFold(rec, tt.s, tt.sub)
+			if rec.HasError() == tt.wantPrefix {
+				t.Errorf("HasPrefixFold() error = %v, want %v", rec.HasError(), !tt.wantPrefix)
+			}
+
+			rec = NewTestRecorder(t)
+			HasSuffixFold(rec, tt.s, tt.sub)
+			if rec.HasError() == tt.wantSuffix {
+				t.Errorf("HasSuffixFold() error = %v, want %v", rec.HasError(), !tt.wantSuffix)
+			}
+
+			rec = NewTestRecorder(t)
+			StringContainsFold(rec, tt.s, tt.sub)
+			if rec.HasError() == tt.wantSub {
+				t.Errorf("StringContainsFold() error = %v, want %v", rec.HasError(), !tt.wantSub)
+			}
+		})
+	}
+}
+
 func TestLen(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -314,6 +841,236 @@ func TestLen(t *testing.T) {
 	}
 }
 
+func TestLenPreview(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		want   string
+	}{
+		{
+			name:   "slice",
+			assert: func(t testing.TB) { Len(t, []int{1, 2, 3}, 2) },
+			want:   "elements: [1, 2, 3]",
+		},
+		{
+			name:   "long slice",
+			assert: func(t testing.TB) { LenSlice(t, []int{1, 2, 3, 4, 5, 6, 7}, 2) },
+			want:   "elements: [1, 2, 3, 4, 5, and 2 more]",
+		},
+		{
+			name:   "map",
+			assert: func(t testing.TB) { LenMap(t, map[string]int{"b": 2, "a": 1}, 1) },
+			want:   `entries: ["a": 1, "b": 2]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if !strings.Contains(rec.ErrorMessage(), tt.want) {
+				t.Errorf("message missing %q\ngot: %s", tt.want, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestLenOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    any
+		expected  any
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name:     "same length",
+			actual:   []int{1, 2},
+			expected: []int{3, 4},
+		},
+		{
+			name:     "slice and array",
+			actual:   []int{1, 2},
+			expected: [2]string{"a", "b"},
+		},
+		{
+			name:      "surplus elements",
+			actual:    []string{"a", "b", "b", "c"},
+			expected:  []string{"a", "b"},
+			wantError: true,
+			wantNote:  `surplus: ["b", "c"]`,
+		},
+		{
+			name:      "missing elements",
+			actual:    []string{"b"},
+			expected:  []string{"a", "b", "c"},
+			wantError: true,
+			wantNote:  `missing: ["a", "c"]`,
+		},
+		{
+			name:      "map keys",
+			actual:    map[string]int{"a": 1, "x": 2},
+			expected:  map[string]int{"a": 1},
+			wantError: true,
+			wantNote:  `surplus: ["x"]`,
+		},
+		{
+			name:      "unsupported type",
+			actual:    "abc",
+			expected:  []int{1},
+			wantError: true,
+			wantNote:  "unsupported types",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			LenOf(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("LenOf() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("LenOf() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestLenSlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     []string
+		expected  int
+		wantError bool
+	}{
+		{
+			name:      "correct length",
+			slice:     []string{"a", "b"},
+			expected:  2,
+			wantError: false,
+		},
+		{
+			name:      "nil slice",
+			slice:     nil,
+			expected:  0,
+			wantError: false,
+		},
+		{
+			name:      "incorrect length",
+			slice:     []string{"a"},
+			expected:  2,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			LenSlice(rec, tt.slice, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("LenSlice() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestLenMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         map[int]bool
+		expected  int
+		wantError bool
+	}{
+		{
+			name:      "correct length",
+			m:         map[int]bool{1: true, 2: false},
+			expected:  2,
+			wantError: false,
+		},
+		{
+			name:      "incorrect length",
+			m:         map[int]bool{},
+			expected:  1,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			LenMap(rec, tt.m, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("LenMap() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMapsEqualFunc(t *testing.T) {
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+
+	tests := []struct {
+		name      string
+		actual    map[string]float64
+		expected  map[string]float64
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "equal within tolerance",
+			actual:    map[string]float64{"a": 1.001, "b": 2},
+			expected:  map[string]float64{"a": 1, "b": 2.002},
+			wantError: false,
+		},
+		{
+			name:      "nil and empty",
+			actual:    nil,
+			expected:  map[string]float64{},
+			wantError: false,
+		},
+		{
+			name:      "value out of tolerance",
+			actual:    map[string]float64{"a": 1.5},
+			expected:  map[string]float64{"a": 1},
+			wantError: true,
+			wantParts: []string{"maps are not equal", `key "a": expected 1, got 1.5`},
+		},
+		{
+			name:      "missing and unexpected keys",
+			actual:    map[string]float64{"b": 2},
+			expected:  map[string]float64{"a": 1},
+			wantError: true,
+			wantParts: []string{`key "a": missing, expected 1`, `key "b": unexpected, got 2`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			MapsEqualFunc(rec, tt.actual, tt.expected, approx)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("MapsEqualFunc() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("MapsEqualFunc() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
 func TestMatchRegexp(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -409,6 +1166,93 @@ func TestNotContains(t *testing.T) {
 	}
 }
 
+func TestSlicesEqualFunc(t *testing.T) {
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+
+	tests := []struct {
+		name      string
+		actual    []float64
+		expected  []float64
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "equal within tolerance",
+			actual:    []float64{1.001, 2},
+			expected:  []float64{1, 2.002},
+			wantError: false,
+		},
+		{
+			name:      "element out of tolerance",
+			actual:    []float64{1, 2.5},
+			expected:  []float64{1, 2},
+			wantError: true,
+			wantParts: []string{"slices are not equal", "index 1: expected 2, got 2.5"},
+		},
+		{
+			name:      "many differences are truncated",
+			actual:    []float64{1, 2, 3, 4, 5, 6, 7},
+			expected:  []float64{0, 0, 0, 0, 0, 0, 0},
+			wantError: true,
+			wantParts: []string{"index 4:", "and 2 more"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			SlicesEqualFunc(rec, tt.actual, tt.expected, approx)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("SlicesEqualFunc() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("SlicesEqualFunc() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestStringCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		substr    string
+		n         int
+		wantError bool
+	}{
+		{"exact count", "a,b,c", ",", 2, false},
+		{"non-overlapping", "aaaa", "aa", 2, false},
+		{"absent", "hello", "x", 0, false},
+		{"wrong count", "retry retry retry", "retry", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			StringCount(rec, tt.s, tt.substr, tt.n)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("StringCount() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("positions are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		StringCount(rec, "retry retry retry", "retry", 2)
+
+		if !strings.Contains(rec.ErrorMessage(), `unexpected count of "retry" (at offsets [0 6 12])`) {
+			t.Errorf("StringCount() message missing positions\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
 func TestStringContains(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -454,3 +1298,11 @@ func TestStringContains(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkLen(b *testing.B) {
+	s := []int{1, 2, 3}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Len(b, s, 3)
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrentTimeout is the time Concurrently waits for its goroutines.
+// It is a variable so that tests can shorten it.
+var concurrentTimeout = 10 * time.Second
+
+// Concurrently runs fn in n goroutines, with i ranging from 0 to n-1, and
+// waits for all of them. Each goroutine asserts through its own Assert,
+// whose failures are collected safely and reported on the test goroutine,
+// prefixed by the index of the goroutine that failed. Panics are reported
+// the same way, and goroutines still running after 10 seconds are reported
+// as blocked, their later failures being reported at the end of the test:
+//
+//	assert.Concurrently(t, 8, func(i int, a *assert.Assert) {
+//	    assert.NoError(a, cache.Set(fmt.Sprint(i), i))
+//	})
+func Concurrently(t testing.TB, n int, fn func(i int, a *Assert)) {
+	t.Helper()
+	defer observe(t)()
+
+	tbs := make([]*SafeTB, n)
+	var (
+		mu      sync.Mutex
+		pending = map[int]bool{}
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < n; i++ {
+		tbs[i] = &SafeTB{TB: t}
+		pending[i] = true
+	}
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				mu.Lock()
+				delete(pending, i)
+				mu.Unlock()
+			}()
+			defer func() {
+				if r := recover(); r != nil {
+					tbs[i].Errorf("\npanic: %v", r)
+				}
+			}()
+
+			fn(i, New(tbs[i]))
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(concurrentTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+
+	for i, s := range tbs {
+		reportQueued(t, s, fmt.Sprintf("goroutine %d of %d failed", i, n))
+	}
+
+	mu.Lock()
+	blocked := make([]int, 0, len(pending))
+	for i := range pending {
+		blocked = append(blocked, i)
+	}
+	mu.Unlock()
+
+	if len(blocked) > 0 {
+		sort.Ints(blocked)
+		failCompare(t, fmt.Sprintf("%d goroutines still running %v", len(blocked), blocked), "all goroutines done",
+			fmt.Sprintf("goroutines did not complete within %v", concurrentTimeout))
+
+		t.Cleanup(func() {
+			t.Helper()
+
+			for _, i := range blocked {
+				reportQueued(t, tbs[i], fmt.Sprintf("goroutine %d of %d failed after the deadline", i, n))
+			}
+		})
+	}
+}
+
+// reportQueued reports to t the failures queued by s, prefixed by desc.
+// They are removed from s, so that each is reported once.
+func reportQueued(t testing.TB, s *SafeTB, desc string) {
+	t.Helper()
+
+	s.mu.Lock()
+	failures, failed := s.failures, s.failed
+	s.failures, s.failed = nil, false
+	s.mu.Unlock()
+
+	for _, failure := range failures {
+		t.Errorf("\n%s:%s", desc, failure)
+	}
+	if failed && len(failures) == 0 {
+		t.Errorf("\n%s", desc)
+	}
+}
+
+// RaceCheck runs funcs concurrently, iterations times, released together
+// at each iteration to provoke the races that the -race flag detects.
+// After each iteration, check runs follow-up assertions on the shared
+// state, and RaceCheck stops at the first iteration that fails or panics,
+// reporting its index. A nil check only exercises funcs:
+//
+//	var c Counter
+//	assert.RaceCheck(t, 100, func(i int, a *assert.Assert) {
+//	    assert.Equal(a, c.Value(), 2*(i+1))
+//	}, c.Inc, c.Inc)
+func RaceCheck(t testing.TB, iterations int, check func(i int, a *Assert), funcs ...func()) {
+	t.Helper()
+	defer observe(t)()
+
+	for i := 0; i < iterations; i++ {
+		if p := runTogether(funcs); p != nil {
+			t.Errorf("\nrace check failed at iteration %d of %d:\npanic: %v", i, iterations, p)
+			return
+		}
+		if check == nil {
+			continue
+		}
+		if err := Check(func(t testing.TB) { check(i, New(t)) }); err != nil {
+			t.Errorf("\nrace check failed at iteration %d of %d:\n%v", i, iterations, err)
+			return
+		}
+	}
+}
+
+// runTogether runs funcs in goroutines released at the same time, and
+// waits for them. It returns the value of the first panic, if any.
+func runTogether(funcs []func()) any {
+	var (
+		start = make(chan struct{})
+		wg    sync.WaitGroup
+		once  sync.Once
+		p     any
+	)
+
+	for _, fn := range funcs {
+		wg.Add(1)
+		go func(fn func()) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { p = r })
+				}
+			}()
+
+			<-start
+			fn()
+		}(fn)
+	}
+
+	close(start)
+	wg.Wait()
+
+	return p
+}
+
+// maxGoroutineDump is the maximum size of the goroutine dump of Within.
+const maxGoroutineDump = 1 << 20
+
+// Within runs fn and fails if it does not complete within timeout, with
+// a dump of all goroutines to locate the blocked code. It keeps a test
+// stuck on a deadlock from hanging until the go test timeout:
+//
+//	assert.Within(t, time.Second, func(a *assert.Assert) {
+//	    assert.NoError(a, pool.Shutdown())
+//	})
+//
+// The assertions of fn are reported when it completes. A block still
+// running after timeout is left behind, and its failures are reported
+// at the end of the test.
+func Within(t testing.TB, timeout time.Duration, fn func(a *Assert)) {
+	t.Helper()
+	defer observe(t)()
+
+	if !runWithin(t, timeout, fn) {
+		t.Errorf("\nblock did not complete within %v\ngoroutines:\n%s", timeout, goroutineDump())
+	}
+}
+
+// LocksWithin runs fn and fails if it does not complete within timeout,
+// with a dump of all goroutines showing where each one waits for a lock.
+// It turns lock ordering bugs into readable failures, instead of tests
+// hanging until the go test timeout:
+//
+//	assert.LocksWithin(t, time.Second, func() {
+//	    accounts.Transfer(a, b, 10)
+//	})
+//
+// Like Within, a call still blocked after timeout is left behind.
+func LocksWithin(t testing.TB, timeout time.Duration, fn func()) {
+	t.Helper()
+	defer observe(t)()
+
+	if !runWithin(t, timeout, func(*Assert) { fn() }) {
+		t.Errorf("\nlocking did not complete within %v, possible deadlock\ngoroutines:\n%s", timeout, goroutineDump())
+	}
+}
+
+// runWithin runs fn in a goroutine, and reports whether it completed
+// within timeout. The failures and panic of fn are reported to t when it
+// completes in time, and at the end of the test otherwise.
+func runWithin(t testing.TB, timeout time.Duration, fn func(a *Assert)) bool {
+	t.Helper()
+
+	s := NewSafeTB(t)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				s.Errorf("\npanic: %v", r)
+			}
+		}()
+
+		fn(New(s))
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		s.Flush()
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// TryLocker is a lock whose acquisition can be attempted without blocking,
+// such as sync.Mutex and sync.RWMutex.
+type TryLocker interface {
+	TryLock() bool
+	Unlock()
+}
+
+// TryLockEventually attempts to acquire l until it succeeds, then releases
+// it. It fails with a dump of all goroutines, showing the holders of the
+// lock, if l could not be acquired within timeout:
+//
+//	cache.Close()
+//	assert.TryLockEventually(t, &cache.mu, time.Second)
+func TryLockEventually(t testing.TB, l TryLocker, timeout time.Duration, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	clk := lookupClock(t)
+	deadline := clk.Now().Add(timeout)
+	attempts := 0
+
+	for {
+		attempts++
+		if l.TryLock() {
+			l.Unlock()
+			return
+		}
+		if clk.Now().Add(pollInterval).After(deadline) {
+			failCompareNote(t, "lock held", "lock acquired", "goroutines:\n"+goroutineDump(),
+				withMessage(fmt.Sprintf("lock not acquired after %d attempts in %v", attempts, timeout), msg)...)
+			return
+		}
+		clk.Sleep(pollInterval)
+	}
+}
+
+// Goroutine is a goroutine started by Go.
+type Goroutine struct {
+	t    testing.TB
+	s    *SafeTB
+	done chan struct{}
+}
+
+// Go runs fn in a goroutine whose panic, instead of crashing the test
+// binary, is reported as a failure of t with the stack of the goroutine.
+// The failure is reported on the test goroutine by Wait, or at the end of
+// the test:
+//
+//	g := assert.Go(t, func() { worker.Run(ctx) })
+//	cancel()
+//	g.Wait(time.Second)
+//
+// It must be called from the test goroutine.
+func Go(t testing.TB, fn func()) *Goroutine {
+	t.Helper()
+
+	g := &Goroutine{t: t, s: NewSafeTB(t), done: make(chan struct{})}
+	go func() {
+		defer close(g.done)
+		defer func() {
+			if r := recover(); r != nil {
+				g.s.Errorf("\npanic in goroutine: %v\n%s", r, debug.Stack())
+			}
+		}()
+
+		fn()
+	}()
+	return g
+}
+
+// Wait waits for the goroutine to complete and reports its panic, if any.
+// It fails with a dump of all goroutines if the goroutine is still running
+// after timeout, and reports whether it completed. It must be called from
+// the test goroutine.
+func (g *Goroutine) Wait(timeout time.Duration) bool {
+	g.t.Helper()
+	defer observe(g.t)()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-g.done:
+		g.s.Flush()
+		return true
+	case <-timer.C:
+		g.t.Errorf("\ngoroutine did not complete within %v\ngoroutines:\n%s", timeout, goroutineDump())
+		return false
+	}
+}
+
+// goroutineDump returns the stacks of all goroutines,
+// truncated to maxGoroutineDump bytes.
+func goroutineDump() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxGoroutineDump {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
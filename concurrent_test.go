@@ -0,0 +1,373 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrently(t *testing.T) {
+	t.Run("all goroutines pass", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		var mu sync.Mutex
+		seen := map[int]bool{}
+
+		Concurrently(rec, 8, func(i int, a *Assert) {
+			mu.Lock()
+			seen[i] = true
+			mu.Unlock()
+			True(a, i >= 0 && i < 8)
+		})
+
+		if rec.HasError() {
+			t.Errorf("Concurrently() recorded error\n%s", rec.ErrorMessage())
+		}
+		if len(seen) != 8 {
+			t.Errorf("Concurrently() ran %d goroutines, want 8", len(seen))
+		}
+	})
+
+	t.Run("failing goroutines are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Concurrently(rec, 4, func(i int, a *Assert) {
+			if i%2 == 1 {
+				Equal(a, i, 0, "odd worker")
+			}
+		})
+
+		if rec.FailureCount() != 2 {
+			t.Fatalf("Concurrently() reported %d failures, want 2", rec.FailureCount())
+		}
+		failures := strings.Join(rec.Failures(), "\n")
+		for _, part := range []string{"goroutine 1 of 4 failed:", "goroutine 3 of 4 failed:", "Message: odd worker"} {
+			if !strings.Contains(failures, part) {
+				t.Errorf("Concurrently() failures missing %q\ngot: %s", part, failures)
+			}
+		}
+	})
+
+	t.Run("fatal stops only the goroutine", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Concurrently(rec, 2, func(i int, a *Assert) {
+			if i == 0 {
+				a.Fatalf("stop")
+				t.Error("Fatalf() did not stop the goroutine")
+			}
+		})
+
+		if !strings.Contains(rec.ErrorMessage(), "goroutine 0 of 2 failed:stop") {
+			t.Errorf("Concurrently() message missing fatal failure\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("panics are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Concurrently(rec, 3, func(i int, a *Assert) {
+			if i == 2 {
+				panic("boom")
+			}
+		})
+
+		if !strings.Contains(rec.ErrorMessage(), "goroutine 2 of 3 failed:\npanic: boom") {
+			t.Errorf("Concurrently() message missing panic\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("blocked goroutines are reported", func(t *testing.T) {
+		defer func(d time.Duration) { concurrentTimeout = d }(concurrentTimeout)
+		concurrentTimeout = 20 * time.Millisecond
+
+		rec := NewTestRecorder(t)
+		release := make(chan struct{})
+		defer close(release)
+
+		Concurrently(rec, 3, func(i int, a *Assert) {
+			if i == 1 {
+				<-release
+			}
+		})
+
+		for _, part := range []string{"goroutines did not complete within 20ms", "1 goroutines still running [1]"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Concurrently() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("late failures are reported", func(t *testing.T) {
+		defer func(d time.Duration) { concurrentTimeout = d }(concurrentTimeout)
+		concurrentTimeout = 20 * time.Millisecond
+
+		rec := NewTestRecorder(t)
+		release := make(chan struct{})
+		done := make(chan struct{})
+
+		Concurrently(rec, 2, func(i int, a *Assert) {
+			if i == 1 {
+				<-release
+				Equal(a, i, 0, "late")
+				close(done)
+			}
+		})
+		close(release)
+		<-done
+		rec.RunCleanups()
+
+		Len(t, rec.Failures(), 2)
+		StringContains(t, rec.ErrorMessage(), "goroutine 1 of 2 failed after the deadline:\n Message: late")
+	})
+}
+
+func TestRaceCheck(t *testing.T) {
+	t.Run("state checked after each iteration", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		c := &atomicCounter{}
+		checks := 0
+
+		RaceCheck(rec, 50, func(i int, a *Assert) {
+			checks++
+			Equal(a, c.Load(), int64(2*(i+1)))
+		}, func() { c.Add(1) }, func() { c.Add(1) })
+
+		if rec.HasError() {
+			t.Errorf("RaceCheck() recorded error\n%s", rec.ErrorMessage())
+		}
+		if checks != 50 {
+			t.Errorf("RaceCheck() ran %d checks, want 50", checks)
+		}
+	})
+
+	t.Run("first failing iteration reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var n int64
+		checks := 0
+
+		RaceCheck(rec, 10, func(i int, a *Assert) {
+			checks++
+			Less(a, atomic.LoadInt64(&n), 7, "lost update")
+		}, func() { atomic.AddInt64(&n, 1) })
+
+		if checks != 7 {
+			t.Errorf("RaceCheck() ran %d checks, want 7", checks)
+		}
+		for _, part := range []string{"race check failed at iteration 6 of 10", "Message: lost update"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("RaceCheck() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("panics reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		calls := int64(0)
+
+		RaceCheck(rec, 5, nil, func() {
+			if atomic.AddInt64(&calls, 1) == 3 {
+				panic("boom")
+			}
+		})
+
+		if !strings.Contains(rec.ErrorMessage(), "iteration 2 of 5:\npanic: boom") {
+			t.Errorf("RaceCheck() message missing panic\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestWithin(t *testing.T) {
+	t.Run("block completes", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Within(rec, time.Second, func(a *Assert) {
+			Equal(a, 1, 1)
+		})
+
+		if rec.HasError() {
+			t.Errorf("Within() recorded error\n%s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("failures of the block are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Within(rec, time.Second, func(a *Assert) {
+			Equal(a, 1, 2, "inside")
+		})
+
+		if !strings.Contains(rec.ErrorMessage(), "Message: inside") {
+			t.Errorf("Within() message missing failure\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("panics are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Within(rec, time.Second, func(a *Assert) {
+			panic("boom")
+		})
+
+		if !strings.Contains(rec.ErrorMessage(), "panic: boom") {
+			t.Errorf("Within() message missing panic\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("timeout dumps goroutines", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		release := make(chan struct{})
+		defer close(release)
+
+		Within(rec, 20*time.Millisecond, func(a *Assert) {
+			blockUntil(release)
+		})
+
+		for _, part := range []string{"block did not complete within 20ms", "goroutine ", "blockUntil"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Within() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestGo(t *testing.T) {
+	t.Run("goroutine completes", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		ran := false
+
+		ok := Go(rec, func() { ran = true }).Wait(time.Second)
+
+		True(t, ok)
+		True(t, ran)
+		False(t, rec.HasError())
+	})
+
+	t.Run("panic is reported with its stack", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Go(rec, func() { panic("boom") }).Wait(time.Second)
+
+		for _, part := range []string{"panic in goroutine: boom", "goroutine ", "TestGo"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Wait() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("panic is reported at cleanup without Wait", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		g := Go(rec, func() { panic("boom") })
+		<-g.done
+		rec.RunCleanups()
+
+		if !strings.Contains(rec.ErrorMessage(), "panic in goroutine: boom") {
+			t.Errorf("cleanup message missing panic\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("timeout dumps goroutines", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		release := make(chan struct{})
+		defer close(release)
+
+		ok := Go(rec, func() { blockUntil(release) }).Wait(20 * time.Millisecond)
+
+		False(t, ok)
+		for _, part := range []string{"goroutine did not complete within 20ms", "blockUntil"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Wait() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestLocksWithin(t *testing.T) {
+	t.Run("locks acquired", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var mu sync.Mutex
+
+		LocksWithin(rec, time.Second, func() {
+			mu.Lock()
+			defer mu.Unlock()
+		})
+
+		False(t, rec.HasError())
+	})
+
+	t.Run("deadlock dumps goroutines", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var mu sync.Mutex
+		mu.Lock()
+		defer mu.Unlock()
+
+		LocksWithin(rec, 20*time.Millisecond, func() { lockMutex(&mu) })
+
+		for _, part := range []string{"locking did not complete within 20ms, possible deadlock", "lockMutex"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("LocksWithin() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+// lockMutex locks and unlocks mu, under a name found in goroutine dumps.
+func lockMutex(mu *sync.Mutex) {
+	mu.Lock()
+	mu.Unlock()
+}
+
+func TestTryLockEventually(t *testing.T) {
+	t.Run("lock free", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var mu sync.RWMutex
+
+		TryLockEventually(rec, &mu, time.Second)
+
+		False(t, rec.HasError())
+		True(t, mu.TryLock(), "lock released")
+	})
+
+	t.Run("lock released later", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var mu sync.Mutex
+		mu.Lock()
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			mu.Unlock()
+		}()
+
+		TryLockEventually(rec, &mu, 5*time.Second)
+
+		False(t, rec.HasError())
+	})
+
+	t.Run("lock held", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		clk := NewFakeClock(clockStart)
+		a := New(rec)
+		a.SetClock(clk)
+		var mu sync.Mutex
+		mu.Lock()
+		defer mu.Unlock()
+
+		TryLockEventually(a, &mu, time.Second, "cache lock")
+
+		for _, part := range []string{"cache lock: lock not acquired after 101 attempts in 1s", "goroutines:", "TestTryLockEventually"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("TryLockEventually() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+// blockUntil blocks until ch is closed, under a name found in goroutine dumps.
+func blockUntil(ch <-chan struct{}) {
+	<-ch
+}
@@ -5,42 +5,66 @@ package assert
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Number represents any numeric type in Go.
-// This constraint allows us to work with any built-in numeric type
+// This constraint allows us to work with any built-in numeric type,
+// and with types derived from them such as time.Duration.
 type Number interface {
-	int | int8 | int16 | int32 | int64 | uint | uint8 |
-		uint16 | uint32 | uint64 | float32 | float64
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 |
+		~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// Integer represents any integer type in Go,
+// and types derived from them.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
 }
 
 // Ordered represents any type that can be ordered (compared with <, >, <=, >=).
-// This includes all numeric types and strings, staying within Go's native types.
+// This includes all numeric types and strings, and types derived from them.
 type Ordered interface {
-	Number | string
+	Number | ~string
 }
 
 // Between checks if a value falls within an inclusive range.
 // It works with any type that can be ordered (numbers and strings).
-func Between[T Ordered](t testing.TB, actual, min, max T) {
+func Between[T Ordered](t testing.TB, actual, min, max T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
-	if actual < min || actual > max {
-		failCompare[any](t,
-			fmt.Sprintf("Between %v and %v", min, max),
+	switch {
+	case actual < min:
+		failCompareNote[any](t,
+			actual,
+			fmt.Sprintf("Between %s and %s", formatBound(min), formatBound(max)),
+			shortBy(min, actual),
+			withMessage("value not within expected range", msg)...,
+		)
+	case actual > max:
+		failCompareNote[any](t,
 			actual,
-			"value not within expected range",
+			fmt.Sprintf("Between %s and %s", formatBound(min), formatBound(max)),
+			overBy(actual, max),
+			withMessage("value not within expected range", msg)...,
 		)
 	}
 }
 
 // Greater checks if a value is greater than a minimum value.
-func Greater[T Ordered](t testing.TB, actual, min T) {
+func Greater[T Ordered](t testing.TB, actual, min T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if actual <= min {
-		failCompare[any](t, fmt.Sprintf("> %v", min), actual, "value not greater than minimum")
+		failCompareNote[any](t, actual, "> "+formatBound(min), shortBy(min, actual),
+			withMessage("value not greater than minimum", msg)...)
 	}
 }
 
@@ -48,9 +72,21 @@ func Greater[T Ordered](t testing.TB, actual, min T) {
 // Particularly useful for validating minimum requirements or thresholds.
 func GreaterOrEqual[T Ordered](t testing.TB, actual, min T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if actual < min {
-		failCompare[any](t, actual, fmt.Sprintf(">= %v", min), msg...)
+		failCompareNote[any](t, actual, ">= "+formatBound(min), shortBy(min, actual), msg...)
+	}
+}
+
+// Less checks if a value is less than a maximum value.
+func Less[T Ordered](t testing.TB, actual, max T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if actual >= max {
+		failCompareNote[any](t, actual, "< "+formatBound(max), overBy(actual, max),
+			withMessage("value not less than maximum", msg)...)
 	}
 }
 
@@ -58,8 +94,252 @@ func GreaterOrEqual[T Ordered](t testing.TB, actual, min T, msg ...string) {
 // This complements our Greater function and is useful for range checks.
 func LessOrEqual[T Ordered](t testing.TB, actual, max T, msg ...string) {
 	t.Helper()
+	defer observe(t)()
 
 	if actual > max {
-		failCompare[any](t, actual, fmt.Sprintf("<= %v", max), msg...)
+		failCompareNote[any](t, actual, "<= "+formatBound(max), overBy(actual, max), msg...)
+	}
+}
+
+// MinElementEquals checks that the smallest element of a slice equals
+// expected, reporting the index of the smallest element otherwise.
+// It fails on an empty slice:
+//
+//	assert.MinElementEquals(t, latencies, 12*time.Millisecond)
+func MinElementEquals[T Ordered](t testing.TB, slice []T, expected T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkExtremum(t, slice, expected, -1, msg)
+}
+
+// MaxElementEquals checks that the largest element of a slice equals
+// expected, like MinElementEquals.
+func MaxElementEquals[T Ordered](t testing.TB, slice []T, expected T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkExtremum(t, slice, expected, 1, msg)
+}
+
+// checkExtremum compares the smallest element of slice to expected when
+// sign is -1, and the largest when it is +1.
+func checkExtremum[T Ordered](t testing.TB, slice []T, expected T, sign int, msg []string) {
+	t.Helper()
+
+	name := "minimum"
+	if sign > 0 {
+		name = "maximum"
+	}
+	if len(slice) == 0 {
+		failCompare[any](t, slice, expected, withMessage("slice has no "+name+" element", msg)...)
+		return
+	}
+
+	index := 0
+	for i, v := range slice {
+		if compareOrdered(v, slice[index]) == sign {
+			index = i
+		}
+	}
+	if slice[index] != expected {
+		failCompare(t, slice[index], expected,
+			withMessage(fmt.Sprintf("%s element at index %d not equal to expected", name, index), msg)...)
+	}
+}
+
+// AllGreater checks that every element of a slice is greater than a
+// bound, and reports the first element that is not, with its index.
+// It succeeds on an empty slice:
+//
+//	assert.AllGreater(t, balances, 0)
+func AllGreater[T Ordered](t testing.TB, slice []T, bound T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	for i, v := range slice {
+		if v <= bound {
+			note := joinNotes(shortBy(bound, v), outOfBounds(slice[i+1:], func(v T) bool { return v <= bound }))
+			failCompareNote[any](t, v, "> "+formatBound(bound), note,
+				withMessage(fmt.Sprintf("element at index %d not greater than minimum", i), msg)...)
+			return
+		}
+	}
+}
+
+// AllLess checks that every element of a slice is less than a bound,
+// like AllGreater.
+func AllLess[T Ordered](t testing.TB, slice []T, bound T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	for i, v := range slice {
+		if v >= bound {
+			note := joinNotes(overBy(v, bound), outOfBounds(slice[i+1:], func(v T) bool { return v >= bound }))
+			failCompareNote[any](t, v, "< "+formatBound(bound), note,
+				withMessage(fmt.Sprintf("element at index %d not less than maximum", i), msg)...)
+			return
+		}
+	}
+}
+
+// outOfBounds describes how many of the remaining elements are also
+// out of bounds, or returns "" when none is.
+func outOfBounds[T any](rest []T, out func(T) bool) string {
+	n := 0
+	for _, v := range rest {
+		if out(v) {
+			n++
+		}
+	}
+	switch n {
+	case 0:
+		return ""
+	case 1:
+		return "1 more element out of bounds"
+	}
+	return fmt.Sprintf("%d more elements out of bounds", n)
+}
+
+// joinNotes joins the non-empty notes of a failure.
+func joinNotes(notes ...string) string {
+	var parts []string
+	for _, note := range notes {
+		if note != "" {
+			parts = append(parts, note)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IntEquals checks if two integers of possibly different types are equal.
+// Unlike a conversion to a common type, it cannot be fooled by overflows,
+// so that a uint64 from a database driver can be compared with an int:
+//
+//	assert.IntEquals(t, row.Count, 3)
+func IntEquals[A, E Integer](t testing.TB, actual A, expected E, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if compareInts(actual, expected) != 0 {
+		failCompare[any](t, actual, expected, withMessage("integers are not equal", msg)...)
+	}
+}
+
+// IntGreater checks if an integer is greater than a minimum
+// of a possibly different type, like IntEquals.
+func IntGreater[A, E Integer](t testing.TB, actual A, min E, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if compareInts(actual, min) <= 0 {
+		failCompare[any](t, actual, min, withMessage("integer not greater than minimum", msg)...)
+	}
+}
+
+// IntLess checks if an integer is less than a maximum
+// of a possibly different type, like IntEquals.
+func IntLess[A, E Integer](t testing.TB, actual A, max E, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if compareInts(actual, max) >= 0 {
+		failCompare[any](t, actual, max, withMessage("integer not less than maximum", msg)...)
+	}
+}
+
+// compareInts compares two integers of any types without conversion
+// loss. It returns -1, 0 or +1 as x is less than, equal to, or
+// greater than y.
+func compareInts[A, B Integer](x A, y B) int {
+	xNeg, yNeg := x < 0, y < 0
+
+	switch {
+	case xNeg && !yNeg:
+		return -1
+	case !xNeg && yNeg:
+		return 1
+	case xNeg && yNeg:
+		// Both fit in an int64.
+		return compareOrdered(int64(x), int64(y))
+	default:
+		// Both fit in a uint64.
+		return compareOrdered(uint64(x), uint64(y))
+	}
+}
+
+// compareOrdered returns -1, 0 or +1 as x is less than, equal to,
+// or greater than y.
+func compareOrdered[T Ordered](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// durationType is the type of time.Duration values.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// formatBound formats a bound of a comparison, with durations in
+// human-friendly units.
+func formatBound(value any) string {
+	v := reflect.ValueOf(value)
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	return fmt.Sprint(value)
+}
+
+// shortBy describes how far a value falls below a minimum.
+func shortBy[T Ordered](min, actual T) string {
+	if actual == min {
+		return "equal to the minimum"
+	}
+	if d := difference(min, actual); d != "" {
+		return "short by " + d
+	}
+	return ""
+}
+
+// overBy describes how far a value exceeds a maximum.
+func overBy[T Ordered](actual, max T) string {
+	if actual == max {
+		return "equal to the maximum"
+	}
+	if d := difference(actual, max); d != "" {
+		return "over by " + d
+	}
+	return ""
+}
+
+// difference formats hi - lo for numeric values, with durations in
+// human-friendly units. It returns "" for strings, and when the
+// difference cannot be represented, like between infinite floats.
+func difference(hi, lo any) string {
+	h, l := reflect.ValueOf(hi), reflect.ValueOf(lo)
+
+	switch h.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		d := h.Int() - l.Int()
+		if d < 0 {
+			// The difference overflows int64.
+			return strconv.FormatUint(uint64(d), 10)
+		}
+		if h.Type() == durationType {
+			return time.Duration(d).String()
+		}
+		return strconv.FormatInt(d, 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(h.Uint()-l.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		d := h.Float() - l.Float()
+		if d != d {
+			return ""
+		}
+		return strconv.FormatFloat(d, 'g', -1, h.Type().Bits())
 	}
+	return ""
 }
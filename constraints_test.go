@@ -3,7 +3,12 @@
 // license that can be found in the LICENSE file.
 package assert
 
-import "testing"
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestBetween(t *testing.T) {
 	t.Run("numeric values", func(t *testing.T) {
@@ -199,3 +204,321 @@ func TestLessOrEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestLess(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    int
+		max       int
+		wantError bool
+	}{
+		{
+			name:      "value less than max",
+			actual:    5,
+			max:       10,
+			wantError: false,
+		},
+		{
+			name:      "value equal to max",
+			actual:    10,
+			max:       10,
+			wantError: true,
+		},
+		{
+			name:      "value greater than max",
+			actual:    15,
+			max:       10,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			Less(rec, tt.actual, tt.max)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("Less() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestComparisonDifference(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		want   []string
+	}{
+		{
+			name:   "short of minimum",
+			assert: func(t testing.TB) { GreaterOrEqual(t, 97, 100) },
+			want:   []string{"Expected: (string) \">= 100\"", "Actual: (int) 97", "Note: short by 3"},
+		},
+		{
+			name:   "equal to minimum",
+			assert: func(t testing.TB) { Greater(t, 100, 100) },
+			want:   []string{"Note: equal to the minimum"},
+		},
+		{
+			name:   "over maximum",
+			assert: func(t testing.TB) { LessOrEqual(t, 2.5, 2.0) },
+			want:   []string{"Note: over by 0.5"},
+		},
+		{
+			name:   "equal to maximum",
+			assert: func(t testing.TB) { Less(t, uint8(7), 7) },
+			want:   []string{"Note: equal to the maximum"},
+		},
+		{
+			name:   "below range",
+			assert: func(t testing.TB) { Between(t, -5, 0, 10) },
+			want:   []string{"Note: short by 5"},
+		},
+		{
+			name:   "above range",
+			assert: func(t testing.TB) { Between(t, uint(12), 1, 10) },
+			want:   []string{"Note: over by 2"},
+		},
+		{
+			name:   "durations",
+			assert: func(t testing.TB) { LessOrEqual(t, 1500*time.Millisecond, time.Second) },
+			want:   []string{`Expected: (string) "<= 1s"`, "Actual: (time.Duration) 1.5s", "Note: over by 500ms"},
+		},
+		{
+			name:   "int64 overflow",
+			assert: func(t testing.TB) { GreaterOrEqual[int64](t, math.MinInt64, math.MaxInt64) },
+			want:   []string{"Note: short by 18446744073709551615"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			for _, part := range tt.want {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+
+	t.Run("strings", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Greater(rec, "a", "b")
+
+		if strings.Contains(rec.ErrorMessage(), "Note:") {
+			t.Errorf("Greater() on strings has a note\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestIntEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"same values", func(t testing.TB) { IntEquals(t, uint64(3), 3) }, false},
+		{"signed and unsigned", func(t testing.TB) { IntEquals(t, int8(-1), uint8(255)) }, true},
+		{"wrapping conversion", func(t testing.TB) { IntEquals(t, uint64(math.MaxUint64), -1) }, true},
+		{"large values", func(t testing.TB) { IntEquals(t, uint64(math.MaxInt64), int64(math.MaxInt64)) }, false},
+		{"negative values", func(t testing.TB) { IntEquals(t, int16(-300), int64(-300)) }, false},
+		{"derived types", func(t testing.TB) { IntEquals(t, time.Duration(5), uint32(5)) }, false},
+		{"different values", func(t testing.TB) { IntEquals(t, uint(2), 3) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("original types are shown", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		IntEquals(rec, uint64(math.MaxUint64), -1)
+
+		for _, part := range []string{"Expected: (int) -1", "Actual: (uint64) 0xffffffffffffffff"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("IntEquals() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestIntGreater(t *testing.T) {
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"greater", func(t testing.TB) { IntGreater(t, uint64(1), -1) }, false},
+		{"equal", func(t testing.TB) { IntGreater(t, uint8(3), int64(3)) }, true},
+		{"negative and unsigned", func(t testing.TB) { IntGreater(t, -1, uint64(0)) }, true},
+		{"large unsigned", func(t testing.TB) { IntGreater(t, uint64(math.MaxUint64), int64(math.MaxInt64)) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("IntGreater() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestIntLess(t *testing.T) {
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"less", func(t testing.TB) { IntLess(t, -1, uint64(0)) }, false},
+		{"equal", func(t testing.TB) { IntLess(t, uint8(3), int64(3)) }, true},
+		{"wrapping conversion", func(t testing.TB) { IntLess(t, uint64(math.MaxUint64), 0) }, true},
+		{"negative values", func(t testing.TB) { IntLess(t, int8(-5), int32(-4)) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("IntLess() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMinMaxElementEquals(t *testing.T) {
+	tests := []struct {
+		name    string
+		assert  func(t testing.TB)
+		wantErr string
+	}{
+		{
+			name:   "minimum equal",
+			assert: func(t testing.TB) { MinElementEquals(t, []int{4, 2, 7, 2}, 2) },
+		},
+		{
+			name:   "maximum equal",
+			assert: func(t testing.TB) { MaxElementEquals(t, []string{"b", "c", "a"}, "c") },
+		},
+		{
+			name:   "single element",
+			assert: func(t testing.TB) { MaxElementEquals(t, []float64{1.5}, 1.5) },
+		},
+		{
+			name:    "minimum differs",
+			assert:  func(t testing.TB) { MinElementEquals(t, []int{4, 3, 7}, 2) },
+			wantErr: "minimum element at index 1 not equal to expected",
+		},
+		{
+			name:    "maximum differs",
+			assert:  func(t testing.TB) { MaxElementEquals(t, []time.Duration{time.Second, 3 * time.Second}, 2*time.Second) },
+			wantErr: "maximum element at index 1 not equal to expected",
+		},
+		{
+			name:    "empty slice",
+			assert:  func(t testing.TB) { MinElementEquals(t, []int{}, 1) },
+			wantErr: "slice has no minimum element",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantErr == "" {
+				False(t, rec.HasError(), rec.ErrorMessage())
+				return
+			}
+			StringContains(t, rec.ErrorMessage(), tt.wantErr)
+		})
+	}
+
+	t.Run("values", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		MaxElementEquals(rec, []int{1, 9, 4}, 5)
+
+		StringContains(t, rec.ErrorMessage(), "Expected: (int) 5\n  Actual: (int) 9\n")
+	})
+}
+
+func TestAllGreaterLess(t *testing.T) {
+	tests := []struct {
+		name     string
+		assert   func(t testing.TB)
+		wantErr  string
+		wantNote string
+	}{
+		{
+			name:   "all greater",
+			assert: func(t testing.TB) { AllGreater(t, []int{3, 5, 4}, 2) },
+		},
+		{
+			name:   "all less",
+			assert: func(t testing.TB) { AllLess(t, []string{"a", "b"}, "c") },
+		},
+		{
+			name:   "empty slice",
+			assert: func(t testing.TB) { AllGreater(t, []int(nil), 0) },
+		},
+		{
+			name:     "element not greater",
+			assert:   func(t testing.TB) { AllGreater(t, []int{3, 1, 4}, 2) },
+			wantErr:  "element at index 1 not greater than minimum",
+			wantNote: "Note: short by 1\n",
+		},
+		{
+			name:     "element equal to bound",
+			assert:   func(t testing.TB) { AllGreater(t, []int{3, 2, 0, 1}, 2) },
+			wantErr:  "element at index 1 not greater than minimum",
+			wantNote: "Note: equal to the minimum; 2 more elements out of bounds\n",
+		},
+		{
+			name: "element not less",
+			assert: func(t testing.TB) {
+				AllLess(t, []time.Duration{time.Second, 3 * time.Second, 2 * time.Second}, 2*time.Second)
+			},
+			wantErr:  "element at index 1 not less than maximum",
+			wantNote: "Note: over by 1s; 1 more element out of bounds\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantErr == "" {
+				False(t, rec.HasError(), rec.ErrorMessage())
+				return
+			}
+			StringContains(t, rec.ErrorMessage(), tt.wantErr)
+			StringContains(t, rec.ErrorMessage(), tt.wantNote)
+		})
+	}
+}
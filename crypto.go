@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// hashes are the algorithms supported by HashEquals.
+var hashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// HashEquals checks if the digest of content, computed with the given
+// algorithm, matches the expected hexadecimal digest. Content is a []byte,
+// a string or an io.Reader, which is streamed rather than loaded in memory:
+//
+//	f, _ := os.Open("dist/app.tar.gz")
+//	defer f.Close()
+//	assert.HashEquals(t, f, "sha256", "9f86d081884c7d65...")
+//
+// Supported algorithms are md5, sha1, sha224, sha256, sha384 and sha512.
+func HashEquals(t testing.TB, content any, algorithm, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	newHash, ok := hashes[strings.ToLower(algorithm)]
+	if !ok {
+		t.Errorf("\nHashEquals() called with unsupported algorithm: %q", algorithm)
+		return
+	}
+
+	var r io.Reader
+	switch c := content.(type) {
+	case []byte:
+		r = bytes.NewReader(c)
+	case string:
+		r = strings.NewReader(c)
+	case io.Reader:
+		r = c
+	default:
+		t.Errorf("\nHashEquals() called with unsupported type: %s", typeName(content))
+		return
+	}
+
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		failCompare(t, err.Error(), "readable content", withMessage("cannot read content", msg)...)
+		return
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != strings.ToLower(expected) {
+		failCompare(t, actual, expected, withMessage(algorithm+" digest mismatch", msg)...)
+	}
+}
+
+// secretPreview is the number of leading bytes shown by maskSecret.
+const secretPreview = 2
+
+// SecretsEqual checks if two secrets are equal using a constant-time
+// comparison. On failure, it only reports their lengths and a masked
+// preview, so secrets are never leaked into test logs:
+//
+//	assert.SecretsEqual(t, derivedKey, expectedKey)
+func SecretsEqual(t testing.TB, actual, expected []byte, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if subtle.ConstantTimeCompare(actual, expected) != 1 {
+		failCompare(t, maskSecret(actual), maskSecret(expected), withMessage("secrets are not equal", msg)...)
+	}
+}
+
+// maskSecret describes a secret by its length, revealing its first bytes
+// only when it is long enough for them not to give it away.
+func maskSecret(secret []byte) string {
+	if len(secret) == 0 {
+		return "empty secret"
+	}
+	if len(secret) < 4*secretPreview {
+		return fmt.Sprintf("%s (%d bytes)", strings.Repeat("*", len(secret)), len(secret))
+	}
+	preview := strconv.Quote(string(secret[:secretPreview]))
+	preview = preview[1 : len(preview)-1]
+	return fmt.Sprintf("%s****** (%d bytes)", preview, len(secret))
+}
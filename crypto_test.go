@@ -0,0 +1,166 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestHashEquals(t *testing.T) {
+	const sha256Hello = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	tests := []struct {
+		name      string
+		content   any
+		algorithm string
+		expected  string
+		wantError bool
+	}{
+		{
+			name:      "bytes",
+			content:   []byte("hello"),
+			algorithm: "sha256",
+			expected:  sha256Hello,
+			wantError: false,
+		},
+		{
+			name:      "string",
+			content:   "hello",
+			algorithm: "sha256",
+			expected:  sha256Hello,
+			wantError: false,
+		},
+		{
+			name:      "reader",
+			content:   iotest.OneByteReader(strings.NewReader("hello")),
+			algorithm: "SHA256",
+			expected:  strings.ToUpper(sha256Hello),
+			wantError: false,
+		},
+		{
+			name:      "md5",
+			content:   "hello",
+			algorithm: "md5",
+			expected:  "5d41402abc4b2a76b9719d911017c592",
+			wantError: false,
+		},
+		{
+			name:      "digest mismatch",
+			content:   "hello!",
+			algorithm: "sha256",
+			expected:  sha256Hello,
+			wantError: true,
+		},
+		{
+			name:      "read error",
+			content:   iotest.ErrReader(errors.New("disk failure")),
+			algorithm: "sha256",
+			expected:  sha256Hello,
+			wantError: true,
+		},
+		{
+			name:      "unsupported algorithm",
+			content:   "hello",
+			algorithm: "crc32",
+			expected:  "3610a686",
+			wantError: true,
+		},
+		{
+			name:      "unsupported type",
+			content:   42,
+			algorithm: "sha256",
+			expected:  sha256Hello,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			HashEquals(rec, tt.content, tt.algorithm, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("HashEquals() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("both digests are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		HashEquals(rec, "hello", "sha256", "abc123")
+
+		for _, part := range []string{"sha256 digest mismatch", sha256Hello, "abc123"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("HashEquals() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestSecretsEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    []byte
+		expected  []byte
+		wantError bool
+	}{
+		{"equal", []byte("s3cr3t-token"), []byte("s3cr3t-token"), false},
+		{"both empty", nil, []byte{}, false},
+		{"different", []byte("s3cr3t-token"), []byte("s3cr3t-tokeN"), true},
+		{"different lengths", []byte("s3cr3t"), []byte("s3cr3t-token"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			SecretsEqual(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("SecretsEqual() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("secrets are not leaked", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		SecretsEqual(rec, []byte("hunter2-password"), []byte("abc"))
+
+		msg := rec.ErrorMessage()
+		for _, part := range []string{"secrets are not equal", "hu****** (16 bytes)", "*** (3 bytes)"} {
+			if !strings.Contains(msg, part) {
+				t.Errorf("SecretsEqual() message missing %q\ngot: %s", part, msg)
+			}
+		}
+		for _, secret := range []string{"hunter2", "abc"} {
+			if strings.Contains(msg, secret) {
+				t.Errorf("SecretsEqual() message leaks %q\ngot: %s", secret, msg)
+			}
+		}
+	})
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		secret []byte
+		want   string
+	}{
+		{nil, "empty secret"},
+		{[]byte("short"), "***** (5 bytes)"},
+		{[]byte("long enough"), "lo****** (11 bytes)"},
+		{[]byte{0, 1, 2, 3, 4, 5, 6, 7}, `\x00\x01****** (8 bytes)`},
+	}
+
+	for _, tt := range tests {
+		if got := maskSecret(tt.secret); got != tt.want {
+			t.Errorf("maskSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// CSVEquals checks if two CSV documents hold the same records. Differences
+// are reported cell by cell, as in "row 12, column email: expected X, got Y".
+// Options CSVHeader and TrimSpace compare the columns by name and ignore
+// the spaces around values:
+//
+//	assert.CSVEquals(t, export, expected, assert.CSVHeader(), assert.TrimSpace())
+func CSVEquals(t testing.TB, actual, expected string, opts ...Option) {
+	t.Helper()
+	defer observe(t)()
+
+	o := newOptions(opts)
+
+	e, err := readCSV(expected, o.trimSpace)
+	if err != nil {
+		failCompare(t, expected, "valid CSV", withMessage("invalid expected CSV: "+err.Error(), o.messages())...)
+		return
+	}
+	a, err := readCSV(actual, o.trimSpace)
+	if err != nil {
+		failCompare(t, actual, "valid CSV", withMessage("invalid actual CSV: "+err.Error(), o.messages())...)
+		return
+	}
+
+	var diffs []string
+	if o.csvHeader {
+		diffs = diffCSVByHeader(a, e)
+	} else {
+		diffs = diffCSV(a, e)
+	}
+
+	if len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, actual, expected, strings.Join(diffs, "; "), withMessage("CSV documents differ", o.messages())...)
+	}
+}
+
+// readCSV parses a CSV document, allowing records of varying length.
+func readCSV(s string, trim bool) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(s))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if trim {
+		for _, record := range records {
+			for i := range record {
+				record[i] = strings.TrimSpace(record[i])
+			}
+		}
+	}
+	return records, nil
+}
+
+// diffCSV compares records cell by cell, with columns identified by position.
+func diffCSV(a, e [][]string) []string {
+	var diffs []string
+
+	for i := 0; i < len(a) || i < len(e); i++ {
+		row := i + 1
+		switch {
+		case i >= len(a):
+			diffs = append(diffs, fmt.Sprintf("row %d: missing", row))
+			continue
+		case i >= len(e):
+			diffs = append(diffs, fmt.Sprintf("row %d: unexpected", row))
+			continue
+		}
+
+		for j := 0; j < len(a[i]) || j < len(e[i]); j++ {
+			diffs = appendCellDiff(diffs, row, strconv.Itoa(j+1), a[i], e[i], j, j)
+		}
+	}
+	return diffs
+}
+
+// diffCSVByHeader compares records cell by cell, with columns identified
+// by the names given in the first record.
+func diffCSVByHeader(a, e [][]string) []string {
+	var diffs []string
+
+	if len(a) == 0 || len(e) == 0 {
+		if len(a) != len(e) {
+			return []string{"row 1: header missing"}
+		}
+		return nil
+	}
+
+	actualColumns := map[string]int{}
+	for j, name := range a[0] {
+		actualColumns[name] = j
+	}
+	expectedColumns := map[string]int{}
+	for j, name := range e[0] {
+		expectedColumns[name] = j
+		if _, ok := actualColumns[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("column %s: missing", name))
+		}
+	}
+	for _, name := range a[0] {
+		if _, ok := expectedColumns[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("column %s: unexpected", name))
+		}
+	}
+
+	for i := 1; i < len(a) || i < len(e); i++ {
+		row := i + 1
+		switch {
+		case i >= len(a):
+			diffs = append(diffs, fmt.Sprintf("row %d: missing", row))
+			continue
+		case i >= len(e):
+			diffs = append(diffs, fmt.Sprintf("row %d: unexpected", row))
+			continue
+		}
+
+		for je, name := range e[0] {
+			if ja, ok := actualColumns[name]; ok {
+				diffs = appendCellDiff(diffs, row, name, a[i], e[i], ja, je)
+			}
+		}
+	}
+	return diffs
+}
+
+// appendCellDiff compares the cells ja of a and je of e, which may be absent,
+// and appends their difference to diffs.
+func appendCellDiff(diffs []string, row int, column string, a, e []string, ja, je int) []string {
+	av, aok := cell(a, ja)
+	ev, eok := cell(e, je)
+
+	switch {
+	case !aok && eok:
+		return append(diffs, fmt.Sprintf("row %d, column %s: missing, expected %q", row, column, ev))
+	case aok && !eok:
+		return append(diffs, fmt.Sprintf("row %d, column %s: unexpected %q", row, column, av))
+	case av != ev:
+		return append(diffs, fmt.Sprintf("row %d, column %s: expected %q, got %q", row, column, ev, av))
+	}
+	return diffs
+}
+
+// cell returns the value of the field i of a record, if present.
+func cell(record []string, i int) (string, bool) {
+	if i < len(record) {
+		return record[i], true
+	}
+	return "", false
+}
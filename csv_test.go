@@ -0,0 +1,113 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVEquals(t *testing.T) {
+	const users = "id,name,email\n1,alice,alice@example.com\n2,bob,bob@example.com\n"
+
+	tests := []struct {
+		name      string
+		actual    string
+		opts      []Option
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "identical",
+			actual:    users,
+			wantError: false,
+		},
+		{
+			name:      "quoting differences",
+			actual:    "id,name,email\n\"1\",alice,alice@example.com\r\n2,\"bob\",bob@example.com",
+			wantError: false,
+		},
+		{
+			name:      "cell differs",
+			actual:    "id,name,email\n1,alice,alice@example.com\n2,bob,robert@example.com\n",
+			wantError: true,
+			wantParts: []string{`row 3, column 3: expected "bob@example.com", got "robert@example.com"`},
+		},
+		{
+			name:      "missing row",
+			actual:    "id,name,email\n1,alice,alice@example.com\n",
+			wantError: true,
+			wantParts: []string{"row 3: missing"},
+		},
+		{
+			name:      "extra cell",
+			actual:    "id,name,email\n1,alice,alice@example.com,admin\n2,bob,bob@example.com\n",
+			wantError: true,
+			wantParts: []string{`row 2, column 4: unexpected "admin"`},
+		},
+		{
+			name:      "spaces differ",
+			actual:    "id, name, email\n1, alice, alice@example.com\n2, bob, bob@example.com\n",
+			wantError: true,
+			wantParts: []string{`row 1, column 2: expected "name", got " name"`},
+		},
+		{
+			name:      "spaces trimmed",
+			actual:    "id, name, email\n1, alice, alice@example.com\n2, bob, bob@example.com\n",
+			opts:      []Option{TrimSpace()},
+			wantError: false,
+		},
+		{
+			name:      "columns reordered",
+			actual:    "email,id,name\nalice@example.com,1,alice\nbob@example.com,2,bob\n",
+			opts:      []Option{CSVHeader()},
+			wantError: false,
+		},
+		{
+			name:      "header keyed difference",
+			actual:    "email,id,name\nalice@example.com,1,alice\nbob@example.com,2,Bob\n",
+			opts:      []Option{CSVHeader()},
+			wantError: true,
+			wantParts: []string{`row 3, column name: expected "bob", got "Bob"`},
+		},
+		{
+			name:      "header columns differ",
+			actual:    "id,name,phone\n1,alice,555\n2,bob,556\n",
+			opts:      []Option{CSVHeader()},
+			wantError: true,
+			wantParts: []string{"column email: missing", "column phone: unexpected"},
+		},
+		{
+			name:      "invalid CSV",
+			actual:    "id,name\n1,\"alice\n",
+			wantError: true,
+			wantParts: []string{"invalid actual CSV"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CSVEquals(rec, tt.actual, users, tt.opts...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CSVEquals() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("CSVEquals() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestCSVEqualsMessage(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	CSVEquals(rec, "a\n1\n", "a\n2\n", Message("export"))
+
+	StringContains(t, rec.ErrorMessage(), "Message: export: ")
+}
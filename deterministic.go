@@ -0,0 +1,63 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Deterministic runs fn n times and checks that all its results are equal,
+// and returns the result of the first run. It catches outputs depending on
+// map iteration order or on the time, such as in serializers:
+//
+//	out := assert.Deterministic(t, 20, func() string { return Render(config) })
+//
+// On failure, the first run whose result differs from the first one is
+// reported, with a diff when both results are strings or byte slices, or
+// the differing fields of other values.
+func Deterministic[T any](t testing.TB, n int, fn func() T, msg ...string) T {
+	t.Helper()
+	defer observe(t)()
+
+	var first T
+	for i := 1; i <= n; i++ {
+		result := fn()
+		if i == 1 {
+			first = result
+			continue
+		}
+		if isEqual(result, first) {
+			continue
+		}
+
+		var note, diff string
+		switch f, r := any(first), any(result); {
+		case isString(f) && isString(r):
+			diff = diffText(t, f.(string), r.(string))
+		case isBytes(f) && isBytes(r):
+			diff = diffText(t, string(f.([]byte)), string(r.([]byte)))
+		default:
+			note = strings.Join(diffFields(&equalizer{}, reflect.ValueOf(f), reflect.ValueOf(r), "", 0), "; ")
+		}
+		reportFailure(t, result, first, note, diff,
+			withMessage(fmt.Sprintf("run %d of %d differs from run 1", i, n), msg))
+		return first
+	}
+	return first
+}
+
+// isString reports whether the dynamic type of v is string.
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+// isBytes reports whether the dynamic type of v is []byte.
+func isBytes(v any) bool {
+	_, ok := v.([]byte)
+	return ok
+}
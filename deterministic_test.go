@@ -0,0 +1,140 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDeterministic(t *testing.T) {
+	type report struct {
+		Keys  []string
+		Count int
+	}
+	counter := func() func() int {
+		n := 0
+		return func() int { n++; return n }
+	}
+
+	tests := []struct {
+		name      string
+		run       func(t testing.TB)
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name: "stable string",
+			run: func(t testing.TB) {
+				Deterministic(t, 10, func() string { return "a,b" })
+			},
+		},
+		{
+			name: "stable struct",
+			run: func(t testing.TB) {
+				Deterministic(t, 10, func() report { return report{Keys: []string{"a"}, Count: 1} })
+			},
+		},
+		{
+			name: "string diff",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 5, func() string {
+					if next() == 3 {
+						return "name=a\nid=1\n"
+					}
+					return "id=1\nname=a\n"
+				})
+			},
+			wantError: true,
+			wantParts: []string{"run 3 of 5 differs from run 1", "Diff:", "-id=1", "+id=1"},
+		},
+		{
+			name: "byte slices diff",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 2, func() []byte { return []byte(fmt.Sprint(next())) })
+			},
+			wantError: true,
+			wantParts: []string{"run 2 of 2 differs from run 1", "-1", "+2"},
+		},
+		{
+			name: "struct fields",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 3, func() report { return report{Keys: []string{"a"}, Count: next()} })
+			},
+			wantError: true,
+			wantParts: []string{"run 2 of 3 differs from run 1", "Note: Count: expected 1, got 2"},
+		},
+		{
+			name: "interface results of different types",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 3, func() any {
+					if next() == 1 {
+						return 1
+					}
+					return "s"
+				})
+			},
+			wantError: true,
+			wantParts: []string{"run 2 of 3 differs from run 1", `Note: expected 1, got "s"`},
+		},
+		{
+			name: "interface string results",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 2, func() any { return fmt.Sprint(next()) })
+			},
+			wantError: true,
+			wantParts: []string{"Diff:", "-1", "+2"},
+		},
+		{
+			name: "nil error first",
+			run: func(t testing.TB) {
+				next := counter()
+				Deterministic(t, 2, func() error {
+					if next() == 1 {
+						return nil
+					}
+					return errors.New("timeout")
+				})
+			},
+			wantError: true,
+			wantParts: []string{"Note: expected nil, got &errors.errorString"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.run(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("Deterministic() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("Deterministic() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+			if rec.FailureCount() > 1 {
+				t.Errorf("Deterministic() reported %d failures, want 1", rec.FailureCount())
+			}
+		})
+	}
+
+	t.Run("returns the first result", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		next := counter()
+
+		got := Deterministic(rec, 3, next)
+
+		Equal(t, got, 1)
+	})
+}
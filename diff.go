@@ -0,0 +1,461 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FullDiff is the diff context showing all unchanged lines.
+const FullDiff = -1
+
+// defaultDiffContext is the number of unchanged lines shown around each
+// change, unless configured otherwise.
+const defaultDiffContext = 3
+
+// diffContextEnv is the environment variable setting the diff context,
+// as a number of lines or "full".
+const diffContextEnv = "ASSERT_DIFF_CONTEXT"
+
+var (
+	diffContextMu    sync.Mutex
+	diffContextSet   bool
+	diffContextLines int
+)
+
+// SetDiffContext sets the number of unchanged lines shown around each
+// change in the diffs of failure messages, such as those of
+// FileContentEquals. FullDiff shows whole documents, which suits CI
+// artifacts, while 0 keeps only the changed lines. It returns the
+// previous setting, so that tests can restore it:
+//
+//	func TestMain(m *testing.M) {
+//	    assert.SetDiffContext(assert.FullDiff)
+//	    os.Exit(m.Run())
+//	}
+//
+// Without a call, the context is read from the ASSERT_DIFF_CONTEXT
+// environment variable, as a number of lines or "full", and defaults
+// to 3 lines.
+func SetDiffContext(lines int) int {
+	if lines < 0 {
+		lines = FullDiff
+	}
+	prev := diffContext()
+
+	diffContextMu.Lock()
+	defer diffContextMu.Unlock()
+
+	diffContextSet, diffContextLines = true, lines
+	return prev
+}
+
+// diffContext returns the number of unchanged lines shown around each
+// change, or FullDiff.
+func diffContext() int {
+	diffContextMu.Lock()
+	set, lines := diffContextSet, diffContextLines
+	diffContextMu.Unlock()
+
+	if set {
+		return lines
+	}
+
+	switch v := os.Getenv(diffContextEnv); v {
+	case "":
+		return defaultDiffContext
+	case "full":
+		return FullDiff
+	default:
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+		return defaultDiffContext
+	}
+}
+
+// DiffEdit is a line of a diff, whose Op is ' ' for a line kept,
+// '-' for a line deleted and '+' for a line inserted.
+type DiffEdit struct {
+	Op   byte
+	Line string
+}
+
+// Differ computes the diffs of failure messages, as the edits turning
+// the lines of an expected text into those of an actual one.
+type Differ interface {
+	Diff(expected, actual []string) []DiffEdit
+}
+
+// MyersDiffer is the default Differ. It computes the shortest diffs with
+// the Myers algorithm, in a time growing with the product of the size of
+// the texts and of their differences. Texts needing more than 1000 edits
+// are shown as entirely replaced, to bound its time and memory.
+type MyersDiffer struct{}
+
+// Diff returns the shortest edits turning expected into actual.
+func (MyersDiffer) Diff(expected, actual []string) []DiffEdit {
+	return diffLines(expected, actual)
+}
+
+// PatienceDiffer is a Differ aligning the texts on their lines found
+// once in each of them, such as function signatures, before diffing the
+// lines between with the Myers algorithm. It is fast on large texts, and
+// its diffs follow the structure of the texts rather than repeated lines
+// such as closing braces, at the cost of not always being the shortest.
+// Like MyersDiffer, the regions between aligned lines needing more than
+// 1000 edits are shown as entirely replaced.
+type PatienceDiffer struct{}
+
+// Diff returns the edits turning expected into actual.
+func (PatienceDiffer) Diff(expected, actual []string) []DiffEdit {
+	return patienceDiff(expected, actual, nil)
+}
+
+// patienceDiff appends the edits turning a into b to edits.
+func patienceDiff(a, b []string, edits []DiffEdit) []DiffEdit {
+	anchors := uniqueMatches(a, b)
+	if len(anchors) == 0 {
+		// Without anchors, diffLines bounds the work by maxDiffEdits.
+		return append(edits, diffWith(MyersDiffer{}, a, b)...)
+	}
+
+	i, j := 0, 0
+	for _, m := range anchors {
+		edits = patienceDiff(a[i:m[0]], b[j:m[1]], edits)
+		edits = append(edits, DiffEdit{' ', a[m[0]]})
+		i, j = m[0]+1, m[1]+1
+	}
+	return patienceDiff(a[i:], b[j:], edits)
+}
+
+// uniqueMatches returns the longest sequence of lines found once in a
+// and once in b, in the same order in both, as pairs of indexes.
+func uniqueMatches(a, b []string) [][2]int {
+	count := make(map[string][2]int, len(a))
+	index := make(map[string]int, len(b))
+	for _, line := range a {
+		c := count[line]
+		c[0]++
+		count[line] = c
+	}
+	for j, line := range b {
+		c := count[line]
+		c[1]++
+		count[line] = c
+		index[line] = j
+	}
+
+	var pairs [][2]int
+	for i, line := range a {
+		if c := count[line]; c[0] == 1 && c[1] == 1 {
+			pairs = append(pairs, [2]int{i, index[line]})
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	// Longest increasing subsequence of the indexes in b, by patience
+	// sorting: tops holds the last pair of each pile, prev links each
+	// pair to the top of the previous pile when it was placed.
+	var tops []int
+	prev := make([]int, len(pairs))
+	for k, p := range pairs {
+		pile := sort.Search(len(tops), func(n int) bool { return pairs[tops[n]][1] > p[1] })
+		prev[k] = -1
+		if pile > 0 {
+			prev[k] = tops[pile-1]
+		}
+		if pile == len(tops) {
+			tops = append(tops, k)
+		} else {
+			tops[pile] = k
+		}
+	}
+
+	matches := make([][2]int, len(tops))
+	for n, k := len(tops)-1, tops[len(tops)-1]; k >= 0; n, k = n-1, prev[k] {
+		matches[n] = pairs[k]
+	}
+	return matches
+}
+
+var (
+	differMu sync.RWMutex
+	differ   Differ
+)
+
+// SetDiffer sets the Differ computing the diffs of failure messages of
+// all tests, such as those of Equal on multi-line strings, JSONEq and
+// FileContentEquals, or restores MyersDiffer
+// when d is nil. It returns the previous Differ, nil for the default one.
+// PatienceDiffer suits large generated files:
+//
+//	assert.SetDiffer(assert.PatienceDiffer{})
+//
+// Assert.SetDiffer sets the Differ of a single Assert.
+func SetDiffer(d Differ) Differ {
+	differMu.Lock()
+	defer differMu.Unlock()
+
+	prev := differ
+	differ = d
+	return prev
+}
+
+// SetDiffer sets the Differ of the assertions made through a, and the
+// Assert of its subtests, like the package-level SetDiffer. A nil Differ
+// uses the package-level one.
+func (a *Assert) SetDiffer(d Differ) {
+	a.differ = d
+}
+
+// lookupDiffer returns the Differ of the assertions reported to t.
+func lookupDiffer(t testing.TB) Differ {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			break
+		}
+		if a.differ != nil {
+			return a.differ
+		}
+		t = a.TB
+	}
+
+	differMu.RLock()
+	defer differMu.RUnlock()
+
+	if differ == nil {
+		return MyersDiffer{}
+	}
+	return differ
+}
+
+// diffText returns a unified diff turning expected into actual, computed
+// by the Differ of t, or an empty string when they are equal.
+func diffText(t testing.TB, expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	edits := diffWith(lookupDiffer(t), strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+	return "--- " + translate("Expected") + "\n+++ " + translate("Actual") + "\n" + unifiedDiff(edits, diffContext())
+}
+
+// textDiff returns the diff of expected and actual computed by diffText
+// when they are strings and one of them spans several lines, or an empty
+// string.
+func textDiff(t testing.TB, expected, actual any) string {
+	e, ok := expected.(string)
+	if !ok {
+		return ""
+	}
+	a, ok := actual.(string)
+	if !ok || !strings.Contains(e, "\n") && !strings.Contains(a, "\n") {
+		return ""
+	}
+	return diffText(t, e, a)
+}
+
+// diffWith returns the edits turning a into b computed by d, which only
+// compares the lines between their common prefix and suffix.
+func diffWith(d Differ, a, b []string) []DiffEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]DiffEdit, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		edits = append(edits, DiffEdit{' ', line})
+	}
+	edits = append(edits, d.Diff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, DiffEdit{' ', line})
+	}
+	return edits
+}
+
+// maxDiffEdits bounds the number of edits searched by diffLines, which
+// needs a time and memory growing with it.
+const maxDiffEdits = 1000
+
+// diffLines computes the shortest edit script turning a into b with the
+// Myers algorithm. When it needs more than maxDiffEdits edits, it falls
+// back to removing all the lines of a and adding those of b.
+func diffLines(a, b []string) []DiffEdit {
+	n, m := len(a), len(b)
+	max := minInt(n+m, maxDiffEdits)
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// The trace holds the furthest x reached on each diagonal k, from -d
+	// to d, after each step d.
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+	return replaceLines(a, b)
+}
+
+// backtrack walks the trace of diffLines back to build the edit script.
+func backtrack(trace [][]int, a, b []string) []DiffEdit {
+	var edits []DiffEdit
+	x, y := len(a), len(b)
+
+	for d := len(trace); d > 0; d-- {
+		// The diagonals of the previous step, from -(d-1) to d-1.
+		v, offset := trace[d-1], d-1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, DiffEdit{' ', a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			edits = append(edits, DiffEdit{'+', b[y-1]})
+		} else {
+			edits = append(edits, DiffEdit{'-', a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		edits = append(edits, DiffEdit{' ', a[x-1]})
+		x--
+		y--
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// replaceLines returns the edits removing all the lines of a,
+// then adding those of b.
+func replaceLines(a, b []string) []DiffEdit {
+	edits := make([]DiffEdit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, DiffEdit{'-', line})
+	}
+	for _, line := range b {
+		edits = append(edits, DiffEdit{'+', line})
+	}
+	return edits
+}
+
+// unifiedDiff formats edits as unified diff hunks, with context unchanged
+// lines around each change. A negative context shows all lines.
+func unifiedDiff(edits []DiffEdit, context int) string {
+	if context < 0 {
+		context = len(edits)
+	}
+
+	// Line numbers, in expected and actual, before each edit.
+	aLine := make([]int, len(edits)+1)
+	bLine := make([]int, len(edits)+1)
+	for i, e := range edits {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if e.Op != '+' {
+			aLine[i+1]++
+		}
+		if e.Op != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	for start := 0; start < len(edits); {
+		first := start
+		for first < len(edits) && edits[first].Op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// Extend the hunk while changes are close enough to share context.
+		from := maxInt(start, first-context)
+		to := first
+		for i := first; i < len(edits) && i <= to+2*context+1; i++ {
+			if edits[i].Op != ' ' {
+				to = i
+			}
+		}
+		end := minInt(len(edits), to+context+1)
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(aLine[from], aLine[end]-aLine[from]),
+			hunkRange(bLine[from], bLine[end]-bLine[from]))
+		for _, e := range edits[from:end] {
+			b.WriteByte(e.Op)
+			b.WriteString(e.Line)
+			b.WriteByte('\n')
+		}
+		start = end
+	}
+	return b.String()
+}
+
+// hunkRange formats the range of a hunk, whose first line follows line.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line)
+	}
+	return fmt.Sprintf("%d,%d", line+1, count)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,424 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"equal", "a b c", "a b c", " a b c"},
+		{"both empty", "", "", ""},
+		{"insert", "a c", "a b c", " a+b c"},
+		{"delete", "a b c", "a c", " a-b c"},
+		{"replace", "a b c", "a x c", " a-b+x c"},
+		{"from empty", "", "a b", "+a+b"},
+		{"to empty", "a b", "", "-a-b"},
+		{"classic", "a b c a b b a", "c b a b a c", "-a-b c+b a b-b a+c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := diffLines(strings.Fields(tt.a), strings.Fields(tt.b))
+
+			var got strings.Builder
+			for _, e := range edits {
+				got.WriteByte(e.Op)
+				got.WriteString(e.Line)
+			}
+			if got.String() != tt.want {
+				t.Errorf("diffLines() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesIsMinimal(t *testing.T) {
+	a := strings.Fields("a b c a b b a")
+	b := strings.Fields("c b a b a c")
+
+	changes := 0
+	for _, e := range diffLines(a, b) {
+		if e.Op != ' ' {
+			changes++
+		}
+	}
+	if changes != 5 {
+		t.Errorf("diffLines() made %d changes, want 5", changes)
+	}
+}
+
+// numberedLines returns n lines made of prefix and their index.
+func numberedLines(prefix string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%s %d", prefix, i)
+	}
+	return lines
+}
+
+func TestDiffLinesBounded(t *testing.T) {
+	t.Run("unrelated texts are replaced", func(t *testing.T) {
+		a, b := numberedLines("old", 3000), numberedLines("new", 3000)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		edits := diffLines(a, b)
+		runtime.ReadMemStats(&after)
+
+		Equal(t, edits, replaceLines(a, b))
+		LessOrEqual(t, after.TotalAlloc-before.TotalAlloc, 32<<20, "bytes allocated")
+	})
+
+	t.Run("edits within the bound are minimal", func(t *testing.T) {
+		a := numberedLines("line", 5000)
+		b := append([]string(nil), a...)
+		for i := 0; i < len(b); i += 20 {
+			b[i] += " changed"
+		}
+
+		changes := 0
+		for _, e := range diffLines(a, b) {
+			if e.Op != ' ' {
+				changes++
+			}
+		}
+		Equal(t, changes, 500)
+	})
+}
+
+func TestDiffText(t *testing.T) {
+	lines := func(from, to int) string {
+		var parts []string
+		for i := from; i <= to; i++ {
+			parts = append(parts, string(rune('a'+i-1)))
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		if got := diffText(t, "a\nb", "a\nb"); got != "" {
+			t.Errorf("diffText() = %q, want empty", got)
+		}
+	})
+
+	t.Run("single hunk", func(t *testing.T) {
+		expected := lines(1, 10)
+		actual := strings.Replace(expected, "e", "E", 1)
+
+		want := "--- Expected\n+++ Actual\n" +
+			"@@ -2,7 +2,7 @@\n b\n c\n d\n-e\n+E\n f\n g\n h\n"
+		if got := diffText(t, expected, actual); got != want {
+			t.Errorf("diffText() =\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("separate hunks", func(t *testing.T) {
+		expected := lines(1, 20)
+		actual := strings.Replace(strings.Replace(expected, "b", "B", 1), "s", "S", 1)
+
+		want := "--- Expected\n+++ Actual\n" +
+			"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+			"@@ -16,5 +16,5 @@\n p\n q\n r\n-s\n+S\n t\n"
+		if got := diffText(t, expected, actual); got != want {
+			t.Errorf("diffText() =\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("close changes share a hunk", func(t *testing.T) {
+		expected := lines(1, 12)
+		actual := strings.Replace(strings.Replace(expected, "c", "C", 1), "i", "I", 1)
+
+		if got := strings.Count(diffText(t, expected, actual), "@@ -"); got != 1 {
+			t.Errorf("diffText() produced %d hunks, want 1", got)
+		}
+	})
+
+	t.Run("insertion into empty", func(t *testing.T) {
+		want := "--- Expected\n+++ Actual\n@@ -1,1 +1,2 @@\n-\n+a\n+b\n"
+		if got := diffText(t, "", "a\nb"); got != want {
+			t.Errorf("diffText() =\n%q\nwant:\n%q", got, want)
+		}
+	})
+}
+
+func TestSetDiffContext(t *testing.T) {
+	defer SetDiffContext(SetDiffContext(defaultDiffContext))
+
+	expected := "a\nb\nc\nd\ne\nf\ng\nh\ni"
+	actual := strings.Replace(expected, "e", "E", 1)
+
+	tests := []struct {
+		name  string
+		lines int
+		want  string
+	}{
+		{"no context", 0, "@@ -5,1 +5,1 @@\n-e\n+E\n"},
+		{"one line", 1, "@@ -4,3 +4,3 @@\n d\n-e\n+E\n f\n"},
+		{"full", FullDiff, "@@ -1,9 +1,9 @@\n a\n b\n c\n d\n-e\n+E\n f\n g\n h\n i\n"},
+		{"negative is full", -5, "@@ -1,9 +1,9 @@\n a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDiffContext(tt.lines)
+
+			if got := diffText(t, expected, actual); !strings.Contains(got, tt.want) {
+				t.Errorf("diffText() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("previous setting", func(t *testing.T) {
+		SetDiffContext(2)
+
+		if got := SetDiffContext(5); got != 2 {
+			t.Errorf("SetDiffContext() = %d, want 2", got)
+		}
+	})
+}
+
+func TestDiffContextEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", defaultDiffContext},
+		{"full", FullDiff},
+		{"0", 0},
+		{"10", 10},
+		{"-1", defaultDiffContext},
+		{"many", defaultDiffContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(diffContextEnv, tt.value)
+
+			diffContextMu.Lock()
+			set := diffContextSet
+			diffContextSet = false
+			diffContextMu.Unlock()
+			defer func() {
+				diffContextMu.Lock()
+				diffContextSet = set
+				diffContextMu.Unlock()
+			}()
+
+			if got := diffContext(); got != tt.want {
+				t.Errorf("diffContext() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// applyEdits returns the texts before and after edits.
+func applyEdits(edits []DiffEdit) (a, b []string) {
+	for _, e := range edits {
+		if e.Op != '+' {
+			a = append(a, e.Line)
+		}
+		if e.Op != '-' {
+			b = append(b, e.Line)
+		}
+	}
+	return a, b
+}
+
+func TestDiffers(t *testing.T) {
+	differs := map[string]Differ{"Myers": MyersDiffer{}, "Patience": PatienceDiffer{}}
+	texts := [][2]string{
+		{"", ""},
+		{"a b c", "a b c"},
+		{"", "a b"},
+		{"a b", ""},
+		{"a b c a b b a", "c b a b a c"},
+		{"x } y } z }", "x } w } y } z }"},
+		{"a b c d", "d c b a"},
+	}
+
+	for name, d := range differs {
+		t.Run(name, func(t *testing.T) {
+			for _, text := range texts {
+				a, b := strings.Fields(text[0]), strings.Fields(text[1])
+
+				gotA, gotB := applyEdits(d.Diff(a, b))
+
+				EqualLoose(t, gotA, a, text[0])
+				EqualLoose(t, gotB, b, text[1])
+			}
+		})
+	}
+}
+
+func TestPatienceDiffer(t *testing.T) {
+	expected := "func a() {\n\treturn 1\n}\n\nfunc b() {\n\treturn 2\n}"
+	actual := "func a() {\n\treturn 1\n}\n\nfunc c() {\n\treturn 3\n}\n\nfunc b() {\n\treturn 2\n}"
+
+	a := New(t)
+	a.SetDiffer(PatienceDiffer{})
+
+	want := "@@ -2,6 +2,10 @@\n" +
+		" \treturn 1\n }\n \n+func c() {\n+\treturn 3\n+}\n+\n func b() {\n \treturn 2\n }\n"
+	StringContains(t, diffText(a, expected, actual), want)
+}
+
+func TestSetDiffer(t *testing.T) {
+	defer SetDiffer(SetDiffer(PatienceDiffer{}))
+
+	t.Run("package-level differ", func(t *testing.T) {
+		Equal(t, lookupDiffer(t), Differ(PatienceDiffer{}))
+	})
+
+	t.Run("Assert differ", func(t *testing.T) {
+		a := New(t)
+		a.SetDiffer(MyersDiffer{})
+
+		Equal(t, lookupDiffer(New(a)), Differ(MyersDiffer{}))
+	})
+
+	t.Run("default differ", func(t *testing.T) {
+		SetDiffer(nil)
+		defer SetDiffer(PatienceDiffer{})
+
+		Equal(t, lookupDiffer(t), Differ(MyersDiffer{}))
+	})
+}
+
+// countingDiffer is a MyersDiffer counting its calls.
+type countingDiffer struct{ calls *int }
+
+func (d countingDiffer) Diff(expected, actual []string) []DiffEdit {
+	*d.calls++
+	return MyersDiffer{}.Diff(expected, actual)
+}
+
+func TestDifferOfAssertions(t *testing.T) {
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantCalls int
+		wantDiff  string
+	}{
+		{
+			name:      "Equal multi-line strings",
+			assert:    func(t testing.TB) { Equal(t, "a\nb\nc", "a\nx\nc") },
+			wantCalls: 1,
+			wantDiff:  "Diff:\n    --- Expected\n    +++ Actual\n    @@ -1,3 +1,3 @@\n     a\n    -x\n    +b\n     c\n",
+		},
+		{
+			name:   "Equal single-line strings",
+			assert: func(t testing.TB) { Equal(t, "a", "b") },
+		},
+		{
+			name:      "JSONEq",
+			assert:    func(t testing.TB) { JSONEq(t, `{"a": 1, "b": 2}`, `{"b": 3, "a": 1}`) },
+			wantCalls: 1,
+			wantDiff:  "    -  \"b\": 3\n    +  \"b\": 2\n",
+		},
+		{
+			name:   "JSONEq with tolerance",
+			assert: func(t testing.TB) { JSONEq(t, `{"a": 1}`, `{"a": 2}`, NumberTolerance(0.1)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			calls := 0
+			a := New(rec)
+			a.SetDiffer(countingDiffer{calls: &calls})
+
+			tt.assert(a)
+
+			True(t, rec.HasError())
+			Equal(t, calls, tt.wantCalls)
+			if tt.wantDiff != "" {
+				StringContains(t, rec.ErrorMessage(), tt.wantDiff)
+			} else {
+				False(t, strings.Contains(rec.ErrorMessage(), "Diff:"), rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("patience", func(t *testing.T) {
+		defer SetDiffer(SetDiffer(PatienceDiffer{}))
+		rec := NewTestRecorder(t)
+
+		expected := "func a() {\n\treturn 1\n}\n\nfunc b() {\n\treturn 2\n}"
+		actual := "func a() {\n\treturn 1\n}\n\nfunc c() {\n\treturn 3\n}\n\nfunc b() {\n\treturn 2\n}"
+		Equal(rec, actual, expected)
+
+		StringContains(t, rec.ErrorMessage(), "    +func c() {\n    +\treturn 3\n    +}\n    +\n     func b() {\n")
+	})
+}
+
+func BenchmarkDiffers(b *testing.B) {
+	var expected, actual []string
+	for i := 0; i < 2000; i++ {
+		line := fmt.Sprintf("line %d", i)
+		expected = append(expected, line, "}")
+		if i%5 == 0 {
+			line += " changed"
+		}
+		actual = append(actual, line, "}")
+	}
+
+	for name, d := range map[string]Differ{"Myers": MyersDiffer{}, "Patience": PatienceDiffer{}} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				diffWith(d, expected, actual)
+			}
+		})
+	}
+}
+
+func TestPatienceDifferUnanchored(t *testing.T) {
+	// Every line is repeated, so that no line anchors the texts.
+	a, b := make([]string, 10000), make([]string, 10000)
+	for i := range a {
+		a[i] = fmt.Sprintf("line %d", i%100)
+		b[i] = fmt.Sprintf("line %d", (i*7)%100)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	edits := PatienceDiffer{}.Diff(a, b)
+	runtime.ReadMemStats(&after)
+
+	gotA, gotB := applyEdits(edits)
+	Equal(t, gotA, a)
+	Equal(t, gotB, b)
+	LessOrEqual(t, after.TotalAlloc-before.TotalAlloc, 32<<20, "bytes allocated")
+}
+
+func BenchmarkPatienceDifferUnanchored(b *testing.B) {
+	x, y := make([]string, 10000), make([]string, 10000)
+	for i := range x {
+		x[i] = fmt.Sprintf("line %d", i%100)
+		y[i] = fmt.Sprintf("line %d", (i*7)%100)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PatienceDiffer{}.Diff(x, y)
+	}
+}
+
+func BenchmarkDiffLinesUnrelated(b *testing.B) {
+	x, y := numberedLines("old", 10000), numberedLines("new", 10000)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		diffLines(x, y)
+	}
+}
@@ -21,40 +21,183 @@
 //
 // Basic Comparisons:
 //   - Equal/NotEqual: Compare values of any type
+//   - EqualDeref: Compare values behind pointers
+//   - EqualLoose: Compare values, treating nil and empty slices or maps as equal
+//   - EqualWith: Compare values with options such as IgnoreUnexported or SameAliasing
+//   - MaxDepth/MaxElements: Limit the levels and elements EqualWith shows of large values
 //   - True/False: Boolean assertions
 //   - Nil/NotNil: Check for nil values
+//   - Satisfies: Check a value against a described predicate
+//   - IsSlice/IsMap/IsPointer/IsFunc/IsStruct: Check the kind of a value
+//
+// Struct fields tagged `assert:"-"` (or `assert:"ignore"`) are excluded
+// from all comparisons.
 //
 // Error Handling:
 //   - Error: Assert that an error occurred (i.e., the error is not nil).
 //   - NoError: Assert that no error occurred (i.e., the error is nil).
 //   - EqualError: Assert that the error returned (if any) is equal to the expected error (compares error messages).
+//   - EqualErrorMessage: Assert that an error occurred with the expected message, whatever its value.
+//   - ErrorCountIs/EachError: Assert the number of errors joined in an error, or run assertions on each of them.
 //   - ErrorIs: Check if an error matches a specific error value anywhere in its chain of wrapped errors.
 //   - ErrorAs: Check if an error (or any error it wraps) matches a specific error type and extracts it.
 //   - Panics: Test for panic conditions.
 //
 // Collection Operations:
 //   - Contains/NotContains: Check if a slice contains (or not) an element
-//   - Empty: Verify if a collection is empty
+//   - ContainsFunc/NotContainsFunc: Check if an element satisfies (or not) a predicate
+//   - CountEquals: Check the number of occurrences of an element
+//   - Empty/NotEmpty: Verify if a collection is empty (or not), honoring Len and IsZero methods
+//   - EmptySlice/EmptyMap: Type-safe variants of Empty
 //   - Len: Check collection length
+//   - LenSlice/LenMap: Type-safe variants of Len
+//   - LenOf: Check a collection has the length of another, reporting surplus and missing elements
 //   - HasKey: Verify map key existence
+//   - KeySetEquals: Check the keys of a map in any order, listing missing and extra keys
+//   - SlicesEqualFunc/EqualBy: Compare slices with a custom equality or by key
+//   - EqualKeyed: Match the elements of two slices by key in any order and compare them field by field
+//   - MapsEqualFunc: Compare maps with a custom value equality
 //
 // String Operations:
 //   - StringContains: Check string containment
+//   - StringCount: Check the number of occurrences of a substring
 //   - HasPrefix: Verify if a string starts with a prefix
 //   - HasSuffix: Verify if a string ends with a suffix
 //   - MatchRegexp: Check if a string matches a regular expression pattern
+//   - StringContainsFold/HasPrefixFold/HasSuffixFold: Case-insensitive variants
 //
 // Numeric Comparisons:
 //   - Greater: Compare if a value is strictly greater
 //   - GreaterOrEqual: Compare if a value is greater or equal
+//   - Less: Compare if a value is strictly less
 //   - LessOrEqual: Compare if a value is less or equal
 //   - Between: Check if a value falls within a range
+//   - IntEquals/IntGreater/IntLess: Compare integers of different types without overflow
+//   - MinElementEquals/MaxElementEquals: Check the smallest or largest element of a slice
+//   - AllGreater/AllLess: Check that every element of a slice is within a bound
+//
+// Numeric comparison failures report how far the value is from the bound,
+// with durations shown in human-friendly units.
+//
+// Documents:
+//   - HTMLEq: Compare HTML fragments, ignoring formatting
+//   - HTMLSelectorText/HTMLSelectorCount: Check parts of a page selected with CSS selectors
+//   - JSONEq: Compare JSON documents by path, with exact numbers and optionally unordered arrays
+//   - MatchesJSONSchema: Validate a JSON document against a JSON Schema (draft 2020-12)
+//   - MatchesOpenAPI: Validate a response body against the schema declared by an OpenAPI spec
+//   - TOMLEq: Compare TOML documents by key path, regardless of how tables are written
+//   - XMLPath/XMLPathExists: Check nodes of XML documents selected with XPath-like paths
+//   - GraphQLNoErrors/GraphQLDataPath/GraphQLErrorCode: Check GraphQL response envelopes
+//   - CSVEquals: Compare CSV documents cell by cell
+//   - CBOREq/MsgPackEq: Compare binary payloads by their decoded values
+//   - TemplateRenders: Execute a template and compare its output with a line diff
+//
+// Time:
+//   - TimeBetween: Check if a time falls within a window
+//   - ParsesAsTime/IsRFC3339: Check the format of a timestamp and return the parsed time
+//   - TimesIncreasing/TimesNonDecreasing: Check that times are in chronological order
+//
+// Integrity and Secrets:
+//   - HashEquals: Check the digest of content, streamed from a reader
+//   - SecretsEqual: Compare secrets in constant time without leaking them
+//
+// HTTP Servers:
+//   - NewServer: Start a test server recording requests, with canned responses
+//   - Server.ReceivedRequest/Server.ReceivedBodyJSONEq: Check the requests received
+//   - FormFieldEquals/FormFieldsMatch: Check the fields of URL-encoded form bodies
+//   - RedirectsTo/RedirectChain: Check redirect responses and the hops followed
+//
+// Network:
+//   - DialSucceeds/PortListening: Wait for a server to accept connections
+//
+// Commands:
+//   - CmdSucceeds/CmdExitCode: Run a command and check its exit status
+//   - CmdOutputContains: Run a command and check its output
+//   - ExitsWith: Check that a function exits the process with a given code
+//
+// Fixtures:
+//   - LoadJSON: Load a JSON fixture file, reporting errors with their position
+//   - LoadYAML: Load a YAML fixture file, reporting errors with their line
+//   - TempFileWith/TempDirWithFiles: Create temporary files removed with the test
+//   - FileExists/FileContentEquals: Check files and their content
+//
+// Preconditions:
+//   - SkipUnlessEnv/SkipUnlessNetwork: Skip tests whose environment is not available
+//   - RequireCommand: Stop tests when a required command is not installed
+//
+// Concurrency:
+//   - Concurrently: Run assertions in several goroutines and report which one failed
+//   - RaceCheck: Run functions concurrently many times and check the shared state after each run
+//   - Within: Fail with a goroutine dump when a block does not complete in time
+//   - Go: Run a goroutine whose panics are reported as failures, and Wait for it with a timeout
+//   - LocksWithin/TryLockEventually: Fail with goroutine stacks when acquiring locks hangs
+//   - AtomicEquals: Load an atomic value once and compare it
+//   - AtomicEventually: Wait for an atomic value to reach an expected value
+//
+// Test Doubles:
+//   - EventRecorder: Record a sequence of events, such as lifecycle callbacks
+//   - InOrder/ExactlyOnce: Check the order and multiplicity of recorded events
+//   - NewSpy: Wrap a function value to record its calls
+//   - CalledTimes/CalledWith: Check the calls recorded by a Spy
+//   - SetClock/NewFakeClock: Run the assertions waiting or measuring time on a fake clock
+//
+// Resources:
+//   - NoFDLeaks: Check that a test closes the file descriptors it opens
+//   - TrackCloser/TrackReadCloser/AllClosed: Check that closers are closed exactly once by the end of a test
+//
+// Performance:
+//   - Allocates: Pin the number of allocations of a function
+//   - MaxAllocsPerRun: Check the average number of allocations of a function
+//   - RunsWithin: Check that a function completes within a duration
+//
+// Matchers:
+//   - Match: Check a value against a composable Matcher
+//   - BeEmpty/HaveLen/BeNumerically/ContainElement: Built-in matchers
+//   - And/Or/Not: Combine matchers into a single condition
+//   - NewMatcher: Build a custom matcher from a predicate
+//
+// Non-Failing Checks:
+//   - Check: Run assertions and return their failures as an error
+//   - CheckEqual/CheckNil/CheckNoError/...: Single comparisons returning an error
+//   - Retry: Rerun assertions until they pass, reporting the last attempt
+//
+// Test Organization:
+//   - New: Bind assertions to a testing.TB through an Assert instance
+//   - Require: Bind assertions to a testing.TB, stopping the test at the first failure
+//   - WithLabel/Label: Prefix failures with breadcrumbs of their context
+//   - Scope: Prefix the failures of nested assertions with the path of the value examined
+//   - WithCallerSkip: Locate the failures of shared helpers on the line of the test calling them
+//   - WithTrace: Log the assertions that pass, with their compared values
+//   - WithSummary: Report failures on a single line and summarize the assertions of a test
+//   - RunSuite: Run the Test methods of a struct with lifecycle hooks
+//   - NewSafeTB: Assert from goroutines other than the test goroutine
+//   - RequireAssertions: Fail tests that complete without executing any assertion
+//
+// Table Tests:
+//   - Table: Run a slice of Case values as subtests with common assertions
+//
+// Property Checks:
+//   - ForAll: Check that a property holds for randomly generated values
+//   - Seed: Get the random seed of a test, shown in its failures and set with ASSERT_SEED
+//   - Deterministic: Check that a function returns equal results when run repeatedly
+//   - FuzzAssert: Run assertions inside fuzz targets, reporting the failing input
+//
+// Failure Output:
+//   - SetFailureTemplate: Lay out failure messages with a text/template, globally or per Assert
+//   - SetLocationFormat: Format failure locations with relative or absolute paths and function names
+//   - SetReporter/GitHubReporter: Forward failures to CI systems, as annotations on GitHub Actions
+//   - TeamCityReporter: Report failures as TeamCity service messages, shown in its diff viewer
+//   - SetDiffContext: Set the unchanged lines shown around each change of a diff
+//   - SetDiffer/MyersDiffer/PatienceDiffer: Choose the algorithm computing diffs, globally or per Assert
+//   - SetOutputWidth: Wrap long values, side by side on wide terminals
+//   - SetTranslator/Catalog: Localize or reword the phrases of failure messages
 //
 // Each assertion function provides clear error messages that include:
 //   - The file and line number where the assertion failed
 //   - The expected and actual values
 //   - The types of the compared values
-//   - An optional custom message
+//   - An optional custom message, accepted as the last argument of every assertion,
+//     as a Message option for the assertions taking options
 //
 // The error messages are designed to be clear and helpful for debugging:
 //
@@ -0,0 +1,327 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// EqualLoose checks if two values are equal like Equal, except that nil and
+// empty slices or maps are considered equal, at any depth. It avoids false
+// failures on values that went through a JSON round-trip:
+//
+//	assert.EqualLoose(t, decoded, User{Roles: []string{}}) // decoded.Roles is nil
+func EqualLoose[T any](t testing.TB, actual, expected T, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	eq := equalizer{nilEqualsEmpty: true}
+	if !eq.equal(actual, expected) {
+		failCompareNote(t, actual, expected, explainUnequal(expected, actual), msg...)
+	}
+}
+
+// EqualWith checks if two values are equal like Equal,
+// with comparison rules relaxed by the given options:
+//
+//	assert.EqualWith(t, got, want,
+//	    assert.IgnoreUnexported(),
+//	    assert.Message("decoded user"),
+//	)
+func EqualWith[T any](t testing.TB, actual, expected T, opts ...Option) {
+	t.Helper()
+	defer observe(t)()
+
+	o := newOptions(opts)
+	if o.equal.equal(actual, expected) {
+		return
+	}
+	if o.equal.limited() {
+		note := o.equal.diffNote()
+		if o.equal.aliasNote != "" {
+			note = strings.TrimPrefix(note+"; "+o.equal.aliasNote, "; ")
+		}
+		reportFailure(t, o.equal.truncate(actual), o.equal.truncate(expected), note, "", o.messages())
+		return
+	}
+
+	note := o.equal.aliasNote
+	if note == "" {
+		note = explainUnequal(expected, actual)
+	}
+	failCompareNote(t, actual, expected, note, o.messages()...)
+}
+
+// equalizer compares values like reflect.DeepEqual,
+// with relaxed rules selected by its fields.
+type equalizer struct {
+	// nilEqualsEmpty makes nil and empty slices or maps equal.
+	nilEqualsEmpty bool
+
+	// ignoreAllUnexported skips unexported fields of all structs,
+	// and ignoreUnexported those of the structs of the given types.
+	ignoreAllUnexported bool
+	ignoreUnexported    map[reflect.Type]bool
+
+	// sameAliasing requires references to be shared the same way in
+	// both values, recording their pairs in aliases. aliasNote explains
+	// the first mismatch.
+	sameAliasing bool
+	aliases      [2]map[alias]uintptr
+	aliasNote    string
+
+	// maxDepth and maxElements limit the levels and the elements per
+	// level shown on failure, when not zero. The values are compared in
+	// full, at path when limited, and diffPath is where they first differ.
+	maxDepth    int
+	maxElements int
+	path        []string
+	diffPath    string
+
+	visited map[visit]bool
+}
+
+// alias identifies a reference by its address and type, since a struct
+// and its first field share their address.
+type alias struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// visit identifies a pair of references already being compared,
+// so that cyclic values terminate.
+type visit struct {
+	x, y uintptr
+	typ  reflect.Type
+}
+
+// equal reports whether x and y are deeply equal.
+func (e *equalizer) equal(x, y any) bool {
+	e.visited = map[visit]bool{}
+	e.path, e.diffPath = nil, ""
+	if e.sameAliasing {
+		e.aliases = [2]map[alias]uintptr{{}, {}}
+		e.aliasNote = ""
+	}
+	return e.deepEqual(reflect.ValueOf(x), reflect.ValueOf(y))
+}
+
+func (e *equalizer) deepEqual(x, y reflect.Value) bool {
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+	if x.Type() != y.Type() {
+		return false
+	}
+
+	switch x.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		if e.sameAliasing && !e.aliased(x, y) {
+			return false
+		}
+		if e.seen(x, y) {
+			return true
+		}
+	}
+
+	switch x.Kind() {
+	case reflect.Array:
+		return e.elementsEqual(x, y)
+	case reflect.Slice:
+		if !e.nilEqualsEmpty && x.IsNil() != y.IsNil() {
+			return false
+		}
+		if x.Len() != y.Len() {
+			return false
+		}
+		if x.Len() > 0 && x.Pointer() == y.Pointer() {
+			return true
+		}
+		return e.elementsEqual(x, y)
+	case reflect.Map:
+		if !e.nilEqualsEmpty && x.IsNil() != y.IsNil() {
+			return false
+		}
+		if x.Len() != y.Len() {
+			return false
+		}
+		if x.Len() > 0 && x.Pointer() == y.Pointer() {
+			return true
+		}
+		iter := x.MapRange()
+		for iter.Next() {
+			if !e.mapEntryEqual(iter.Key(), iter.Value(), y.MapIndex(iter.Key())) {
+				return false
+			}
+		}
+		return true
+	case reflect.Ptr:
+		if x.Pointer() == y.Pointer() {
+			return true
+		}
+		return e.deepEqual(x.Elem(), y.Elem())
+	case reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+		return e.deepEqual(x.Elem(), y.Elem())
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			field := x.Type().Field(i)
+			if e.skipField(x.Type(), field) {
+				continue
+			}
+			e.enterField(field.Name)
+			ok := e.deepEqual(x.Field(i), y.Field(i))
+			if !ok {
+				e.mismatch()
+			}
+			e.leave()
+			if !ok {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		// Like reflect.DeepEqual, funcs are only equal when both are nil.
+		return x.IsNil() && y.IsNil()
+	case reflect.Bool:
+		return x.Bool() == y.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.Int() == y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.Uint() == y.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.Float() == y.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return x.Complex() == y.Complex()
+	case reflect.String:
+		return x.String() == y.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return x.Pointer() == y.Pointer()
+	default:
+		return false
+	}
+}
+
+// elementsEqual reports whether the arrays or slices x and y, of the same
+// length, hold equal elements.
+func (e *equalizer) elementsEqual(x, y reflect.Value) bool {
+	for i := 0; i < x.Len(); i++ {
+		e.enterIndex(i)
+		ok := e.deepEqual(x.Index(i), y.Index(i))
+		if !ok {
+			e.mismatch()
+		}
+		e.leave()
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// seen records the comparison of two references, and reports whether it
+// was already in progress.
+func (e *equalizer) seen(x, y reflect.Value) bool {
+	if x.IsNil() || y.IsNil() {
+		return false
+	}
+	v := visit{x: x.Pointer(), y: y.Pointer(), typ: x.Type()}
+	if e.visited[v] {
+		return true
+	}
+	e.visited[v] = true
+	return false
+}
+
+// aliased records that the references x and y are paired, and reports
+// whether they were not already paired with other references.
+func (e *equalizer) aliased(x, y reflect.Value) bool {
+	if x.IsNil() || y.IsNil() || (x.Kind() == reflect.Slice && (x.Len() == 0 || y.Len() == 0)) {
+		return true
+	}
+	ax := alias{ptr: x.Pointer(), typ: x.Type()}
+	ay := alias{ptr: y.Pointer(), typ: y.Type()}
+
+	if p, ok := e.aliases[0][ax]; ok && p != ay.ptr {
+		e.aliasNote = fmt.Sprintf("actual shares a %s where expected holds distinct ones", x.Type())
+		return false
+	}
+	if p, ok := e.aliases[1][ay]; ok && p != ax.ptr {
+		e.aliasNote = fmt.Sprintf("expected shares a %s where actual holds distinct ones", y.Type())
+		return false
+	}
+	e.aliases[0][ax] = ay.ptr
+	e.aliases[1][ay] = ax.ptr
+	return true
+}
+
+// skipField reports whether the field of the struct type typ is ignored.
+func (e *equalizer) skipField(typ reflect.Type, field reflect.StructField) bool {
+	if ignoredTag(field) {
+		return true
+	}
+	if !field.IsExported() {
+		return e.ignoreAllUnexported || e.ignoreUnexported[typ]
+	}
+	return false
+}
+
+// ignoredTag reports whether a struct field is excluded from all comparisons
+// with an `assert:"-"` or `assert:"ignore"` tag:
+//
+//	type User struct {
+//	    ID        int
+//	    UpdatedAt time.Time `assert:"-"`
+//	}
+func ignoredTag(field reflect.StructField) bool {
+	tag := field.Tag.Get("assert")
+	return tag == "-" || tag == "ignore"
+}
+
+// taggedTypes caches the result of mayHaveIgnoredFields by type.
+var taggedTypes sync.Map
+
+// mayHaveIgnoredFields reports whether values of typ may hold struct fields
+// ignored by tag. Interfaces can hold such structs, so they are assumed to.
+func mayHaveIgnoredFields(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if has, ok := taggedTypes.Load(typ); ok {
+		return has.(bool)
+	}
+	has := scanIgnoredFields(typ, map[reflect.Type]bool{})
+	taggedTypes.Store(typ, has)
+	return has
+}
+
+func scanIgnoredFields(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return scanIgnoredFields(typ.Elem(), seen)
+	case reflect.Map:
+		return scanIgnoredFields(typ.Key(), seen) || scanIgnoredFields(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if ignoredTag(field) || scanIgnoredFields(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
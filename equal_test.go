@@ -0,0 +1,376 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type profile struct {
+	Name  string
+	Roles []string
+	Meta  map[string]string
+	Next  *profile
+
+	secret int
+}
+
+func TestEqualLoose(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    any
+		expected  any
+		wantError bool
+	}{
+		{
+			name:      "nil and empty slice",
+			actual:    []string(nil),
+			expected:  []string{},
+			wantError: false,
+		},
+		{
+			name:      "nil and empty map",
+			actual:    map[string]int{},
+			expected:  map[string]int(nil),
+			wantError: false,
+		},
+		{
+			name:      "nested nil and empty",
+			actual:    profile{Name: "a", Next: &profile{Roles: nil}},
+			expected:  profile{Name: "a", Meta: map[string]string{}, Next: &profile{Roles: []string{}}},
+			wantError: false,
+		},
+		{
+			name:      "slices in a map",
+			actual:    map[string][]int{"a": nil},
+			expected:  map[string][]int{"a": {}},
+			wantError: false,
+		},
+		{
+			name:      "different elements",
+			actual:    []string{"a"},
+			expected:  []string{"b"},
+			wantError: true,
+		},
+		{
+			name:      "nil and non-empty slice",
+			actual:    []string(nil),
+			expected:  []string{"a"},
+			wantError: true,
+		},
+		{
+			name:      "different types",
+			actual:    1,
+			expected:  "1",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualLoose(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualLoose() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+// counter mimics a type holding internal state that is not part of its value.
+type counter struct {
+	Name string
+
+	mu   sync.Mutex
+	hits int
+}
+
+func TestEqualWith(t *testing.T) {
+	busy := &counter{Name: "requests", hits: 3}
+	busy.mu.Lock()
+	defer busy.mu.Unlock()
+
+	tests := []struct {
+		name      string
+		actual    any
+		expected  any
+		opts      []Option
+		wantError bool
+	}{
+		{
+			name:      "unexported fields differ",
+			actual:    busy,
+			expected:  &counter{Name: "requests"},
+			wantError: true,
+		},
+		{
+			name:      "all unexported fields ignored",
+			actual:    busy,
+			expected:  &counter{Name: "requests"},
+			opts:      []Option{IgnoreUnexported()},
+			wantError: false,
+		},
+		{
+			name:      "unexported fields of type ignored",
+			actual:    busy,
+			expected:  &counter{Name: "requests"},
+			opts:      []Option{IgnoreUnexported(counter{})},
+			wantError: false,
+		},
+		{
+			name:      "unexported fields of other type kept",
+			actual:    profile{secret: 1},
+			expected:  profile{secret: 2},
+			opts:      []Option{IgnoreUnexported(counter{})},
+			wantError: true,
+		},
+		{
+			name:      "exported fields still compared",
+			actual:    &counter{Name: "a"},
+			expected:  &counter{Name: "b"},
+			opts:      []Option{IgnoreUnexported()},
+			wantError: true,
+		},
+		{
+			name:      "options combined",
+			actual:    profile{Roles: nil, secret: 1},
+			expected:  profile{Roles: []string{}},
+			opts:      []Option{IgnoreUnexported(), NilEqualsEmpty()},
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualWith(rec, tt.actual, tt.expected, tt.opts...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualWith() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		EqualWith(rec, 1, 2, Message("custom"))
+
+		if !strings.Contains(rec.ErrorMessage(), "Message: custom") {
+			t.Errorf("EqualWith() message missing custom message\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+// record is a model type excluding volatile fields from comparisons.
+type record struct {
+	ID        int
+	Name      string
+	UpdatedAt time.Time `assert:"-"`
+	ETag      string    `assert:"ignore"`
+}
+
+func TestSameAliasing(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	type pair struct {
+		A, B *node
+		Tags []string
+		More []string
+	}
+
+	shared := &node{Name: "x"}
+	ring := &node{Name: "ring"}
+	ring.Next = ring
+	tags := []string{"a"}
+
+	tests := []struct {
+		name      string
+		actual    pair
+		expected  pair
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name:     "shared in both",
+			actual:   func() pair { n := &node{Name: "x"}; return pair{A: n, B: n} }(),
+			expected: pair{A: shared, B: shared},
+		},
+		{
+			name:     "distinct in both",
+			actual:   pair{A: &node{Name: "x"}, B: &node{Name: "x"}},
+			expected: pair{A: &node{Name: "x"}, B: &node{Name: "x"}},
+		},
+		{
+			name:     "cyclic values",
+			actual:   func() pair { n := &node{Name: "ring"}; n.Next = n; return pair{A: n, B: n} }(),
+			expected: pair{A: ring, B: ring},
+		},
+		{
+			name:      "shared reference copied",
+			actual:    pair{A: &node{Name: "x"}, B: &node{Name: "x"}},
+			expected:  pair{A: shared, B: shared},
+			wantError: true,
+			wantNote:  "expected shares a *assert.node where actual holds distinct ones",
+		},
+		{
+			name:      "distinct references shared",
+			actual:    pair{A: shared, B: shared},
+			expected:  pair{A: &node{Name: "x"}, B: &node{Name: "x"}},
+			wantError: true,
+			wantNote:  "actual shares a *assert.node where expected holds distinct ones",
+		},
+		{
+			name:      "shared slice",
+			actual:    pair{Tags: []string{"a"}, More: []string{"a"}},
+			expected:  pair{Tags: tags, More: tags},
+			wantError: true,
+			wantNote:  "expected shares a []string",
+		},
+		{
+			name:      "different values",
+			actual:    pair{A: &node{Name: "x"}},
+			expected:  pair{A: &node{Name: "y"}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualWith(rec, tt.actual, tt.expected, SameAliasing())
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualWith() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("EqualWith() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("ignored without the option", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		EqualWith(rec, pair{A: &node{Name: "x"}, B: &node{Name: "x"}}, pair{A: shared, B: shared})
+
+		if rec.HasError() {
+			t.Errorf("EqualWith() reported %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestIgnoredFields(t *testing.T) {
+	a := record{ID: 1, Name: "a", UpdatedAt: time.Unix(1, 0), ETag: "x"}
+	b := record{ID: 1, Name: "a", UpdatedAt: time.Unix(2, 0), ETag: "y"}
+	c := record{ID: 2, Name: "a"}
+
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"Equal ignores tagged fields", func(t testing.TB) { Equal(t, a, b) }, false},
+		{"Equal compares other fields", func(t testing.TB) { Equal(t, a, c) }, true},
+		{"Equal through pointers", func(t testing.TB) { Equal(t, &a, &b) }, false},
+		{"Equal in slices", func(t testing.TB) { Equal(t, []record{a}, []record{b}) }, false},
+		{"Equal in interfaces", func(t testing.TB) { Equal(t, []any{a}, []any{b}) }, false},
+		{"NotEqual ignores tagged fields", func(t testing.TB) { NotEqual(t, a, b) }, true},
+		{"Contains ignores tagged fields", func(t testing.TB) { Contains(t, []record{c, b}, a) }, false},
+		{"EqualWith ignores tagged fields", func(t testing.TB) { EqualWith(t, a, b) }, false},
+		{"EqualLoose ignores tagged fields", func(t testing.TB) { EqualLoose(t, a, b) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestMayHaveIgnoredFields(t *testing.T) {
+	type tree struct {
+		Children []*tree
+	}
+
+	tests := []struct {
+		value any
+		want  bool
+	}{
+		{0, false},
+		{"", false},
+		{profile{}, false},
+		{tree{}, false},
+		{record{}, true},
+		{&record{}, true},
+		{map[string][]record{}, true},
+		{[]any{}, true},
+		{struct{ Err error }{}, true},
+	}
+
+	for _, tt := range tests {
+		if got := mayHaveIgnoredFields(reflect.TypeOf(tt.value)); got != tt.want {
+			t.Errorf("mayHaveIgnoredFields(%T) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEqualizerMatchesDeepEqual(t *testing.T) {
+	cyclic := &profile{Name: "a"}
+	cyclic.Next = cyclic
+	other := &profile{Name: "a"}
+	other.Next = other
+
+	fn := func() {}
+	ch := make(chan int)
+
+	values := []struct {
+		x, y any
+	}{
+		{1, 1},
+		{1, 2},
+		{int8(1), 1},
+		{"a", "a"},
+		{[]int(nil), []int{}},
+		{[]int{1, 2}, []int{1, 2}},
+		{[2]int{1, 2}, [2]int{1, 3}},
+		{map[string]int{"a": 1}, map[string]int{"a": 1}},
+		{map[string]int{"a": 1}, map[string]int{"b": 1}},
+		{&profile{Name: "a"}, &profile{Name: "a"}},
+		{profile{secret: 1}, profile{secret: 2}},
+		{cyclic, other},
+		{any(nil), any(nil)},
+		{[]any{1, "a"}, []any{1, "a"}},
+		{[]any{nil}, []any{1}},
+		{fn, fn},
+		{(func())(nil), (func())(nil)},
+		{ch, ch},
+		{ch, make(chan int)},
+		{1.5, 1.5},
+		{complex(1, 2), complex(1, 2)},
+	}
+
+	for _, v := range values {
+		var e equalizer
+		if got, want := e.equal(v.x, v.y), reflect.DeepEqual(v.x, v.y); got != want {
+			t.Errorf("equal(%#v, %#v) = %v, want %v", v.x, v.y, got, want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// EventRecorder records a sequence of named events, such as lifecycle
+// callbacks, to check their order and multiplicity with InOrder and
+// ExactlyOnce. It is safe for concurrent use, and its zero value is ready
+// to use:
+//
+//	var events assert.EventRecorder
+//	conn := Dial(Hooks{
+//	    OnInit:    func() { events.Record("init") },
+//	    OnConnect: func() { events.Record("connect") },
+//	})
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+// Record appends event to the sequence.
+func (r *EventRecorder) Record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+// Events returns a copy of the recorded sequence.
+func (r *EventRecorder) Events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.events...)
+}
+
+// InOrder checks if the events were recorded in the given order. Other
+// events may be recorded between them. On failure, the recorded sequence
+// is shown with the first event missing from the order:
+//
+//	assert.InOrder(t, &events, "init", "connect", "ready")
+func InOrder(t testing.TB, rec *EventRecorder, events ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	actual := rec.Events()
+	next := 0
+	for i := 0; i < len(actual) && next < len(events); i++ {
+		if actual[i] == events[next] {
+			next++
+		}
+	}
+	if next == len(events) {
+		return
+	}
+
+	note := fmt.Sprintf("%q never recorded", events[next])
+	if next > 0 {
+		note = fmt.Sprintf("%q not recorded after %q", events[next], events[next-1])
+		if indexes := eventIndexes(actual, events[next]); len(indexes) > 0 {
+			note += fmt.Sprintf(", only at indexes %v", indexes)
+		}
+	}
+	failCompareNote(t, actual, events, note, withMessage("events not recorded in order", nil)...)
+}
+
+// ExactlyOnce checks if event was recorded exactly once. On failure,
+// the recorded sequence is shown with the positions of the event.
+func ExactlyOnce(t testing.TB, rec *EventRecorder, event string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	actual := rec.Events()
+	indexes := eventIndexes(actual, event)
+	switch len(indexes) {
+	case 1:
+		return
+	case 0:
+		failCompareNote(t, actual, []string{event}, fmt.Sprintf("%q never recorded", event),
+			withMessage("event not recorded exactly once", msg)...)
+	default:
+		failCompareNote(t, actual, []string{event},
+			fmt.Sprintf("%q recorded %d times, at indexes %v", event, len(indexes), indexes),
+			withMessage("event not recorded exactly once", msg)...)
+	}
+}
+
+// eventIndexes returns the positions of event in events.
+func eventIndexes(events []string, event string) []int {
+	var indexes []int
+	for i, e := range events {
+		if e == event {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
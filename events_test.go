@@ -0,0 +1,116 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func recordEvents(events ...string) *EventRecorder {
+	var rec EventRecorder
+	for _, e := range events {
+		rec.Record(e)
+	}
+	return &rec
+}
+
+func TestEventRecorder(t *testing.T) {
+	var rec EventRecorder
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec.Record("tick")
+		}()
+	}
+	wg.Wait()
+
+	events := rec.Events()
+	Len(t, events, 10)
+
+	events[0] = "changed"
+	Equal(t, rec.Events()[0], "tick")
+}
+
+func TestInOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		recorded  []string
+		events    []string
+		wantError bool
+		wantNote  string
+	}{
+		{"same sequence", []string{"init", "connect", "ready"}, []string{"init", "connect", "ready"}, false, ""},
+		{"other events between", []string{"init", "log", "connect", "log", "ready"}, []string{"init", "ready"}, false, ""},
+		{"no events expected", nil, nil, false, ""},
+		{
+			name:      "wrong order",
+			recorded:  []string{"init", "ready", "connect"},
+			events:    []string{"init", "connect", "ready"},
+			wantError: true,
+			wantNote:  `Note: "ready" not recorded after "connect", only at indexes [1]`,
+		},
+		{
+			name:      "first event missing",
+			recorded:  []string{"connect"},
+			events:    []string{"init", "connect"},
+			wantError: true,
+			wantNote:  `Note: "init" never recorded`,
+		},
+		{
+			name:      "later event missing",
+			recorded:  []string{"init"},
+			events:    []string{"init", "ready"},
+			wantError: true,
+			wantNote:  `Note: "ready" not recorded after "init"` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			InOrder(rec, recordEvents(tt.recorded...), tt.events...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("InOrder() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("InOrder() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestExactlyOnce(t *testing.T) {
+	tests := []struct {
+		name      string
+		recorded  []string
+		wantError bool
+		wantNote  string
+	}{
+		{"once", []string{"init", "flush", "close"}, false, ""},
+		{"never", []string{"init", "close"}, true, `Note: "flush" never recorded`},
+		{"twice", []string{"flush", "init", "flush"}, true, `Note: "flush" recorded 2 times, at indexes [0 2]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			ExactlyOnce(rec, recordEvents(tt.recorded...), "flush")
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ExactlyOnce() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("ExactlyOnce() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// maxCmdOutput is the number of trailing bytes of an output shown in failures.
+const maxCmdOutput = 1024
+
+// CmdSucceeds runs cmd and checks that it exits with status 0.
+// It returns the standard output of the command. Failures include the
+// captured standard output and error:
+//
+//	out := assert.CmdSucceeds(t, exec.Command("./app", "version"))
+//	assert.HasPrefix(t, out, "app v")
+func CmdSucceeds(t testing.TB, cmd *exec.Cmd, msg ...string) string {
+	t.Helper()
+	defer observe(t)()
+
+	res := runCmd(cmd)
+	if res.code != 0 {
+		failCompareNote(t, res.status(), "exit status 0", res.describe(cmd), withMessage("command failed", msg)...)
+	}
+	return res.stdout
+}
+
+// CmdExitCode runs cmd and checks that it exits with the expected status.
+// It returns the standard output of the command.
+func CmdExitCode(t testing.TB, cmd *exec.Cmd, code int, msg ...string) string {
+	t.Helper()
+	defer observe(t)()
+
+	res := runCmd(cmd)
+	if res.code != code {
+		failCompareNote(t, res.status(), fmt.Sprintf("exit status %d", code), res.describe(cmd),
+			withMessage("unexpected exit status", msg)...)
+	}
+	return res.stdout
+}
+
+// CmdOutputContains runs cmd and checks that its standard output or
+// standard error contains substr, whatever its exit status.
+//
+//	assert.CmdOutputContains(t, exec.Command("./app", "-h"), "usage:")
+func CmdOutputContains(t testing.TB, cmd *exec.Cmd, substr string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	res := runCmd(cmd)
+	if res.err != nil || (!strings.Contains(res.stdout, substr) && !strings.Contains(res.stderr, substr)) {
+		failCompareNote(t, res.status(), fmt.Sprintf("output containing %q", substr), res.describe(cmd),
+			withMessage("command output does not contain expected substring", msg)...)
+	}
+}
+
+// cmdResult is the outcome of a command run by runCmd.
+type cmdResult struct {
+	stdout string
+	stderr string
+	code   int
+	err    error // set when the command could not run
+}
+
+// runCmd runs cmd, capturing its outputs unless they are already redirected.
+func runCmd(cmd *exec.Cmd) cmdResult {
+	var stdout, stderr bytes.Buffer
+	if cmd.Stdout == nil {
+		cmd.Stdout = &stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+
+	res := cmdResult{}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			res.code = exitErr.ExitCode()
+		} else {
+			res.code = -1
+			res.err = err
+		}
+	}
+	res.stdout = stdout.String()
+	res.stderr = stderr.String()
+	return res
+}
+
+// status describes how the command ended.
+func (r cmdResult) status() string {
+	if r.err != nil {
+		return r.err.Error()
+	}
+	return fmt.Sprintf("exit status %d", r.code)
+}
+
+// describe formats the command and its outputs for failure messages.
+func (r cmdResult) describe(cmd *exec.Cmd) string {
+	return fmt.Sprintf("command: %s\n  stdout: %q\n  stderr: %q",
+		cmd.String(), lastBytes(r.stdout, maxCmdOutput), lastBytes(r.stderr, maxCmdOutput))
+}
+
+// lastBytes returns the last n bytes of s, marking it when truncated.
+func lastBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
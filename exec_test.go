@@ -0,0 +1,132 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// helperCommand returns a command running TestHelperProcess, which prints
+// stdout and stderr and exits with the given code.
+func helperCommand(code int, stdout, stderr string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(),
+		"ASSERT_HELPER_PROCESS=1",
+		"ASSERT_HELPER_CODE="+strconv.Itoa(code),
+		"ASSERT_HELPER_STDOUT="+stdout,
+		"ASSERT_HELPER_STDERR="+stderr,
+	)
+	return cmd
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("ASSERT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("ASSERT_HELPER_STDOUT"))
+	fmt.Fprint(os.Stderr, os.Getenv("ASSERT_HELPER_STDERR"))
+	code, _ := strconv.Atoi(os.Getenv("ASSERT_HELPER_CODE"))
+	os.Exit(code)
+}
+
+func TestCmdSucceeds(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		out := CmdSucceeds(rec, helperCommand(0, "v1.2.3", ""))
+
+		if rec.HasError() {
+			t.Errorf("CmdSucceeds() failed: %s", rec.ErrorMessage())
+		}
+		if out != "v1.2.3" {
+			t.Errorf("CmdSucceeds() = %q, want %q", out, "v1.2.3")
+		}
+	})
+
+	t.Run("failure includes outputs", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		CmdSucceeds(rec, helperCommand(3, "partial", "fatal: boom"))
+
+		for _, part := range []string{"command failed", "exit status 3", `stdout: "partial"`, `stderr: "fatal: boom"`} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("CmdSucceeds() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("command not found", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		CmdSucceeds(rec, exec.Command("assert-command-that-does-not-exist"))
+
+		if !rec.HasError() {
+			t.Error("CmdSucceeds() did not fail")
+		}
+	})
+}
+
+func TestCmdExitCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      int
+		expected  int
+		wantError bool
+	}{
+		{"expected failure code", 2, 2, false},
+		{"expected success", 0, 0, false},
+		{"unexpected code", 1, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CmdExitCode(rec, helperCommand(tt.code, "", ""), tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CmdExitCode() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCmdOutputContains(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmd       *exec.Cmd
+		wantError bool
+	}{
+		{"in stdout", helperCommand(0, "usage: app", ""), false},
+		{"in stderr with failure", helperCommand(2, "", "usage: app [flags]"), false},
+		{"missing", helperCommand(0, "hello", "world"), true},
+		{"command not found", exec.Command("assert-command-that-does-not-exist"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CmdOutputContains(rec, tt.cmd, "usage:")
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CmdOutputContains() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestLastBytes(t *testing.T) {
+	if got := lastBytes("hello", 10); got != "hello" {
+		t.Errorf("lastBytes() = %q, want %q", got, "hello")
+	}
+	if got := lastBytes("hello world", 5); got != "...world" {
+		t.Errorf("lastBytes() = %q, want %q", got, "...world")
+	}
+}
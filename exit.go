@@ -0,0 +1,98 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// exitTestEnv names the environment variable selecting, in a re-executed
+// test binary, the ExitsWith call whose function must be run.
+const exitTestEnv = "ASSERT_EXITS_WITH"
+
+// exitReturned is written by the re-executed test binary
+// when the function returns instead of exiting.
+const exitReturned = "assert: function returned without exiting"
+
+// exitCalls counts the ExitsWith calls of each running test, so that
+// a re-executed test binary can select the same call as its parent.
+var exitCalls = struct {
+	sync.Mutex
+	n map[string]int
+}{n: map[string]int{}}
+
+// ExitsWith checks that fn terminates the process with the given exit code,
+// as with os.Exit or log.Fatal. Since the process would exit, fn runs in a
+// re-executed test binary restricted to the current test, like the exit tests
+// of the standard library. The standard error of fn is returned:
+//
+//	out := assert.ExitsWith(t, 1, func() { log.Fatal("missing config") })
+//	assert.StringContains(t, out, "missing config")
+//
+// t must be the running test (or wrap it), and the code of the test before
+// ExitsWith is run again in the subprocess, so it should be free of side effects.
+func ExitsWith(t testing.TB, code int, fn func(), msg ...string) string {
+	t.Helper()
+	defer observe(t)()
+
+	id := fmt.Sprintf("%s#%d", t.Name(), nextExitCall(t))
+
+	if target, ok := os.LookupEnv(exitTestEnv); ok {
+		if target == id {
+			fn()
+			fmt.Fprintln(os.Stderr, exitReturned)
+			os.Exit(0)
+		}
+		return ""
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run="+runPattern(t.Name()), "-test.count=1")
+	cmd.Env = append(os.Environ(), exitTestEnv+"="+id)
+	res := runCmd(cmd)
+	want := fmt.Sprintf("exit status %d", code)
+
+	switch {
+	case res.err != nil:
+		failCompare(t, res.status(), want, withMessage("cannot run the test binary", msg)...)
+	case strings.Contains(res.stderr, exitReturned):
+		failCompare(t, "no exit", want, withMessage("function returned without exiting", msg)...)
+	case res.code != code:
+		failCompareNote(t, res.status(), want, res.describe(cmd), withMessage("unexpected exit status", msg)...)
+	}
+	return res.stderr
+}
+
+// nextExitCall returns the index of the next ExitsWith call of the test.
+// Counters are reset when the test ends, so that repeated runs match.
+func nextExitCall(t testing.TB) int {
+	exitCalls.Lock()
+	defer exitCalls.Unlock()
+
+	name := t.Name()
+	n, ok := exitCalls.n[name]
+	if !ok {
+		t.Cleanup(func() {
+			exitCalls.Lock()
+			defer exitCalls.Unlock()
+			delete(exitCalls.n, name)
+		})
+	}
+	exitCalls.n[name] = n + 1
+	return n
+}
+
+// runPattern returns the -test.run pattern matching exactly the named test.
+func runPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+	return strings.Join(parts, "/")
+}
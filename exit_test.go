@@ -0,0 +1,98 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// exitRecorder returns a recorder running its cleanups at the end of the
+// test, as ExitsWith relies on them when the test is run several times.
+func exitRecorder(t *testing.T) *TestRecorder {
+	rec := NewTestRecorder(t)
+	t.Cleanup(rec.RunCleanups)
+	return rec
+}
+
+func TestExitsWith(t *testing.T) {
+	t.Run("os.Exit", func(t *testing.T) {
+		rec := exitRecorder(t)
+
+		ExitsWith(rec, 3, func() { os.Exit(3) })
+
+		if rec.HasError() {
+			t.Errorf("ExitsWith() failed: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("fatal log", func(t *testing.T) {
+		rec := exitRecorder(t)
+
+		out := ExitsWith(rec, 1, func() { log.Fatal("missing config") })
+
+		if rec.HasError() {
+			t.Errorf("ExitsWith() failed: %s", rec.ErrorMessage())
+		}
+		if !strings.Contains(out, "missing config") {
+			t.Errorf("ExitsWith() output = %q, want fatal log", out)
+		}
+	})
+
+	t.Run("several calls", func(t *testing.T) {
+		rec := exitRecorder(t)
+
+		ExitsWith(rec, 2, func() { os.Exit(2) })
+		ExitsWith(rec, 4, func() { os.Exit(4) })
+
+		if rec.HasError() {
+			t.Errorf("ExitsWith() failed: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("unexpected code", func(t *testing.T) {
+		rec := exitRecorder(t)
+
+		ExitsWith(rec, 1, func() {
+			fmt.Fprint(os.Stderr, "shutting down")
+			os.Exit(2)
+		})
+
+		for _, part := range []string{"unexpected exit status", "exit status 2", "shutting down"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("ExitsWith() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("no exit", func(t *testing.T) {
+		rec := exitRecorder(t)
+
+		ExitsWith(rec, 0, func() {})
+
+		if !strings.Contains(rec.ErrorMessage(), "function returned without exiting") {
+			t.Errorf("ExitsWith() message missing reason\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestRunPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"TestExitsWith", "^TestExitsWith$"},
+		{"TestExitsWith/os.Exit", `^TestExitsWith$/^os\.Exit$`},
+		{"TestA/case_(1)", `^TestA$/^case_\(1\)$`},
+	}
+
+	for _, tt := range tests {
+		if got := runPattern(tt.name); got != tt.want {
+			t.Errorf("runPattern(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
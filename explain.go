@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// maxExplainDepth bounds the traversal of explainUnequal.
+const maxExplainDepth = 32
+
+// explainer walks two values looking for the reason they differ.
+// Visited pointers are remembered to stop on cyclic values.
+type explainer struct {
+	visited map[[2]uintptr]bool
+}
+
+// explainUnequal returns a hint when x and y are not DeepEqual because they
+// contain values for which DeepEqual semantics are surprising: non-nil funcs,
+// distinct channels or NaN floats. It returns an empty string otherwise.
+// It is only meant to be called once a comparison failed.
+func explainUnequal(x, y any) string {
+	e := explainer{visited: map[[2]uintptr]bool{}}
+	return e.explain(reflect.ValueOf(x), reflect.ValueOf(y), "", 0)
+}
+
+func (e explainer) explain(x, y reflect.Value, path string, depth int) string {
+	if depth > maxExplainDepth || !x.IsValid() || !y.IsValid() || x.Type() != y.Type() {
+		return ""
+	}
+
+	switch x.Kind() {
+	case reflect.Func:
+		if !x.IsNil() && !y.IsNil() {
+			return "func values are never DeepEqual" + at(path) + "; compare behavior or pointers instead"
+		}
+	case reflect.Chan:
+		if x.Pointer() != y.Pointer() {
+			return "channel values are DeepEqual only if they are the same channel" + at(path)
+		}
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(x.Float()) {
+			return "NaN is never equal to itself" + at(path)
+		}
+	case reflect.Ptr:
+		if x.IsNil() || y.IsNil() {
+			return ""
+		}
+		key := [2]uintptr{x.Pointer(), y.Pointer()}
+		if e.visited[key] {
+			return ""
+		}
+		e.visited[key] = true
+		return e.explain(x.Elem(), y.Elem(), path, depth+1)
+	case reflect.Interface:
+		if !x.IsNil() && !y.IsNil() {
+			return e.explain(x.Elem(), y.Elem(), path, depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			name := path + "." + x.Type().Field(i).Name
+			if note := e.explain(x.Field(i), y.Field(i), name, depth+1); note != "" {
+				return note
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < x.Len() && i < y.Len(); i++ {
+			if note := e.explain(x.Index(i), y.Index(i), fmt.Sprintf("%s[%d]", path, i), depth+1); note != "" {
+				return note
+			}
+		}
+	case reflect.Map:
+		iter := x.MapRange()
+		for iter.Next() {
+			yv := y.MapIndex(iter.Key())
+			name := fmt.Sprintf("%s[%#v]", path, iter.Key())
+			if note := e.explain(iter.Value(), yv, name, depth+1); note != "" {
+				return note
+			}
+		}
+	}
+	return ""
+}
+
+// at formats the location of an explained value, if any.
+func at(path string) string {
+	if path == "" {
+		return ""
+	}
+	return " (at " + path + ")"
+}
+
+// typedNilNote explains failures involving an interface holding a typed nil
+// pointer, the most common source of confusing nil checks in Go.
+// It returns an empty string for untyped nil and non-nil values.
+func typedNilNote(value any) string {
+	if value == nil || !isNil(value) {
+		return ""
+	}
+	return fmt.Sprintf("value is a non-nil interface containing a nil %T — did you return a typed nil?", value)
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestExplainUnequal(t *testing.T) {
+	type handler struct {
+		Name string
+		Fn   func()
+	}
+
+	ch1, ch2 := make(chan int), make(chan int)
+
+	tests := []struct {
+		name string
+		x    any
+		y    any
+		want string
+	}{
+		{
+			name: "funcs",
+			x:    func() {},
+			y:    func() {},
+			want: "func values are never DeepEqual; compare behavior or pointers instead",
+		},
+		{
+			name: "func field",
+			x:    &handler{Name: "a", Fn: func() {}},
+			y:    &handler{Name: "a", Fn: func() {}},
+			want: "func values are never DeepEqual (at .Fn)",
+		},
+		{
+			name: "nil func",
+			x:    handler{},
+			y:    handler{Name: "b"},
+			want: "",
+		},
+		{
+			name: "distinct channels",
+			x:    []chan int{ch1},
+			y:    []chan int{ch2},
+			want: "same channel (at [0])",
+		},
+		{
+			name: "NaN in map",
+			x:    map[string]float64{"a": math.NaN()},
+			y:    map[string]float64{"a": math.NaN()},
+			want: `NaN is never equal to itself (at ["a"])`,
+		},
+		{
+			name: "plain values",
+			x:    1,
+			y:    2,
+			want: "",
+		},
+		{
+			name: "different types",
+			x:    1,
+			y:    "1",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := explainUnequal(tt.x, tt.y)
+
+			if tt.want == "" && got != "" {
+				t.Errorf("explainUnequal() = %q, want empty", got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("explainUnequal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualExplainsFuncs(t *testing.T) {
+	rec := NewTestRecorder(t)
+	fn := func() {}
+
+	Equal(rec, fn, fn)
+
+	if !strings.Contains(rec.ErrorMessage(), "Note: func values are never DeepEqual") {
+		t.Errorf("Equal() message missing note\ngot: %s", rec.ErrorMessage())
+	}
+}
+
+func TestExplainCyclicValue(t *testing.T) {
+	type node struct {
+		Left, Right *node
+		Fn          func()
+	}
+
+	x := &node{}
+	x.Left, x.Right = x, x
+	y := &node{}
+	y.Left, y.Right = y, y
+
+	if got := explainUnequal(x, y); got != "" {
+		t.Errorf("explainUnequal() = %q, want empty", got)
+	}
+}
+
+func TestTypedNilNote(t *testing.T) {
+	var nilPtr *testError
+	var err error = nilPtr
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{
+			name:  "typed nil in interface",
+			value: err,
+			want:  "non-nil interface containing a nil *assert.testError",
+		},
+		{
+			name:  "untyped nil",
+			value: nil,
+			want:  "",
+		},
+		{
+			name:  "non-nil value",
+			value: &testError{},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := typedNilNote(tt.value)
+
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("typedNilNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
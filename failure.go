@@ -0,0 +1,144 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"testing"
+	"text/template"
+)
+
+// Failure is the data of a failure message, given to failure templates.
+type Failure struct {
+	// Location is the file and line of the failed assertion,
+	// such as "user_test.go:42", formatted by SetLocationFormat.
+	Location string
+	// File and Line locate the failed assertion, File being an
+	// absolute path, whatever the location format.
+	File string
+	Line int
+	// Message is the message of the failure, if any.
+	Message string
+	// Expected and Actual are the compared values, formatted.
+	Expected string
+	Actual   string
+	// Types holds the types of the compared values.
+	Types FailureTypes
+	// Note explains the failure, and Diff shows the differences of the
+	// compared values, when available.
+	Note string
+	Diff string
+	// Seed is the random seed of the test, when drawn by Seed.
+	Seed string
+}
+
+// FailureTypes holds the types of the values compared by an assertion.
+type FailureTypes struct {
+	Expected string
+	Actual   string
+}
+
+var (
+	failureTemplateMu sync.RWMutex
+	failureTemplate   *template.Template
+)
+
+// SetFailureTemplate replaces the layout of the failure messages of all
+// tests with a template executed with a Failure, or restores the default
+// layout when tmpl is nil. It returns the previous template:
+//
+//	assert.SetFailureTemplate(template.Must(template.New("failure").Parse(
+//	    `ASSERT {{.Location}} expected={{.Expected}} actual={{.Actual}}{{with .Message}} msg={{.}}{{end}}`,
+//	)))
+//
+// Failing to execute the template falls back to the default layout.
+// Assert.SetFailureTemplate sets the template of a single Assert.
+func SetFailureTemplate(tmpl *template.Template) *template.Template {
+	failureTemplateMu.Lock()
+	defer failureTemplateMu.Unlock()
+
+	prev := failureTemplate
+	failureTemplate = tmpl
+	return prev
+}
+
+// SetFailureTemplate replaces the layout of the failure messages of
+// the assertions made through a, and the Assert of its subtests, like
+// the package-level SetFailureTemplate. A nil template uses the
+// package-level layout.
+func (a *Assert) SetFailureTemplate(tmpl *template.Template) {
+	a.template = tmpl
+}
+
+// lookupFailureTemplate returns the template of the failure messages
+// reported to t, or nil for the default layout.
+func lookupFailureTemplate(t testing.TB) *template.Template {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			break
+		}
+		if a.template != nil {
+			return a.template
+		}
+		t = a.TB
+	}
+
+	failureTemplateMu.RLock()
+	defer failureTemplateMu.RUnlock()
+
+	return failureTemplate
+}
+
+// newFailure returns the data of a failure reported to t.
+func newFailure(t testing.TB, actual, expected any, note, diff string, msg []string) Failure {
+	f := Failure{
+		Expected: formatValue(expected),
+		Actual:   formatValue(actual),
+		Types: FailureTypes{
+			Expected: comparedType(expected),
+			Actual:   comparedType(actual),
+		},
+		Note: note,
+		Diff: diff,
+	}
+	if frame, ok := callerFrame(t); ok {
+		f.File, f.Line = frame.File, frame.Line
+		if format := lookupLocationFormat(t); format&LocationHidden == 0 {
+			f.Location = formatLocation(frame, format)
+		}
+	}
+	if len(msg) > 0 {
+		f.Message = msg[0]
+	}
+	if seed, ok := testSeed(t); ok {
+		f.Seed = strconv.FormatInt(seed, 10)
+	}
+	return f
+}
+
+// comparedType returns the name of the type of a compared value.
+func comparedType(value any) string {
+	if v, ok := value.(truncated); ok {
+		value = v.value
+	}
+	return typeName(value)
+}
+
+// reportTemplate reports a failure laid out by tmpl, and reports whether
+// the template could be executed.
+func reportTemplate(t testing.TB, tmpl *template.Template, f Failure) bool {
+	t.Helper()
+
+	b := buffers.Get().(*bytes.Buffer)
+	defer putBuffer(b)
+
+	if err := tmpl.Execute(b, f); err != nil {
+		return false
+	}
+	t.Error(b.String())
+	return true
+}
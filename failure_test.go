@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestSetFailureTemplate(t *testing.T) {
+	defer SetFailureTemplate(SetFailureTemplate(template.Must(template.New("failure").Parse(
+		`ASSERT {{.Location}} {{.Types.Expected}}:{{.Expected}} {{.Types.Actual}}:{{.Actual}}` +
+			`{{with .Message}} msg={{.}}{{end}}{{with .Note}} note={{.}}{{end}}{{with .Diff}} diff{{end}}`,
+	))))
+
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		want   string
+	}{
+		{
+			name:   "compared values",
+			assert: func(t testing.TB) { Equal(t, 1, 2) },
+			want:   `^ASSERT failure_test\.go:\d+ int:2 int:1$`,
+		},
+		{
+			name:   "message and note",
+			assert: func(t testing.TB) { Between(t, 5, 1, 3, "score") },
+			want:   `^ASSERT failure_test\.go:\d+ string:"Between 1 and 3" int:5 msg=score: value not within expected range note=over by 2$`,
+		},
+		{
+			name:   "diff",
+			assert: func(t testing.TB) { FileContentEquals(t, TempFileWith(t, "a"), "b") },
+			want:   ` diff$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if !regexp.MustCompile(tt.want).MatchString(rec.ErrorMessage()) {
+				t.Errorf("failure message = %q, want match %q", rec.ErrorMessage(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertSetFailureTemplate(t *testing.T) {
+	defer SetFailureTemplate(SetFailureTemplate(template.Must(template.New("global").Parse("global"))))
+
+	t.Run("instance template", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(template.Must(template.New("local").Parse("local {{.Actual}}")))
+
+		Equal(a, 1, 2)
+		Equal(rec, 1, 2)
+
+		Equal(t, rec.Failures(), []string{"local 1", "global"})
+	})
+
+	t.Run("inherited by subtests", func(t *testing.T) {
+		a := New(t)
+		a.SetFailureTemplate(template.Must(template.New("local").Parse("local")))
+
+		a.Run("child", func(a *Assert) {
+			rec := NewTestRecorder(t)
+			child := *a
+			child.TB = rec
+
+			Equal(&child, 1, 2)
+
+			Equal(t, rec.ErrorMessage(), "local")
+		})
+	})
+
+	t.Run("nested Assert", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(template.Must(template.New("local").Parse("local")))
+
+		Equal(New(a), 1, 2)
+
+		Equal(t, rec.ErrorMessage(), "local")
+	})
+
+	t.Run("execution error falls back to the default layout", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(template.Must(template.New("local").Parse("{{.Missing}}")))
+
+		Equal(a, 1, 2)
+
+		if !strings.Contains(rec.ErrorMessage(), "Expected: (int) 2") {
+			t.Errorf("failure message = %q, want default layout", rec.ErrorMessage())
+		}
+	})
+}
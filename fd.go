@@ -0,0 +1,105 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// NoFDLeaks checks that the file descriptors open at the end of the test,
+// once its other cleanups have run, were already open when NoFDLeaks was
+// called. It lists the targets of the leaked descriptors, such as file
+// paths or sockets, where the platform provides them:
+//
+//	func TestImport(t *testing.T) {
+//	    assert.NoFDLeaks(t)
+//	    // ...
+//	}
+//
+// The descriptors of the Go runtime network poller are ignored. On
+// platforms where descriptors cannot be listed, NoFDLeaks only logs it.
+func NoFDLeaks(t testing.TB, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	before, err := openFDs()
+	if err != nil {
+		t.Logf("NoFDLeaks: cannot list file descriptors: %v", err)
+		return
+	}
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		after, err := openFDs()
+		if err != nil {
+			t.Logf("NoFDLeaks: cannot list file descriptors: %v", err)
+			return
+		}
+
+		var leaked []int
+		for fd, target := range after {
+			if prev, ok := before[fd]; (!ok || prev != target) && !runtimeFD(target) {
+				leaked = append(leaked, fd)
+			}
+		}
+		if len(leaked) == 0 {
+			return
+		}
+		sort.Ints(leaked)
+
+		notes := make([]string, 0, len(leaked))
+		for _, fd := range leaked {
+			if target := after[fd]; target != "" {
+				notes = append(notes, fmt.Sprintf("%d -> %s", fd, target))
+			} else {
+				notes = append(notes, strconv.Itoa(fd))
+			}
+		}
+		if len(notes) > maxSliceDiffs {
+			notes = append(notes[:maxSliceDiffs], fmt.Sprintf("and %d more", len(notes)-maxSliceDiffs))
+		}
+
+		failCompareNote(t, fmt.Sprintf("%d open descriptors", len(after)), fmt.Sprintf("%d open descriptors", len(before)),
+			"leaked: "+strings.Join(notes, "; "), withMessage("file descriptors leaked", msg)...)
+	})
+}
+
+// runtimeFD reports whether target is a descriptor opened by the Go
+// runtime network poller, which stays open once created.
+func runtimeFD(target string) bool {
+	return target == "anon_inode:[eventpoll]" || target == "anon_inode:[eventfd]"
+}
+
+// listFDs lists the descriptors of the process found in dir, with their
+// targets when dir holds symbolic links to them. The descriptor used to
+// read dir is left out.
+func listFDs(dir string) (map[int]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	self := int(f.Fd())
+	names, err := f.Readdirnames(-1)
+	_ = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	fds := make(map[int]string, len(names))
+	for _, name := range names {
+		fd, err := strconv.Atoi(name)
+		if err != nil || fd == self {
+			continue
+		}
+		target, _ := os.Readlink(dir + "/" + name)
+		fds[fd] = target
+	}
+	return fds, nil
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package assert
+
+// openFDs returns the open descriptors of the process. Their targets are
+// not available from /dev/fd.
+func openFDs() (map[int]string, error) {
+	return listFDs("/dev/fd")
+}
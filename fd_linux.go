@@ -0,0 +1,9 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+// openFDs returns the open descriptors of the process, with their targets.
+func openFDs() (map[int]string, error) {
+	return listFDs("/proc/self/fd")
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package assert
+
+import (
+	"errors"
+	"runtime"
+)
+
+// openFDs reports that descriptors cannot be listed on this platform.
+func openFDs() (map[int]string, error) {
+	return nil, errors.New("not supported on " + runtime.GOOS)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNoFDLeaks(t *testing.T) {
+	if _, err := openFDs(); err != nil {
+		t.Skipf("file descriptors cannot be listed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "leaked.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		run       func(t *testing.T)
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name: "no descriptor opened",
+			run:  func(t *testing.T) {},
+		},
+		{
+			name: "file closed",
+			run: func(t *testing.T) {
+				f, err := os.Open(path)
+				NoError(t, err)
+				NoError(t, f.Close())
+			},
+		},
+		{
+			name: "file left open",
+			run: func(t *testing.T) {
+				f, err := os.Open(path)
+				NoError(t, err)
+				t.Cleanup(func() { _ = f.Close() })
+			},
+			wantError: true,
+			wantParts: []string{"file descriptors leaked", "leaked: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			NoFDLeaks(rec)
+			tt.run(t)
+			rec.RunCleanups()
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("NoFDLeaks() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+			if tt.wantError && runtime.GOOS == "linux" && !strings.Contains(rec.ErrorMessage(), path) {
+				t.Errorf("message missing the leaked file %q\ngot: %s", path, rec.ErrorMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TempFileWith creates a file holding contents in a temporary directory,
+// and returns its path. The file is removed when the test completes.
+func TempFileWith(t testing.TB, contents string) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "fixture-*")
+	if err != nil {
+		t.Fatalf("\ncannot create temporary file: %v", err)
+	}
+	_, err = f.WriteString(contents)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		t.Fatalf("\ncannot write temporary file: %v", err)
+	}
+	return f.Name()
+}
+
+// TempDirWithFiles creates a temporary directory holding the given files,
+// keyed by slash-separated paths relative to the directory, and returns
+// its path. Parent directories are created as needed, and the directory
+// is removed when the test completes:
+//
+//	dir := assert.TempDirWithFiles(t, map[string]string{
+//	    "config.yaml":    "port: 8080\n",
+//	    "static/app.css": "body {}\n",
+//	})
+func TempDirWithFiles(t testing.TB, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rel := filepath.FromSlash(name)
+		if rel == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(filepath.Clean(rel), ".."+string(filepath.Separator)) {
+			t.Fatalf("\ninvalid fixture path %q: must be relative to the directory", name)
+		}
+
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("\ncannot create fixture directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			t.Fatalf("\ncannot write fixture file: %v", err)
+		}
+	}
+	return dir
+}
+
+// FileExists checks that path exists and is a regular file.
+func FileExists(t testing.TB, path string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		failCompare(t, err.Error(), "existing file "+path, withMessage("file does not exist", msg)...)
+	case !info.Mode().IsRegular():
+		failCompare(t, info.Mode().String(), "regular file "+path, withMessage("not a regular file", msg)...)
+	}
+}
+
+// FileContentEquals checks that the file at path holds expected.
+// Differing contents are shown as a line diff.
+func FileContentEquals(t testing.TB, path, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		failCompare(t, err.Error(), "readable file "+path, withMessage("cannot read file", msg)...)
+		return
+	}
+
+	if actual := string(data); actual != expected {
+		failCompareDiff(t, actual, expected, diffText(t, expected, actual), withMessage("unexpected content of "+path, msg)...)
+	}
+}
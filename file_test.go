@@ -0,0 +1,118 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTempFileWith(t *testing.T) {
+	path := TempFileWith(t, "hello\n")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello\n" {
+		t.Errorf("TempFileWith() content = %q, want %q", data, "hello\n")
+	}
+}
+
+func TestTempDirWithFiles(t *testing.T) {
+	dir := TempDirWithFiles(t, map[string]string{
+		"config.yaml":      "port: 8080\n",
+		"static/css/a.css": "body {}\n",
+		"empty":            "",
+	})
+
+	for name, want := range map[string]string{
+		"config.yaml":      "port: 8080\n",
+		"static/css/a.css": "body {}\n",
+		"empty":            "",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("file %s = %q, want %q", name, data, want)
+		}
+	}
+
+	for _, name := range []string{"../escape", "/abs", "", "a/../../b"} {
+		t.Run("invalid path "+name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			rec.Call(func() {
+				TempDirWithFiles(rec, map[string]string{name: "x"})
+			})
+
+			if !strings.Contains(rec.ErrorMessage(), "invalid fixture path") {
+				t.Errorf("TempDirWithFiles() message missing error\ngot: %s", rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestFileExists(t *testing.T) {
+	dir := TempDirWithFiles(t, map[string]string{"a.txt": "a"})
+
+	tests := []struct {
+		name      string
+		path      string
+		wantError bool
+	}{
+		{"regular file", filepath.Join(dir, "a.txt"), false},
+		{"missing file", filepath.Join(dir, "b.txt"), true},
+		{"directory", dir, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			FileExists(rec, tt.path)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("FileExists() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestFileContentEquals(t *testing.T) {
+	path := TempFileWith(t, "a\nb\nc\n")
+
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		wantError bool
+		wantParts []string
+	}{
+		{"same content", path, "a\nb\nc\n", false, nil},
+		{"different content", path, "a\nx\nc\n", true, []string{"unexpected content of " + path, "-x", "+b"}},
+		{"missing file", path + ".missing", "", true, []string{"cannot read file"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			FileContentEquals(rec, tt.path, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("FileContentEquals() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("FileContentEquals() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
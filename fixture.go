@@ -0,0 +1,120 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// LoadJSON reads a JSON fixture file into v, and returns the decoded value
+// for use with other assertions. The test stops if the file cannot be read
+// or decoded, with the position of syntax and type errors in the file:
+//
+//	user := assert.LoadJSON(t, "testdata/user.json", &User{})
+//	assert.Equal(t, user.Name, "alice")
+func LoadJSON[T any](t testing.TB, path string, v *T) T {
+	t.Helper()
+	defer observe(t)()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		failCompare(t, err.Error(), "readable fixture file", "cannot load "+path)
+		t.FailNow()
+		return *v
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		failCompare(t, err.Error(), fmt.Sprintf("JSON decodable into %T", v),
+			"cannot load "+path+jsonErrorPosition(data, err))
+		t.FailNow()
+	}
+	return *v
+}
+
+// LoadYAML reads a YAML fixture file into v, and returns the decoded value.
+// The document is decoded through its JSON form, so the fields of v are
+// matched as by LoadJSON, with their json tags. The test stops if the file
+// cannot be read or decoded, with the line of syntax and type errors in
+// the file:
+//
+//	config := assert.LoadYAML(t, "testdata/config.yaml", &Config{})
+//
+// Documents are made of block and flow collections, plain, quoted and
+// block scalars. Anchors, aliases, tags and multiple documents are not
+// supported.
+func LoadYAML[T any](t testing.TB, path string, v *T) T {
+	t.Helper()
+	defer observe(t)()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		failCompare(t, err.Error(), "readable fixture file", "cannot load "+path)
+		t.FailNow()
+		return *v
+	}
+
+	doc, err := readYAML(string(data))
+	if err != nil {
+		line := err.(*yamlError).line
+		failCompare(t, err.Error(), "valid YAML", fmt.Sprintf("cannot load %s:%d", path, line))
+		t.FailNow()
+		return *v
+	}
+
+	var b bytes.Buffer
+	var marks []yamlMark
+	doc.writeJSON(&b, &marks)
+	if err := json.Unmarshal(b.Bytes(), v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			err = fmt.Errorf("cannot decode YAML %s into %s", typeErr.Value, typeErr.Type)
+			if typeErr.Field != "" {
+				err = fmt.Errorf("cannot decode YAML %s into field %s of type %s", typeErr.Value, typeErr.Field, typeErr.Type)
+			}
+			failCompare(t, err.Error(), fmt.Sprintf("YAML decodable into %T", v),
+				fmt.Sprintf("cannot load %s:%d", path, yamlLineAt(marks, typeErr.Offset)))
+		} else {
+			failCompare(t, err.Error(), fmt.Sprintf("YAML decodable into %T", v), "cannot load "+path)
+		}
+		t.FailNow()
+	}
+	return *v
+}
+
+// jsonErrorPosition returns the ":line:column" position in data of
+// a JSON syntax or type error, or "" when the error has no offset.
+// The offset of these errors counts the bytes read, so the position
+// is the one of the last byte read: the invalid character, or the
+// end of the value of the wrong type.
+func jsonErrorPosition(data []byte, err error) string {
+	var offset int64
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return ""
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+
+	return fmt.Sprintf(":%d:%d", line, column)
+}
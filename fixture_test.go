@@ -0,0 +1,137 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fixtureUser struct {
+	Name  string   `json:"name"`
+	Age   int      `json:"age"`
+	Roles []string `json:"roles"`
+}
+
+func TestLoadJSON(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := write("valid.json", `{"name": "alice", "age": 30, "roles": ["admin"]}`)
+	syntax := write("syntax.json", "{\n  \"name\": \"alice\",\n  \"age\": 30,\n}\n")
+	wrongType := write("type.json", "{\n  \"name\": \"alice\",\n  \"age\": \"thirty\"\n}\n")
+
+	t.Run("valid fixture", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		user := LoadJSON(rec, valid, &fixtureUser{})
+
+		if rec.HasError() {
+			t.Fatalf("LoadJSON() recorded error\n%s", rec.ErrorMessage())
+		}
+		if user.Name != "alice" || user.Age != 30 || len(user.Roles) != 1 {
+			t.Errorf("LoadJSON() = %+v", user)
+		}
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"missing file", filepath.Join(dir, "missing.json"), []string{"cannot load " + filepath.Join(dir, "missing.json"), "Expected: (string) \"readable fixture file\""}},
+		{"syntax error", syntax, []string{"cannot load " + syntax + ":4:1", "invalid character '}'"}},
+		{"type error", wrongType, []string{"cannot load " + wrongType + ":3:17", "JSON decodable into *assert.fixtureUser"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			reached := false
+
+			rec.Call(func() {
+				LoadJSON(rec, tt.path, &fixtureUser{})
+				reached = true
+			})
+
+			if reached {
+				t.Error("LoadJSON() did not stop the test")
+			}
+			for _, part := range tt.want {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("LoadJSON() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := write("valid.yaml", "# user\nname: alice\nage: 30\nroles:\n  - admin\n")
+	syntax := write("syntax.yaml", "name: alice\nroles:\n  - admin\n   - user\n")
+	wrongType := write("type.yaml", "name: alice\nage: thirty\nroles: [admin]\n")
+	wrongList := write("list.yaml", "name: alice\nroles:\n  admin: true\n")
+
+	t.Run("valid fixture", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		user := LoadYAML(rec, valid, &fixtureUser{})
+
+		if rec.HasError() {
+			t.Fatalf("LoadYAML() recorded error\n%s", rec.ErrorMessage())
+		}
+		if user.Name != "alice" || user.Age != 30 || len(user.Roles) != 1 {
+			t.Errorf("LoadYAML() = %+v", user)
+		}
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"missing file", filepath.Join(dir, "missing.yaml"), []string{"cannot load " + filepath.Join(dir, "missing.yaml"), "Expected: (string) \"readable fixture file\""}},
+		{"syntax error", syntax, []string{"cannot load " + syntax + ":4", "unexpected indentation"}},
+		{"type error", wrongType, []string{"cannot load " + wrongType + ":2", "cannot decode YAML string into field age of type int", "YAML decodable into *assert.fixtureUser"}},
+		{"collection type error", wrongList, []string{"cannot load " + wrongList + ":3", "cannot decode YAML object into field roles of type []string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			reached := false
+
+			rec.Call(func() {
+				LoadYAML(rec, tt.path, &fixtureUser{})
+				reached = true
+			})
+
+			if reached {
+				t.Error("LoadYAML() did not stop the test")
+			}
+			for _, part := range tt.want {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("LoadYAML() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
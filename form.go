@@ -0,0 +1,160 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// FormFieldEquals checks the value of a field of an
+// application/x-www-form-urlencoded body. The form is given as an
+// *http.Request (whose body is restored after reading), a RecordedRequest
+// of a Server, url.Values, or the body as a string or []byte:
+//
+//	assert.FormFieldEquals(t, req, "grant_type", "refresh_token")
+//
+// A field with several values is compared by its first value.
+func FormFieldEquals(t testing.TB, form any, field, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	values, ok := readForm(t, form, msg)
+	if !ok {
+		return
+	}
+
+	got, present := values[field]
+	switch {
+	case !present:
+		failCompareNote(t, "no field "+field, expected, "fields: "+strings.Join(formKeys(values), ", "),
+			withMessage("form field missing", msg)...)
+	case got[0] != expected:
+		var note string
+		if len(got) > 1 {
+			note = fmt.Sprintf("field has %d values: %q", len(got), got)
+		}
+		failCompareNote(t, got[0], expected, note, withMessage("unexpected value of form field "+field, msg)...)
+	}
+}
+
+// FormFieldsMatch checks the values of several fields of a form, given
+// as for FormFieldEquals. Other fields are ignored, and all differing
+// fields are reported:
+//
+//	assert.FormFieldsMatch(t, req, map[string]string{
+//	    "grant_type":    "refresh_token",
+//	    "refresh_token": token,
+//	})
+func FormFieldsMatch(t testing.TB, form any, expected map[string]string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	values, ok := readForm(t, form, msg)
+	if !ok {
+		return
+	}
+
+	fields := make([]string, 0, len(expected))
+	for field := range expected {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var diffs []string
+	for _, field := range fields {
+		got, present := values[field]
+		switch {
+		case !present:
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %q", field, expected[field]))
+		case got[0] != expected[field]:
+			diffs = append(diffs, fmt.Sprintf("%s: expected %q, got %q", field, expected[field], got[0]))
+		}
+	}
+
+	if len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote[any](t, values.Encode(), expected, strings.Join(diffs, "; "), withMessage("form fields differ", msg)...)
+	}
+}
+
+// readForm decodes a form given as a request, url.Values or body,
+// reporting a failure when it is not a valid URL-encoded form.
+func readForm(t testing.TB, form any, msg []string) (url.Values, bool) {
+	t.Helper()
+
+	var body []byte
+	switch f := form.(type) {
+	case url.Values:
+		return f, true
+	case string:
+		body = []byte(f)
+	case []byte:
+		body = f
+	case RecordedRequest:
+		if !checkFormType(t, f.Header, msg) {
+			return nil, false
+		}
+		body = f.Body
+	case *http.Request:
+		if !checkFormType(t, f.Header, msg) {
+			return nil, false
+		}
+		if f.Body != nil {
+			data, err := io.ReadAll(f.Body)
+			if err != nil {
+				failCompare(t, err.Error(), "readable body", withMessage("cannot read request body", msg)...)
+				return nil, false
+			}
+			_ = f.Body.Close()
+			f.Body = io.NopCloser(bytes.NewReader(data))
+			body = data
+		}
+	default:
+		t.Errorf("\nform of unsupported type: %s", typeName(form))
+		return nil, false
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		failCompare(t, string(body), "URL-encoded form", withMessage("invalid form: "+err.Error(), msg)...)
+		return nil, false
+	}
+	return values, true
+}
+
+// checkFormType checks that the Content-Type of a request, when set,
+// is application/x-www-form-urlencoded.
+func checkFormType(t testing.TB, header http.Header, msg []string) bool {
+	t.Helper()
+
+	ct := header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	if mediaType, _, err := mime.ParseMediaType(ct); err != nil || mediaType != "application/x-www-form-urlencoded" {
+		failCompare(t, ct, "application/x-www-form-urlencoded", withMessage("unexpected content type", msg)...)
+		return false
+	}
+	return true
+}
+
+// formKeys returns the sorted field names of a form.
+func formKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const tokenForm = "grant_type=refresh_token&refresh_token=a%2Bb%3D&scope=read&scope=write"
+
+func formRequest(contentType string) *http.Request {
+	req, _ := http.NewRequest("POST", "https://auth.example.com/token", strings.NewReader(tokenForm))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+func TestFormFieldEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      any
+		field     string
+		expected  string
+		wantError bool
+		wantPart  string
+	}{
+		{"string body", tokenForm, "grant_type", "refresh_token", false, ""},
+		{"decoded value", []byte(tokenForm), "refresh_token", "a+b=", false, ""},
+		{"url values", url.Values{"a": {"1"}}, "a", "1", false, ""},
+		{"request", formRequest("application/x-www-form-urlencoded; charset=utf-8"), "grant_type", "refresh_token", false, ""},
+		{"request without content type", formRequest(""), "grant_type", "refresh_token", false, ""},
+		{"recorded request", RecordedRequest{Header: http.Header{}, Body: []byte(tokenForm)}, "scope", "read", false, ""},
+		{"different value", tokenForm, "grant_type", "password", true, "unexpected value of form field grant_type"},
+		{"several values", tokenForm, "scope", "write", true, `field has 2 values: ["read" "write"]`},
+		{"missing field", tokenForm, "client_id", "app", true, "fields: grant_type, refresh_token, scope"},
+		{"wrong content type", formRequest("application/json"), "grant_type", "refresh_token", true, "unexpected content type"},
+		{"invalid form", "a=%zz", "a", "", true, "invalid form"},
+		{"unsupported type", 42, "a", "", true, "form of unsupported type: int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			FormFieldEquals(rec, tt.form, tt.field, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("FormFieldEquals() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("FormFieldEquals() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("request body is restored", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		req := formRequest("")
+
+		FormFieldEquals(rec, req, "grant_type", "refresh_token")
+
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != tokenForm {
+			t.Errorf("request body = %q, want %q", body, tokenForm)
+		}
+	})
+}
+
+func TestFormFieldsMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		expected  map[string]string
+		wantError bool
+		wantPart  string
+	}{
+		{"matching subset", map[string]string{"grant_type": "refresh_token", "refresh_token": "a+b="}, false, ""},
+		{"differences", map[string]string{"grant_type": "password", "client_id": "app"}, true,
+			`Note: client_id: missing, expected "app"; grant_type: expected "password", got "refresh_token"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			FormFieldsMatch(rec, tokenForm, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("FormFieldsMatch() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("FormFieldsMatch() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import "testing"
+
+// ErrorAssertionFunc is an assertion on an error.
+// It lets table-driven tests store which error assertion to run:
+//
+//	tests := []struct {
+//	    input     string
+//	    assertErr assert.ErrorAssertionFunc
+//	}{
+//	    {"42", assert.NoErrorFunc},
+//	    {"-1", assert.ErrorIsFunc(ErrNegative)},
+//	}
+type ErrorAssertionFunc func(t testing.TB, err error)
+
+// ComparisonAssertionFunc is an assertion comparing two values.
+type ComparisonAssertionFunc func(t testing.TB, actual, expected any)
+
+// ValueAssertionFunc is an assertion on a single value.
+type ValueAssertionFunc func(t testing.TB, value any)
+
+// BoolAssertionFunc is an assertion on a boolean value.
+type BoolAssertionFunc func(t testing.TB, value bool)
+
+// NoErrorFunc is an ErrorAssertionFunc calling NoError.
+var NoErrorFunc ErrorAssertionFunc = func(t testing.TB, err error) {
+	t.Helper()
+	NoError(t, err)
+}
+
+// ErrorFunc is an ErrorAssertionFunc calling Error.
+var ErrorFunc ErrorAssertionFunc = func(t testing.TB, err error) {
+	t.Helper()
+	Error(t, err)
+}
+
+// ErrorIsFunc returns an ErrorAssertionFunc calling ErrorIs with target.
+func ErrorIsFunc(target error) ErrorAssertionFunc {
+	return func(t testing.TB, err error) {
+		t.Helper()
+		ErrorIs(t, err, target)
+	}
+}
+
+// ErrorAsFunc returns an ErrorAssertionFunc calling ErrorAs with target.
+func ErrorAsFunc(target any) ErrorAssertionFunc {
+	return func(t testing.TB, err error) {
+		t.Helper()
+		ErrorAs(t, err, target)
+	}
+}
+
+// EqualFunc is a ComparisonAssertionFunc calling Equal.
+var EqualFunc ComparisonAssertionFunc = func(t testing.TB, actual, expected any) {
+	t.Helper()
+	Equal(t, actual, expected)
+}
+
+// NotEqualFunc is a ComparisonAssertionFunc calling NotEqual.
+var NotEqualFunc ComparisonAssertionFunc = func(t testing.TB, actual, expected any) {
+	t.Helper()
+	NotEqual(t, actual, expected)
+}
+
+// NilFunc is a ValueAssertionFunc calling Nil.
+var NilFunc ValueAssertionFunc = func(t testing.TB, value any) {
+	t.Helper()
+	Nil(t, value)
+}
+
+// NotNilFunc is a ValueAssertionFunc calling NotNil.
+var NotNilFunc ValueAssertionFunc = func(t testing.TB, value any) {
+	t.Helper()
+	NotNil(t, value)
+}
+
+// TrueFunc is a BoolAssertionFunc calling True.
+var TrueFunc BoolAssertionFunc = func(t testing.TB, value bool) {
+	t.Helper()
+	True(t, value)
+}
+
+// FalseFunc is a BoolAssertionFunc calling False.
+var FalseFunc BoolAssertionFunc = func(t testing.TB, value bool) {
+	t.Helper()
+	False(t, value)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorAssertionFunc(t *testing.T) {
+	errBase := errors.New("base")
+
+	tests := []struct {
+		name      string
+		err       error
+		assertErr ErrorAssertionFunc
+		wantError bool
+	}{
+		{
+			name:      "NoErrorFunc with nil",
+			err:       nil,
+			assertErr: NoErrorFunc,
+			wantError: false,
+		},
+		{
+			name:      "NoErrorFunc with error",
+			err:       errBase,
+			assertErr: NoErrorFunc,
+			wantError: true,
+		},
+		{
+			name:      "ErrorFunc with nil",
+			err:       nil,
+			assertErr: ErrorFunc,
+			wantError: true,
+		},
+		{
+			name:      "ErrorIsFunc with wrapped error",
+			err:       fmt.Errorf("wrapped: %w", errBase),
+			assertErr: ErrorIsFunc(errBase),
+			wantError: false,
+		},
+		{
+			name:      "ErrorAsFunc with other error",
+			err:       errBase,
+			assertErr: ErrorAsFunc(new(*testError)),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assertErr(rec, tt.err)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ErrorAssertionFunc error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestComparisonAndValueAssertionFuncs(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(t testing.TB)
+		wantError bool
+	}{
+		{
+			name:      "EqualFunc pass",
+			run:       func(t testing.TB) { EqualFunc(t, 1, 1) },
+			wantError: false,
+		},
+		{
+			name:      "NotEqualFunc fail",
+			run:       func(t testing.TB) { NotEqualFunc(t, 1, 1) },
+			wantError: true,
+		},
+		{
+			name:      "NilFunc fail",
+			run:       func(t testing.TB) { NilFunc(t, 1) },
+			wantError: true,
+		},
+		{
+			name:      "NotNilFunc pass",
+			run:       func(t testing.TB) { NotNilFunc(t, 1) },
+			wantError: false,
+		},
+		{
+			name:      "TrueFunc fail",
+			run:       func(t testing.TB) { TrueFunc(t, false) },
+			wantError: true,
+		},
+		{
+			name:      "FalseFunc pass",
+			run:       func(t testing.TB) { FalseFunc(t, false) },
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.run(rec)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("assertion func error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+// FuzzT wraps the testing.TB of a fuzz target so that every assertion
+// failure also reports the input that triggered it.
+type FuzzT struct {
+	testing.TB
+	input []byte
+}
+
+// FuzzAssert registers a fuzz target operating on raw bytes.
+// The target receives a FuzzT that can be passed to any assertion.
+func FuzzAssert(f *testing.F, fn func(t *FuzzT, data []byte)) {
+	f.Helper()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fn(&FuzzT{TB: t, input: data}, data)
+	})
+}
+
+// Input returns the input bytes of the current fuzz iteration.
+func (f *FuzzT) Input() []byte {
+	return f.input
+}
+
+// Error reports a failure along with the fuzz input.
+func (f *FuzzT) Error(args ...any) {
+	f.TB.Helper()
+	f.TB.Error(fmt.Sprint(args...) + f.inputLine())
+}
+
+// Errorf reports a formatted failure along with the fuzz input.
+func (f *FuzzT) Errorf(format string, args ...any) {
+	f.TB.Helper()
+	f.TB.Error(fmt.Sprintf(format, args...) + f.inputLine())
+}
+
+// Fatal reports a failure along with the fuzz input and stops the iteration.
+func (f *FuzzT) Fatal(args ...any) {
+	f.TB.Helper()
+	f.TB.Fatal(fmt.Sprint(args...) + f.inputLine())
+}
+
+// Fatalf reports a formatted failure along with the fuzz input and stops the iteration.
+func (f *FuzzT) Fatalf(format string, args ...any) {
+	f.TB.Helper()
+	f.TB.Fatal(fmt.Sprintf(format, args...) + f.inputLine())
+}
+
+// SkipIf skips the current input when cond is true.
+// It is meant for discarding inputs that are not interesting to the target.
+func (f *FuzzT) SkipIf(cond bool, reason string) {
+	f.TB.Helper()
+
+	if cond {
+		f.TB.Skip(reason)
+	}
+}
+
+func (f *FuzzT) inputLine() string {
+	return fmt.Sprintf("\n   Input: %q", f.input)
+}
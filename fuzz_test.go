@@ -0,0 +1,62 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzFuzzAssert(f *testing.F) {
+	f.Add([]byte("hello"))
+	f.Add([]byte{0xff, 0xfe})
+
+	FuzzAssert(f, func(t *FuzzT, data []byte) {
+		t.SkipIf(!utf8.Valid(data), "invalid UTF-8")
+
+		Equal(t, string(t.Input()), string(data))
+		True(t, utf8.ValidString(string(data)))
+	})
+}
+
+func TestFuzzT(t *testing.T) {
+	t.Run("failure reports input", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		ft := &FuzzT{TB: rec, input: []byte("boom")}
+
+		Equal(ft, 1, 2)
+
+		if !rec.HasError() {
+			t.Fatal("Equal() did not record error")
+		}
+		if !strings.Contains(rec.ErrorMessage(), `Input: "boom"`) {
+			t.Errorf("failure message missing input\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("errorf reports input", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		ft := &FuzzT{TB: rec, input: []byte{0x01}}
+
+		ft.Errorf("bad value %d", 42)
+
+		if !strings.Contains(rec.ErrorMessage(), `bad value 42`) ||
+			!strings.Contains(rec.ErrorMessage(), `Input: "\x01"`) {
+			t.Errorf("Errorf() message = %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("success records nothing", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		ft := &FuzzT{TB: rec, input: []byte("ok")}
+
+		Equal(ft, 1, 1)
+		ft.SkipIf(false, "never")
+
+		if rec.HasError() {
+			t.Error("unexpected error recorded")
+		}
+	})
+}
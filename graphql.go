@@ -0,0 +1,170 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// graphQLResponse is the standard envelope of a GraphQL response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+// graphQLError is an entry of the errors array of a GraphQL response.
+type graphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// code returns the extensions.code of the error, or "".
+func (e graphQLError) code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// String formats the error as "path: message (CODE)".
+func (e graphQLError) String() string {
+	var b strings.Builder
+	if len(e.Path) > 0 {
+		parts := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			parts[i] = fmt.Sprint(p)
+		}
+		b.WriteString(strings.Join(parts, ".") + ": ")
+	}
+	b.WriteString(e.Message)
+	if code := e.code(); code != "" {
+		b.WriteString(" (" + code + ")")
+	}
+	return b.String()
+}
+
+// GraphQLNoErrors checks that a GraphQL response body has no errors.
+// On failure, the errors are listed with their path and code.
+func GraphQLNoErrors(t testing.TB, body string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	resp, ok := readGraphQL(t, body, msg)
+	if !ok || len(resp.Errors) == 0 {
+		return
+	}
+
+	errs := make([]string, len(resp.Errors))
+	for i, e := range resp.Errors {
+		errs[i] = e.String()
+	}
+	failCompareNote(t, fmt.Sprintf("%d errors", len(errs)), "no errors", strings.Join(errs, "; "),
+		withMessage("GraphQL response has errors", msg)...)
+}
+
+// GraphQLDataPath checks that the value at path in the data of a GraphQL
+// response equals expected, compared as JSON documents like JSONEq.
+// The path is made of field names and list indexes separated by dots:
+//
+//	assert.GraphQLDataPath(t, body, "user.name", "alice")
+//	assert.GraphQLDataPath(t, body, "users.0.roles", []string{"admin"})
+func GraphQLDataPath(t testing.TB, body, path string, expected any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	resp, ok := readGraphQL(t, body, msg)
+	if !ok {
+		return
+	}
+
+	data, err := readJSON(string(resp.Data))
+	if err != nil {
+		failCompare(t, string(resp.Data), "data object", withMessage("GraphQL response has no data", msg)...)
+		return
+	}
+
+	actual, err := jsonLookup(data, path)
+	if err != nil {
+		failCompare(t, formatJSON(data), "value at "+path, withMessage("GraphQL data path not found: "+err.Error(), msg)...)
+		return
+	}
+
+	raw, err := json.Marshal(expected)
+	if err != nil {
+		failCompare(t, err.Error(), "JSON encodable value", withMessage("invalid expected value", msg)...)
+		return
+	}
+	e, _ := readJSON(string(raw))
+
+	if diffs := diffJSON(actual, e, path, &options{}); len(diffs) > 0 {
+		failCompareNote(t, formatJSON(actual), string(raw), strings.Join(diffs, "; "),
+			withMessage("unexpected GraphQL data", msg)...)
+	}
+}
+
+// GraphQLErrorCode checks that a GraphQL response body has an error
+// with the given extensions.code, such as "NOT_FOUND".
+func GraphQLErrorCode(t testing.TB, body, code string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	resp, ok := readGraphQL(t, body, msg)
+	if !ok {
+		return
+	}
+
+	codes := make([]string, 0, len(resp.Errors))
+	for _, e := range resp.Errors {
+		if e.code() == code {
+			return
+		}
+		codes = append(codes, e.String())
+	}
+	failCompareNote(t, fmt.Sprintf("%d errors", len(resp.Errors)), "error with code "+code, strings.Join(codes, "; "),
+		withMessage("GraphQL error code not found", msg)...)
+}
+
+// readGraphQL decodes a GraphQL response envelope, failing
+// when body is not one.
+func readGraphQL(t testing.TB, body string, msg []string) (graphQLResponse, bool) {
+	t.Helper()
+
+	var resp graphQLResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		failCompare(t, body, "GraphQL response", withMessage("invalid GraphQL response: "+err.Error(), msg)...)
+		return resp, false
+	}
+	if resp.Data == nil && resp.Errors == nil {
+		failCompare(t, body, "GraphQL response", withMessage("invalid GraphQL response: no data nor errors", msg)...)
+		return resp, false
+	}
+	return resp, true
+}
+
+// jsonLookup returns the value at a dot-separated path of object keys
+// and array indexes in a decoded JSON value.
+func jsonLookup(v any, path string) (any, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("no field %q", key)
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("no index %q in list of %d elements", key, len(node))
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("no field %q in %s", key, jsonKind(v))
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const graphQLOK = `{"data": {"user": {"name": "alice", "id": 9007199254740993, "roles": ["admin", "dev"]}, "users": [{"name": "bob"}]}}`
+
+const graphQLFailed = `{
+  "data": {"user": null},
+  "errors": [
+    {"message": "user not found", "path": ["user"], "extensions": {"code": "NOT_FOUND"}},
+    {"message": "rate limited"}
+  ]
+}`
+
+func TestGraphQLNoErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantError bool
+		wantPart  string
+	}{
+		{"no errors", graphQLOK, false, ""},
+		{"errors", graphQLFailed, true, "Note: user: user not found (NOT_FOUND); rate limited"},
+		{"invalid JSON", `{`, true, "invalid GraphQL response"},
+		{"not an envelope", `{"user": {}}`, true, "no data nor errors"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			GraphQLNoErrors(rec, tt.body)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("GraphQLNoErrors() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("GraphQLNoErrors() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestGraphQLDataPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expected  any
+		wantError bool
+		wantPart  string
+	}{
+		{"string field", "user.name", "alice", false, ""},
+		{"list", "user.roles", []string{"admin", "dev"}, false, ""},
+		{"list index", "users.0.name", "bob", false, ""},
+		{"object", "users.0", map[string]string{"name": "bob"}, false, ""},
+		{"large number", "user.id", uint64(9007199254740993), false, ""},
+		{"rounded number", "user.id", uint64(9007199254740992), true, "user.id: expected 9007199254740992, got 9007199254740993"},
+		{"different value", "user.name", "eve", true, `user.name: expected "eve", got "alice"`},
+		{"missing field", "user.email", "x", true, `no field "email"`},
+		{"index out of range", "users.3.name", "x", true, `no index "3" in list of 1 elements`},
+		{"field of scalar", "user.name.first", "x", true, `no field "first" in string`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			GraphQLDataPath(rec, graphQLOK, tt.path, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("GraphQLDataPath() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("GraphQLDataPath() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("errors only", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		GraphQLDataPath(rec, `{"errors": [{"message": "boom"}]}`, "user", nil)
+
+		if !strings.Contains(rec.ErrorMessage(), "GraphQL response has no data") {
+			t.Errorf("GraphQLDataPath() message missing error\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestGraphQLErrorCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		code      string
+		wantError bool
+		wantPart  string
+	}{
+		{"code present", graphQLFailed, "NOT_FOUND", false, ""},
+		{"code absent", graphQLFailed, "FORBIDDEN", true, "Note: user: user not found (NOT_FOUND); rate limited"},
+		{"no errors", graphQLOK, "NOT_FOUND", true, `Actual: (string) "0 errors"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			GraphQLErrorCode(rec, tt.body, tt.code)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("GraphQLErrorCode() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("GraphQLErrorCode() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+}
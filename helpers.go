@@ -4,99 +4,270 @@
 package assert
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/nanoninja/assert/asserttest"
 )
 
-// TestRecorder wraps a testing.T instance and records error messages
-// without failing the actual test. This allows us to verify assertion
-// behaviors in our unit tests.
-type TestRecorder struct {
-	*testing.T
-	errorCalled  bool
-	errorMessage string
-	helperCalled bool
-}
+// TestRecorder is the recorder used to test assertions.
+// It is an alias of asserttest.Recorder, kept for compatibility.
+type TestRecorder = asserttest.Recorder
 
 // NewTestRecorder creates a new TestRecorder instance.
-// It automatically marks itself as a test helper to maintain
-// accurate line numbers in test output.
+// It is equivalent to asserttest.NewRecorder.
 func NewTestRecorder(t *testing.T) *TestRecorder {
 	t.Helper()
 
-	return &TestRecorder{T: t}
+	return asserttest.NewRecorder(t)
 }
 
-// Error records that an error occurred with the given arguments
-func (r *TestRecorder) Error(args ...interface{}) {
-	r.errorCalled = true
-	r.errorMessage = fmt.Sprint(args...)
-}
+func compare[T any](t testing.TB, actual, expected T, msg ...string) {
+	t.Helper()
+	if tr := lookupTracer(t); tr != nil {
+		tr.record(expected, actual)
+	}
 
-// Errorf records that an error occurred with the formatted message
-func (r *TestRecorder) Errorf(format string, args ...interface{}) {
-	r.errorCalled = true
-	r.errorMessage = fmt.Sprintf(format, args...)
+	if !isEqual(expected, actual) {
+		reportFailure(t, expected, actual, explainUnequal(expected, actual), textDiff(t, actual, expected), msg)
+	}
 }
 
-// Helper records that Helper() was called, which is useful
-// for verifying our assertions maintain proper stack traces
-func (r *TestRecorder) Helper() {
-	r.helperCalled = true
-}
+// failCompare formats and outputs a detailed comparison error message for test failures.
+// It includes the location of the failure, types of values being compared,
+// and a clear visual representation of expected vs actual values.
+func failCompare[T any](t testing.TB, actual, expected T, msg ...string) {
+	t.Helper()
 
-// HasError checks if any error method was called
-func (r *TestRecorder) HasError() bool {
-	return r.errorCalled
+	failCompareNote(t, actual, expected, "", msg...)
 }
 
-// ErrorMessage returns the recorded error message
-func (r *TestRecorder) ErrorMessage() string {
-	return r.errorMessage
-}
+// failCompareNote is failCompare with an explanatory note,
+// printed after the compared values when not empty.
+func failCompareNote[T any](t testing.TB, actual, expected T, note string, msg ...string) {
+	t.Helper()
 
-// HelperCalled checks if Helper() was called
-func (r *TestRecorder) HelperCalled() bool {
-	return r.helperCalled
+	reportFailure(t, actual, expected, note, "", msg)
 }
 
-func compare[T any](t testing.TB, actual, expected T, msg ...string) {
+// failCompareDiff is failCompare with a diff of the compared values,
+// printed after them when not empty.
+func failCompareDiff[T any](t testing.TB, actual, expected T, diff string, msg ...string) {
 	t.Helper()
 
-	if !isEqual(expected, actual) {
-		failCompare(t, expected, actual, msg...)
-	}
+	reportFailure(t, actual, expected, "", diff, msg)
 }
 
-// failCompare formats and outputs a detailed comparison error message for test failures.
-// It includes the location of the failure, types of values being compared,
-// and a clear visual representation of expected vs actual values.
-func failCompare[T any](t testing.TB, actual, expected T, msg ...string) {
+// reportFailure builds the failure message shared by the failCompare
+// functions and reports it to t.
+func reportFailure(t testing.TB, actual, expected any, note, diff string, msg []string) {
 	t.Helper()
 
-	var builder strings.Builder
+	f := newFailure(t, actual, expected, note, diff, msg)
+	if r := currentReporter(); r != nil && reported(t) {
+		r.Report(t, f)
+	}
 
-	if len(msg) > 0 && msg[0] != "" {
-		builder.WriteString(fmt.Sprintf("\n Message: %s", msg[0]))
+	if tmpl := lookupFailureTemplate(t); tmpl != nil {
+		if reportTemplate(t, tmpl, f) {
+			return
+		}
 	}
 
-	// Get the types of both values for more informative error messages
-	exptectedType := reflect.TypeOf(actual)
-	actualType := reflect.TypeOf(expected)
+	b := buffers.Get().(*bytes.Buffer)
+	defer putBuffer(b)
+	labels := newFailureLabels()
 
-	// Build the error messageyy
-	builder.WriteString(fmt.Sprintf("\nExpected: (%v) %#v\n", exptectedType, expected))
-	builder.WriteString(fmt.Sprintf("  Actual: (%v) %#v\n", actualType, actual))
+	if f.Location != "" && lookupLocationFormat(t) != 0 && lookupCallerSkip(t) == 0 {
+		b.WriteString("\n" + labels.location + " " + f.Location)
+	}
+	if f.Message != "" {
+		b.WriteString("\n" + labels.message + " " + f.Message)
+	}
+
+	// Build the error message
+	writeCompared(b, labels, f.Types.Expected, f.Expected, f.Types.Actual, f.Actual, outputWidth())
+
+	if note != "" {
+		b.WriteString(labels.note + " " + note + "\n")
+	}
+
+	if f.Seed != "" {
+		b.WriteString(labels.seed + " " + f.Seed + " (" + seedEnv + "=" + f.Seed + " to reproduce)\n")
+	}
 
-	t.Error(builder.String())
+	if diff != "" {
+		b.WriteString(labels.diff + "\n")
+		for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+
+	t.Error(b.String())
+}
+
+// buffers holds the buffers failure messages are built in, since
+// failing tests often report many of them.
+var buffers = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer is the capacity beyond which buffers are not pooled,
+// so that a single large diff does not stay in memory.
+const maxPooledBuffer = 64 << 10
+
+// putBuffer returns b to the pool.
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+	b.Reset()
+	buffers.Put(b)
+}
+
+// formatValue formats a compared value with the %#v verb,
+// except for durations which are shown in human-friendly units.
+// The most common types are formatted without going through fmt.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Duration:
+		return v.String()
+	case truncated:
+		return v.String()
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// withMessage combines the default message of a failure, translated, with
+// the optional message given by the caller, which comes first when present.
+func withMessage(def string, msg []string) []string {
+	def = translate(def)
+	if len(msg) > 0 && msg[0] != "" {
+		return []string{msg[0] + ": " + def}
+	}
+	return []string{def}
 }
 
 // isEqual performs a generic equality check between two values of the same type.
-// It uses reflection.DeepEqual to handle complex data structures correctly.
+// It uses reflection.DeepEqual to handle complex data structures correctly,
+// except for struct fields ignored with an `assert:"-"` tag.
+// Builtin scalar types are compared directly, so that successful assertions
+// on them do not allocate.
 func isEqual[T any](x, y T) bool {
-	return reflect.DeepEqual(x, y)
+	switch xv := any(x).(type) {
+	case bool:
+		yv, ok := any(y).(bool)
+		return ok && xv == yv
+	case string:
+		yv, ok := any(y).(string)
+		return ok && xv == yv
+	case int:
+		yv, ok := any(y).(int)
+		return ok && xv == yv
+	case int8:
+		yv, ok := any(y).(int8)
+		return ok && xv == yv
+	case int16:
+		yv, ok := any(y).(int16)
+		return ok && xv == yv
+	case int32:
+		yv, ok := any(y).(int32)
+		return ok && xv == yv
+	case int64:
+		yv, ok := any(y).(int64)
+		return ok && xv == yv
+	case uint:
+		yv, ok := any(y).(uint)
+		return ok && xv == yv
+	case uint8:
+		yv, ok := any(y).(uint8)
+		return ok && xv == yv
+	case uint16:
+		yv, ok := any(y).(uint16)
+		return ok && xv == yv
+	case uint32:
+		yv, ok := any(y).(uint32)
+		return ok && xv == yv
+	case uint64:
+		yv, ok := any(y).(uint64)
+		return ok && xv == yv
+	case float32:
+		yv, ok := any(y).(float32)
+		return ok && xv == yv
+	case float64:
+		yv, ok := any(y).(float64)
+		return ok && xv == yv
+	case []byte:
+		yv, ok := any(y).([]byte)
+		return ok && equalSlices(xv, yv)
+	case []int:
+		yv, ok := any(y).([]int)
+		return ok && equalSlices(xv, yv)
+	case []string:
+		yv, ok := any(y).([]string)
+		return ok && equalSlices(xv, yv)
+	}
+	if reflect.DeepEqual(x, y) {
+		return true
+	}
+
+	// Values only differing by fields ignored by tag are equal.
+	if mayHaveIgnoredFields(reflect.TypeOf(x)) {
+		var e equalizer
+		return e.equal(x, y)
+	}
+	return false
+}
+
+// equalSlices compares slices of comparable elements with the
+// semantics of reflect.DeepEqual, where nil and empty slices differ.
+func equalSlices[E comparable](x, y []E) bool {
+	if (x == nil) != (y == nil) || len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// typeName returns the name of the dynamic type of value, like the %T verb.
+// Unlike passing value to fmt, it does not force value to escape to the heap.
+func typeName(value any) string {
+	if typ := reflect.TypeOf(value); typ != nil {
+		return typ.String()
+	}
+	return "<nil>"
+}
+
+// deref follows pointers until a non-pointer value is reached.
+// It reports whether a nil pointer was found on the way.
+func deref(value any) (any, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr {
+		return value, false
+	}
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), false
 }
 
 // isNil is a helper function that properly checks if a value is nil,
@@ -4,10 +4,24 @@
 package assert
 
 import (
+	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
+// TestMain makes failure messages independent of the environment
+// running the tests, such as an exported COLUMNS, and keeps recorded
+// failures from being reported to CI systems.
+func TestMain(m *testing.M) {
+	SetOutputWidth(0)
+	SetReporter(nil)
+	SetDiffContext(defaultDiffContext)
+
+	os.Exit(m.Run())
+}
+
 func TestCompare(t *testing.T) {
 	t.Run("compare with equal values", func(t *testing.T) {
 		rec := NewTestRecorder(t)
@@ -90,6 +104,15 @@ func TestFailCompare(t *testing.T) {
 				"Expected: ([]int) []int{3, 4}",
 			},
 		},
+		{
+			name:     "mixed types",
+			actual:   42,
+			expected: "answer",
+			wantParts: []string{
+				"Expected: (string) \"answer\"",
+				"Actual: (int) 42",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,6 +136,62 @@ func TestFailCompare(t *testing.T) {
 	}
 }
 
+func TestFailCompareDiff(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	failCompareDiff(rec, "a\nx", "a\nb", "@@ -1,2 +1,2 @@\n a\n-b\n+x\n", "message")
+
+	wantParts := []string{
+		"Message: message",
+		`Expected: (string) "a\nb"`,
+		`Actual: (string) "a\nx"`,
+		"    Diff:\n    @@ -1,2 +1,2 @@\n     a\n    -b\n    +x\n",
+	}
+	for _, part := range wantParts {
+		if !strings.Contains(rec.ErrorMessage(), part) {
+			t.Errorf("failCompareDiff() message missing %q\ngot: %s", part, rec.ErrorMessage())
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"string", "a \"quoted\"\n\tvalue ✓"},
+		{"empty string", ""},
+		{"invalid utf-8", "\xff"},
+		{"int", -42},
+		{"bool", true},
+		{"struct", point{1, 2}},
+		{"nil", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Equal(t, formatValue(tt.value), fmt.Sprintf("%#v", tt.value))
+		})
+	}
+
+	t.Run("duration", func(t *testing.T) {
+		Equal(t, formatValue(1500*time.Millisecond), "1.5s")
+	})
+}
+
+func TestReportFailureReusesBuffers(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		rec := NewTestRecorder(t)
+
+		failCompare(rec, i, 10, "message")
+
+		want := fmt.Sprintf("\n Message: message\nExpected: (int) 10\n  Actual: (int) %d\n", i)
+		Equal(t, rec.ErrorMessage(), want)
+	}
+}
+
 func TestIsEqual(t *testing.T) {
 	tests := []struct {
 		name string
@@ -156,6 +235,24 @@ func TestIsEqual(t *testing.T) {
 			y:    nil,
 			want: true,
 		},
+		{
+			name: "same int slices",
+			x:    []int{1, 2},
+			y:    []int{1, 2},
+			want: true,
+		},
+		{
+			name: "nil and empty slices",
+			x:    []string(nil),
+			y:    []string{},
+			want: false,
+		},
+		{
+			name: "same type different dynamic values",
+			x:    int64(1),
+			y:    int32(1),
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,508 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// HTMLEq checks if two HTML fragments are equivalent. Both are parsed and
+// normalized before being compared: tag and attribute names are lowercased,
+// attributes are sorted, comments are dropped and whitespace inside texts
+// is collapsed. Formatting-only changes thus do not fail:
+//
+//	assert.HTMLEq(t, rendered, `<ul class="menu"><li>Home</li></ul>`)
+//
+// Like browsers, the parser closes void elements such as <img> and <input>,
+// implies the end tags of elements such as <li>, <p> and <td>, puts table
+// rows in an implied <tbody>, gives attributes without value the empty
+// string, and reads the content of <script> and <style> as raw text. Whitespace between text
+// and elements is kept as a single space, whitespace-only text between
+// elements is dropped, and the text of <pre> and <textarea> is compared
+// verbatim. Only the whitespace of the class and rel attributes, which
+// hold lists of tokens, is normalized.
+func HTMLEq(t testing.TB, actual, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	e, err := parseHTML(expected)
+	if err != nil {
+		failCompare(t, expected, "valid HTML", withMessage("invalid expected HTML: "+err.Error(), msg)...)
+		return
+	}
+	a, err := parseHTML(actual)
+	if err != nil {
+		failCompare(t, actual, "valid HTML", withMessage("invalid actual HTML: "+err.Error(), msg)...)
+		return
+	}
+
+	if note := diffHTML(a, e, ""); note != "" {
+		failCompareNote(t, a.inner(), e.inner(), note, withMessage("HTML documents differ", msg)...)
+	}
+}
+
+// htmlNode is a node of a parsed HTML document.
+// Text nodes have an empty tag.
+type htmlNode struct {
+	tag      string
+	attrs    []xml.Attr
+	text     string
+	children []*htmlNode
+	parent   *htmlNode
+}
+
+// voidElements are the elements without content nor end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements are the elements whose content is text up to their
+// end tag. Entities are decoded in those mapped to true.
+var rawTextElements = map[string]bool{
+	"script": false, "style": false, "textarea": true, "title": true,
+}
+
+// verbatimElements are the elements whose text is compared verbatim.
+var verbatimElements = map[string]bool{"pre": true, "textarea": true}
+
+// blockElements are the elements whose start tag implies the end of an
+// open paragraph.
+var blockElements = []string{
+	"address", "article", "aside", "blockquote", "details", "div", "dl",
+	"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3",
+	"h4", "h5", "h6", "header", "hr", "main", "menu", "nav", "ol", "p",
+	"pre", "section", "table", "ul",
+}
+
+// impliedEndTags maps the elements whose end tag may be omitted to the
+// start tags implying it. The end tag of their parent implies it too.
+var impliedEndTags = map[string][]string{
+	"li":       {"li"},
+	"p":        blockElements,
+	"dt":       {"dt", "dd"},
+	"dd":       {"dt", "dd"},
+	"td":       {"td", "th", "tr", "tbody", "thead", "tfoot"},
+	"th":       {"td", "th", "tr", "tbody", "thead", "tfoot"},
+	"tr":       {"tr", "tbody", "thead", "tfoot"},
+	"thead":    {"tbody", "tfoot"},
+	"tbody":    {"tbody", "tfoot"},
+	"tfoot":    {"tbody"},
+	"option":   {"option", "optgroup"},
+	"optgroup": {"optgroup"},
+}
+
+// impliedParents maps the elements put in an implied parent when started
+// in another element, such as the rows of a table, to that parent by the
+// element they are started in.
+var impliedParents = map[string]map[string]string{
+	"tr": {"table": "tbody"},
+	"td": {"table": "tbody", "tbody": "tr", "thead": "tr", "tfoot": "tr"},
+	"th": {"table": "tbody", "tbody": "tr", "thead": "tr", "tfoot": "tr"},
+}
+
+// tokenListAttrs are the attributes holding lists of tokens separated by
+// whitespace, whose whitespace is normalized.
+var tokenListAttrs = map[string]bool{"class": true, "rel": true}
+
+// parseHTML parses an HTML fragment into a tree rooted at a node without
+// tag, with normalized texts.
+func parseHTML(s string) (*htmlNode, error) {
+	p := &htmlParser{s: s, root: &htmlNode{}}
+	p.cur = p.root
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	p.root.normalize(false)
+	return p.root, nil
+}
+
+// htmlParser builds the tree of an HTML fragment.
+type htmlParser struct {
+	s    string
+	pos  int
+	root *htmlNode
+	cur  *htmlNode
+}
+
+func (p *htmlParser) parse() error {
+	for p.pos < len(p.s) {
+		rest := p.s[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return errors.New("unclosed comment")
+			}
+			p.pos += 4 + end + 3
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			p.skipPast('>')
+		case strings.HasPrefix(rest, "</") && len(rest) > 2 && isASCIILetter(rest[2]):
+			p.pos += 2
+			name := strings.ToLower(p.readName())
+			p.skipPast('>')
+			if err := p.end(name); err != nil {
+				return err
+			}
+		case rest[0] == '<' && len(rest) > 1 && isASCIILetter(rest[1]):
+			p.pos++
+			if err := p.start(); err != nil {
+				return err
+			}
+		default:
+			end := strings.IndexByte(rest[1:], '<') + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			p.text(html.UnescapeString(rest[:end]))
+			p.pos += end
+		}
+	}
+
+	for p.cur != p.root {
+		if _, ok := impliedEndTags[p.cur.tag]; !ok {
+			return fmt.Errorf("unclosed tag <%s>", p.cur.tag)
+		}
+		p.cur = p.cur.parent
+	}
+	return nil
+}
+
+// start parses a start tag, after its "<".
+func (p *htmlParser) start() error {
+	n := &htmlNode{tag: strings.ToLower(p.readName())}
+
+	for {
+		p.skipSpace()
+		switch {
+		case p.pos >= len(p.s):
+			return fmt.Errorf("unclosed start tag <%s", n.tag)
+		case p.s[p.pos] == '>':
+			p.pos++
+			return p.open(n, false)
+		case strings.HasPrefix(p.s[p.pos:], "/>"):
+			p.pos += 2
+			return p.open(n, true)
+		case p.s[p.pos] == '/' || p.s[p.pos] == '=':
+			p.pos++
+			continue
+		}
+
+		name := strings.ToLower(p.readName())
+		value := ""
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == '=' {
+			p.pos++
+			p.skipSpace()
+			value = html.UnescapeString(p.readValue())
+		}
+		if tokenListAttrs[name] {
+			value = collapseSpace(value, true)
+		}
+		n.attrs = append(n.attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+}
+
+// open adds the element n, after closing the open elements whose end its
+// start implies. The content of raw text elements is read at once.
+func (p *htmlParser) open(n *htmlNode, selfClosing bool) error {
+	sort.SliceStable(n.attrs, func(i, j int) bool { return n.attrs[i].Name.Local < n.attrs[j].Name.Local })
+
+	for p.cur != p.root && impliesEnd(n.tag, p.cur.tag) {
+		p.cur = p.cur.parent
+	}
+	for {
+		tag, ok := impliedParents[n.tag][p.cur.tag]
+		if !ok {
+			break
+		}
+		parent := &htmlNode{tag: tag, parent: p.cur}
+		p.cur.children = append(p.cur.children, parent)
+		p.cur = parent
+	}
+	n.parent = p.cur
+	p.cur.children = append(p.cur.children, n)
+
+	if selfClosing || voidElements[n.tag] {
+		return nil
+	}
+	decode, raw := rawTextElements[n.tag]
+	if !raw {
+		p.cur = n
+		return nil
+	}
+
+	end := indexFold(p.s[p.pos:], "</"+n.tag)
+	if end < 0 {
+		return fmt.Errorf("unclosed tag <%s>", n.tag)
+	}
+	text := p.s[p.pos : p.pos+end]
+	if decode {
+		text = html.UnescapeString(text)
+	}
+	if text != "" {
+		n.children = append(n.children, &htmlNode{text: text, parent: n})
+	}
+	p.pos += end
+	p.skipPast('>')
+	return nil
+}
+
+// end closes the open element named name, and the elements within it
+// whose end tag may be omitted. End tags of void elements are ignored.
+func (p *htmlParser) end(name string) error {
+	for n := p.cur; n != p.root; n = n.parent {
+		if n.tag == name {
+			p.cur = n.parent
+			return nil
+		}
+		if _, ok := impliedEndTags[n.tag]; !ok && !voidElements[name] {
+			return fmt.Errorf("unclosed tag <%s>", n.tag)
+		}
+	}
+	if voidElements[name] {
+		return nil
+	}
+	return fmt.Errorf("unexpected closing tag </%s>", name)
+}
+
+// text adds text to the open element, merged with a preceding text.
+func (p *htmlParser) text(text string) {
+	if k := len(p.cur.children); k > 0 && p.cur.children[k-1].tag == "" {
+		p.cur.children[k-1].text += text
+		return
+	}
+	p.cur.children = append(p.cur.children, &htmlNode{text: text, parent: p.cur})
+}
+
+// impliesEnd reports whether the start tag of an element named start
+// closes an open element named open.
+func impliesEnd(start, open string) bool {
+	for _, tag := range impliedEndTags[open] {
+		if tag == start {
+			return true
+		}
+	}
+	return false
+}
+
+// readName reads a tag or attribute name.
+func (p *htmlParser) readName() string {
+	i := p.pos
+	for p.pos < len(p.s) && !isHTMLSpace(p.s[p.pos]) && !strings.ContainsRune("/>=", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[i:p.pos]
+}
+
+// readValue reads an attribute value, quoted or not.
+func (p *htmlParser) readValue() string {
+	if p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+		quote := p.s[p.pos]
+		p.pos++
+		i := p.pos
+		p.skipPast(quote)
+		return strings.TrimSuffix(p.s[i:p.pos], string(quote))
+	}
+	i := p.pos
+	for p.pos < len(p.s) && !isHTMLSpace(p.s[p.pos]) && p.s[p.pos] != '>' {
+		p.pos++
+	}
+	return p.s[i:p.pos]
+}
+
+func (p *htmlParser) skipSpace() {
+	for p.pos < len(p.s) && isHTMLSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+// skipPast moves past the next c, or to the end of the input.
+func (p *htmlParser) skipPast(c byte) {
+	if i := strings.IndexByte(p.s[p.pos:], c); i >= 0 {
+		p.pos += i + 1
+		return
+	}
+	p.pos = len(p.s)
+}
+
+// normalize collapses the whitespace of the texts of n and its
+// descendants, except within verbatim elements. Whitespace at the edges
+// of elements is dropped, as is whitespace-only text between elements.
+func (n *htmlNode) normalize(verbatim bool) {
+	if verbatimElements[n.tag] {
+		verbatim = true
+		if len(n.children) > 0 && n.children[0].tag == "" {
+			// Like browsers, ignore a newline right after the start tag.
+			first := n.children[0]
+			first.text = strings.TrimPrefix(strings.TrimPrefix(first.text, "\r"), "\n")
+		}
+	}
+
+	children := n.children[:0]
+	for i, c := range n.children {
+		if c.tag != "" {
+			c.normalize(verbatim)
+			children = append(children, c)
+			continue
+		}
+		if !verbatim {
+			if collapseSpace(c.text, true) == "" {
+				continue
+			}
+			c.text = collapseSpace(c.text, false)
+			if i == 0 {
+				c.text = strings.TrimPrefix(c.text, " ")
+			}
+			if i == len(n.children)-1 {
+				c.text = strings.TrimSuffix(c.text, " ")
+			}
+		}
+		if c.text != "" {
+			children = append(children, c)
+		}
+	}
+	n.children = children
+}
+
+// collapseSpace replaces the runs of HTML whitespace in s with a single
+// space, dropping them at the edges of s when trim is set. Unlike
+// strings.Fields, it keeps non-breaking spaces.
+func collapseSpace(s string, trim bool) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(s); i++ {
+		if isHTMLSpace(s[i]) {
+			space = true
+			continue
+		}
+		if space && (!trim || b.Len() > 0) {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte(s[i])
+	}
+	if space && !trim {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// indexFold returns the index of the first instance of substr in s,
+// ignoring ASCII case, or -1 if it is not present.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// inner renders the children of the node in their normalized form.
+func (n *htmlNode) inner() string {
+	var b strings.Builder
+	for _, c := range n.children {
+		c.render(&b)
+	}
+	return b.String()
+}
+
+func (n *htmlNode) render(b *strings.Builder) {
+	if n.tag == "" {
+		b.WriteString(html.EscapeString(n.text))
+		return
+	}
+
+	b.WriteString("<" + n.tag)
+	for _, attr := range n.attrs {
+		b.WriteString(" " + attr.Name.Local + `="` + html.EscapeString(attr.Value) + `"`)
+	}
+	b.WriteString(">")
+	if voidElements[n.tag] {
+		return
+	}
+	for _, c := range n.children {
+		c.render(b)
+	}
+	b.WriteString("</" + n.tag + ">")
+}
+
+// path describes the position of an element child, such as "div[2]".
+func (n *htmlNode) path(parent string, index int) string {
+	name := n.tag
+	if name == "" {
+		name = "text()"
+	}
+	if parent != "" {
+		parent += " > "
+	}
+	return fmt.Sprintf("%s%s[%d]", parent, name, index+1)
+}
+
+// diffHTML describes the first difference between two trees,
+// or returns an empty string when they are equal.
+func diffHTML(a, e *htmlNode, path string) string {
+	where := ""
+	if path != "" {
+		where = " at " + path
+	}
+
+	switch {
+	case a.tag != e.tag:
+		return fmt.Sprintf("expected %s, got %s%s", e.describe(), a.describe(), where)
+	case a.tag == "" && a.text != e.text:
+		return fmt.Sprintf("expected text %q, got %q%s", e.text, a.text, where)
+	}
+
+	if as, es := attrsString(a.attrs), attrsString(e.attrs); as != es {
+		return fmt.Sprintf("expected attributes [%s], got [%s]%s", es, as, where)
+	}
+
+	for i := 0; i < len(a.children) && i < len(e.children); i++ {
+		if note := diffHTML(a.children[i], e.children[i], e.children[i].path(path, i)); note != "" {
+			return note
+		}
+	}
+
+	switch {
+	case len(a.children) > len(e.children):
+		return fmt.Sprintf("unexpected %s%s", a.children[len(e.children)].describe(), where)
+	case len(a.children) < len(e.children):
+		return fmt.Sprintf("missing %s%s", e.children[len(a.children)].describe(), where)
+	}
+	return ""
+}
+
+// describe names a node in difference notes.
+func (n *htmlNode) describe() string {
+	if n.tag == "" {
+		return fmt.Sprintf("text %q", n.text)
+	}
+	return "<" + n.tag + ">"
+}
+
+// attrsString formats sorted attributes for comparison.
+func attrsString(attrs []xml.Attr) string {
+	parts := make([]string, len(attrs))
+	for i, attr := range attrs {
+		parts[i] = fmt.Sprintf("%s=%q", attr.Name.Local, attr.Value)
+	}
+	return strings.Join(parts, " ")
+}
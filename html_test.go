@@ -0,0 +1,249 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLEq(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		expected  string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "identical",
+			actual:    `<p>hello</p>`,
+			expected:  `<p>hello</p>`,
+			wantError: false,
+		},
+		{
+			name: "formatting only",
+			actual: `
+				<ul   class="menu  main">
+					<li>Home</li>
+					<li>About   us</li>
+				</ul>`,
+			expected:  `<ul class="menu main"><li>Home</li><li>About us</li></ul>`,
+			wantError: false,
+		},
+		{
+			name:      "attribute order and case",
+			actual:    `<A HREF="/x" title="X">link</A>`,
+			expected:  `<a title="X" href="/x">link</a>`,
+			wantError: false,
+		},
+		{
+			name:      "html specifics",
+			actual:    `<p>Tom &amp; Jerry&nbsp;<br><input type=checkbox checked></p><!-- comment -->`,
+			expected:  `<p>Tom &amp; Jerry&nbsp;<br/><input checked="" type="checkbox"/></p>`,
+			wantError: false,
+		},
+		{
+			name:      "attribute without value",
+			actual:    `<input disabled>`,
+			expected:  `<input disabled="">`,
+			wantError: false,
+		},
+		{
+			name:      "attribute without value is empty",
+			actual:    `<input disabled>`,
+			expected:  `<input disabled="disabled">`,
+			wantError: true,
+		},
+		{
+			name:      "implied tbody",
+			actual:    `<table><tr><td>1</td></tr></table>`,
+			expected:  `<table><tbody><tr><td>1</td></tr></tbody></table>`,
+			wantError: false,
+		},
+		{
+			name:      "several roots",
+			actual:    `<h1>Title</h1> <p>Body</p>`,
+			expected:  `<h1>Title</h1><p>Body</p>`,
+			wantError: false,
+		},
+		{
+			name:      "different text",
+			actual:    `<div><p>hello</p><p>world</p></div>`,
+			expected:  `<div><p>hello</p><p>gophers</p></div>`,
+			wantError: true,
+			wantParts: []string{`expected text "gophers", got "world" at div[1] > p[2] > text()[1]`},
+		},
+		{
+			name:      "different attribute",
+			actual:    `<div class="error">x</div>`,
+			expected:  `<div class="warning">x</div>`,
+			wantError: true,
+			wantParts: []string{`expected attributes [class="warning"], got [class="error"] at div[1]`},
+		},
+		{
+			name:      "different tag",
+			actual:    `<span>x</span>`,
+			expected:  `<div>x</div>`,
+			wantError: true,
+			wantParts: []string{"expected <div>, got <span> at div[1]"},
+		},
+		{
+			name:      "missing element",
+			actual:    `<ul><li>a</li></ul>`,
+			expected:  `<ul><li>a</li><li>b</li></ul>`,
+			wantError: true,
+			wantParts: []string{"missing <li> at ul[1]"},
+		},
+		{
+			name:      "unexpected element",
+			actual:    `<p>a</p><p>b</p>`,
+			expected:  `<p>a</p>`,
+			wantError: true,
+			wantParts: []string{"unexpected <p>"},
+		},
+		{
+			name:      "void elements at the end",
+			actual:    `<img src="x.png" alt="">`,
+			expected:  `<img alt="" src="x.png">`,
+			wantError: false,
+		},
+		{
+			name:      "unclosed input",
+			actual:    `<input type="text">`,
+			expected:  `<input type="text"/>`,
+			wantError: false,
+		},
+		{
+			name:      "closed void element",
+			actual:    `<p>a<br></br>b</p>`,
+			expected:  `<p>a<br>b</p>`,
+			wantError: false,
+		},
+		{
+			name:      "script as raw text",
+			actual:    `<script>if (a < b && c) { x = "</p>" }</script><p>x</p>`,
+			expected:  `<script>if (a < b && c) { x = "</p>" }</script><p>x</p>`,
+			wantError: false,
+		},
+		{
+			name:      "different script",
+			actual:    `<style>a < b {}</style>`,
+			expected:  `<style>a > b {}</style>`,
+			wantError: true,
+			wantParts: []string{`expected text "a > b {}", got "a < b {}"`},
+		},
+		{
+			name:      "implied end tags",
+			actual:    `<ul><li>a<li>b</ul><table><tr><td>1<td>2<tr><td>3</table><select><option>x<option>y</select><p>one<p>two`,
+			expected:  `<ul><li>a</li><li>b</li></ul><table><tr><td>1</td><td>2</td></tr><tr><td>3</td></tr></table><select><option>x</option><option>y</option></select><p>one</p><p>two</p>`,
+			wantError: false,
+		},
+		{
+			name:      "space between text and element",
+			actual:    `<p>Hello <b>world</b></p>`,
+			expected:  `<p>Hello<b>world</b></p>`,
+			wantError: true,
+			wantParts: []string{`expected text "Hello", got "Hello "`},
+		},
+		{
+			name:      "spaces collapsed at boundaries",
+			actual:    "<p>\n  Hello\n  <b>world</b>  </p>",
+			expected:  `<p>Hello <b>world</b></p>`,
+			wantError: false,
+		},
+		{
+			name:      "pre kept verbatim",
+			actual:    `<pre>a  b</pre>`,
+			expected:  `<pre>a b</pre>`,
+			wantError: true,
+			wantParts: []string{`expected text "a b", got "a  b"`},
+		},
+		{
+			name:      "textarea kept verbatim",
+			actual:    "<textarea>\nline 1\n  line 2</textarea>",
+			expected:  "<textarea>line 1\n  line 2</textarea>",
+			wantError: false,
+		},
+		{
+			name:      "attribute value kept",
+			actual:    `<input value="a  b">`,
+			expected:  `<input value="a b">`,
+			wantError: true,
+			wantParts: []string{`expected attributes [value="a b"], got [value="a  b"]`},
+		},
+		{
+			name:      "class whitespace normalized",
+			actual:    `<a class=" x   y " rel="nofollow  noopener">z</a>`,
+			expected:  `<a class="x y" rel="nofollow noopener">z</a>`,
+			wantError: false,
+		},
+		{
+			name:      "unclosed actual",
+			actual:    `<div><p>a</p>`,
+			expected:  `<div><p>a</p></div>`,
+			wantError: true,
+			wantParts: []string{"invalid actual HTML"},
+		},
+		{
+			name:      "invalid expected",
+			actual:    `<p>a</p>`,
+			expected:  `</p>`,
+			wantError: true,
+			wantParts: []string{"invalid expected HTML"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			HTMLEq(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("HTMLEq() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("HTMLEq() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestParseHTMLNormalizes(t *testing.T) {
+	root, err := parseHTML(`<DIV id="a"  Class=" x  y ">  Hello <b>world</b> </DIV>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<div class="x y" id="a">Hello <b>world</b></div>`
+	if got := root.inner(); got != want {
+		t.Errorf("inner() = %q, want %q", got, want)
+	}
+}
+
+func TestParseHTMLImpliedEndTags(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{`<ul><li>a<li>b</ul>`, `<ul><li>a</li><li>b</li></ul>`},
+		{`<ul><li>a<ul><li>b</ul></ul>`, `<ul><li>a<ul><li>b</li></ul></li></ul>`},
+		{`<p>a<div>b</div>`, `<p>a</p><div>b</div>`},
+		{`<dl><dt>k<dd>v<dt>k2</dl>`, `<dl><dt>k</dt><dd>v</dd><dt>k2</dt></dl>`},
+		{`<table><tr><th>h<td>d</table>`, `<table><tbody><tr><th>h</th><td>d</td></tr></tbody></table>`},
+		{`<table><td>a<tr><td>b</table>`, `<table><tbody><tr><td>a</td></tr><tr><td>b</td></tr></tbody></table>`},
+		{`<table><thead><th>h<tbody><tr><td>d</table>`, `<table><thead><tr><th>h</th></tr></thead><tbody><tr><td>d</td></tr></tbody></table>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.html, func(t *testing.T) {
+			root, err := parseHTML(tt.html)
+			NoError(t, err)
+			Equal(t, root.inner(), tt.want)
+		})
+	}
+}
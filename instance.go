@@ -0,0 +1,81 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"testing"
+	"text/template"
+)
+
+// Assert binds assertions to a testing.TB.
+// It implements testing.TB itself, so it can be passed to every
+// assertion function of this package:
+//
+//	a := assert.New(t)
+//	assert.Equal(a, got, want)
+type Assert struct {
+	testing.TB
+
+	// template overrides the layout of failure messages.
+	template *template.Template
+	// labels prefix failure messages, see WithLabel. The last one is
+	// the path of a Scope when scoped is set.
+	labels []string
+	scoped bool
+	// clock overrides the clock of the assertions, see SetClock.
+	clock Clock
+	// callerSkip is the number of frames skipped above the caller
+	// of the assertions, see WithCallerSkip.
+	callerSkip int
+	// locationFormat overrides the format of failure locations,
+	// see SetLocationFormat.
+	locationFormat *LocationFormat
+	// tracer logs the assertions that pass, see WithTrace.
+	tracer *tracer
+	// summary collapses failures and counts the assertions,
+	// see WithSummary.
+	summary *summary
+	// differ overrides the Differ of failure diffs, see SetDiffer.
+	differ Differ
+	// required stops the test at the first failure, see Require.
+	required bool
+}
+
+// New creates an Assert bound to t.
+func New(t testing.TB) *Assert {
+	return &Assert{TB: t}
+}
+
+// Run runs fn as a subtest named name and reports whether it succeeded.
+// The child Assert is bound to the subtest and inherits the settings of a,
+// and of the Assert values a wraps. The underlying TB must support
+// subtests, such as *testing.T.
+func (a *Assert) Run(name string, fn func(a *Assert)) bool {
+	a.TB.Helper()
+
+	root := rootTB(a)
+	runner, ok := root.(interface {
+		Run(string, func(*testing.T)) bool
+	})
+	if !ok {
+		a.TB.Fatalf("\nRun() requires a TB supporting subtests, got %T", root)
+		return false
+	}
+
+	return runner.Run(name, func(t *testing.T) {
+		fn(rebind(a, t))
+	})
+}
+
+// rebind returns a copy of a, and of the Assert values it wraps, bound to t
+// in place of the TB they wrap.
+func rebind(a *Assert, t testing.TB) *Assert {
+	child := *a
+	if inner, ok := a.TB.(*Assert); ok {
+		child.TB = rebind(inner, t)
+	} else {
+		child.TB = t
+	}
+	return &child
+}
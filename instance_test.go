@@ -0,0 +1,74 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	t.Run("failures are reported to the bound TB", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+
+		Equal(a, 1, 2)
+
+		if !rec.HasError() {
+			t.Error("Equal() did not report failure through Assert")
+		}
+	})
+
+	t.Run("success reports nothing", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+
+		True(a, true)
+
+		if rec.HasError() {
+			t.Error("True() reported failure through Assert")
+		}
+	})
+}
+
+func TestAssertRun(t *testing.T) {
+	a := New(t)
+	var names []string
+
+	ok := a.Run("parent", func(a *Assert) {
+		names = append(names, a.Name())
+
+		a.Run("child", func(a *Assert) {
+			names = append(names, a.Name())
+			Equal(a, 1, 1)
+		})
+	})
+
+	True(t, ok)
+	Equal(t, names, []string{"TestAssertRun/parent", "TestAssertRun/parent/child"})
+}
+
+func TestAssertRunNested(t *testing.T) {
+	rec := NewTestRecorder(t)
+	r := Require(New(rec).WithLabel("user 42"))
+
+	var sub *Assert
+	ok := r.Run("sub", func(a *Assert) {
+		sub = a
+		Equal(t, a.Name(), "TestAssertRunNested/sub")
+	})
+
+	True(t, ok)
+	False(t, rec.HasError(), rec.ErrorMessage())
+	True(t, lookupRequired(sub), "required kept")
+	Equal(t, rootTB(sub).Name(), "TestAssertRunNested/sub")
+
+	var labels []string
+	for tb := testing.TB(sub); ; {
+		a, ok := tb.(*Assert)
+		if !ok {
+			break
+		}
+		labels = append(labels, a.labels...)
+		tb = a.TB
+	}
+	Equal(t, labels, []string{"user 42"}, "labels kept")
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// JSONEq checks if two JSON documents are semantically equal, ignoring
+// formatting and the order of object keys. Numbers are compared by their
+// decimal value instead of being converted to float64, so that 64-bit IDs
+// above 2^53 are not rounded. Differences are reported by path, as in
+// "$.users[0].id: expected 9007199254740993, got 9007199254740992", and
+// shown in a diff of the indented documents computed by the Differ of t,
+// unless NumberTolerance or UnorderedArrays is set.
+// Option NumberTolerance allows numbers to differ slightly, and
+// UnorderedArrays ignores the order of array elements:
+//
+//	assert.JSONEq(t, body, `{"id": 1, "score": 0.3}`, assert.NumberTolerance(1e-9))
+func JSONEq(t testing.TB, actual, expected string, opts ...Option) {
+	t.Helper()
+	defer observe(t)()
+
+	o := newOptions(opts)
+
+	e, err := readJSON(expected)
+	if err != nil {
+		failCompare(t, expected, "valid JSON", withMessage("invalid expected JSON: "+err.Error(), o.messages())...)
+		return
+	}
+	a, err := readJSON(actual)
+	if err != nil {
+		failCompare(t, actual, "valid JSON", withMessage("invalid actual JSON: "+err.Error(), o.messages())...)
+		return
+	}
+
+	if diffs := diffJSON(a, e, "$", o); len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		var diff string
+		if o.numberTolerance == 0 && !o.unorderedArrays {
+			diff = textDiff(t, indentJSON(e), indentJSON(a))
+		}
+		reportFailure(t, actual, expected, strings.Join(diffs, "; "), diff, withMessage("JSON documents differ", o.messages()))
+	}
+}
+
+// indentJSON formats a decoded JSON value with one member or element per
+// line and sorted keys, for a line diff of two documents.
+func indentJSON(v any) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// readJSON decodes a single JSON value, keeping numbers as json.Number.
+func readJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// diffJSON compares two decoded JSON values, and describes
+// their differences prefixed by their path.
+func diffJSON(a, e any, path string, o *options) []string {
+	switch ev := e.(type) {
+	case map[string]any:
+		av, ok := a.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object, got %s", path, jsonKind(a))}
+		}
+		return diffJSONObjects(av, ev, path, o)
+	case []any:
+		av, ok := a.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %s", path, jsonKind(a))}
+		}
+		return diffJSONArrays(av, ev, path, o)
+	case json.Number:
+		av, ok := a.(json.Number)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected number, got %s", path, jsonKind(a))}
+		}
+		if !equalNumbers(av, ev, o.numberTolerance) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, ev, av)}
+		}
+	default:
+		if jsonKind(a) != jsonKind(e) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, jsonKind(e), jsonKind(a))}
+		}
+		if a != e {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, formatJSON(e), formatJSON(a))}
+		}
+	}
+	return nil
+}
+
+func diffJSONObjects(a, e map[string]any, path string, o *options) []string {
+	keys := make([]string, 0, len(a)+len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	for k := range a {
+		if _, ok := e[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []string
+	for _, k := range keys {
+		av, inActual := a[k]
+		ev, inExpected := e[k]
+		p := jsonPath(path, k)
+
+		switch {
+		case !inActual:
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", p, formatJSON(ev)))
+		case !inExpected:
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected, got %s", p, formatJSON(av)))
+		default:
+			diffs = append(diffs, diffJSON(av, ev, p, o)...)
+		}
+	}
+	return diffs
+}
+
+func diffJSONArrays(a, e []any, path string, o *options) []string {
+	if o.unorderedArrays {
+		return diffJSONUnordered(a, e, path, o)
+	}
+
+	var diffs []string
+
+	if len(a) != len(e) {
+		diffs = append(diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(e), len(a)))
+	}
+	for i := 0; i < len(a) && i < len(e); i++ {
+		diffs = append(diffs, diffJSON(a[i], e[i], fmt.Sprintf("%s[%d]", path, i), o)...)
+	}
+	return diffs
+}
+
+// diffJSONUnordered compares two arrays as multisets. Elements holding
+// the key field are matched by key, and compared; the others are matched
+// to any equal element left.
+func diffJSONUnordered(a, e []any, path string, o *options) []string {
+	var diffs []string
+	matched := make([]bool, len(a))
+	var rest []any
+
+	byKey := map[string][]int{}
+	if o.arrayKey != "" {
+		for i, v := range a {
+			if k, ok := jsonKey(v, o.arrayKey); ok {
+				byKey[k] = append(byKey[k], i)
+			}
+		}
+	}
+
+	for _, ev := range e {
+		k, ok := jsonKey(ev, o.arrayKey)
+		if !ok {
+			rest = append(rest, ev)
+			continue
+		}
+		idx := byKey[k]
+		if len(idx) == 0 {
+			diffs = append(diffs, fmt.Sprintf("%s: missing element %s", path, formatJSON(ev)))
+			continue
+		}
+		byKey[k] = idx[1:]
+		matched[idx[0]] = true
+		diffs = append(diffs, diffJSON(a[idx[0]], ev, fmt.Sprintf("%s[%s=%s]", path, o.arrayKey, k), o)...)
+	}
+
+next:
+	for _, ev := range rest {
+		for i, av := range a {
+			if !matched[i] && len(diffJSON(av, ev, path, o)) == 0 {
+				matched[i] = true
+				continue next
+			}
+		}
+		diffs = append(diffs, fmt.Sprintf("%s: missing element %s", path, formatJSON(ev)))
+	}
+
+	for i, av := range a {
+		if !matched[i] {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected element %s", path, formatJSON(av)))
+		}
+	}
+	return diffs
+}
+
+// jsonKey returns the value of the key field of an object element,
+// formatted as JSON.
+func jsonKey(v any, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	k, ok := obj[key]
+	if !ok {
+		return "", false
+	}
+	return formatJSON(k), true
+}
+
+// jsonNumberPrec is the precision used to compare JSON numbers,
+// enough to hold any 64-bit integer or float64 exactly.
+const jsonNumberPrec = 256
+
+// equalNumbers reports whether two JSON numbers differ by at most tolerance.
+func equalNumbers(a, e json.Number, tolerance float64) bool {
+	if a == e {
+		return true
+	}
+
+	x, _, errA := big.ParseFloat(a.String(), 10, jsonNumberPrec, big.ToNearestEven)
+	y, _, errE := big.ParseFloat(e.String(), 10, jsonNumberPrec, big.ToNearestEven)
+	if errA != nil || errE != nil {
+		return false
+	}
+
+	if tolerance == 0 {
+		return x.Cmp(y) == 0
+	}
+	delta := new(big.Float).SetPrec(jsonNumberPrec).Sub(x, y)
+	return delta.Abs(delta).Cmp(big.NewFloat(tolerance)) <= 0
+}
+
+// jsonKind returns the JSON type of a decoded value.
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// formatJSON formats a decoded value as JSON.
+func formatJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// jsonIdent matches the object keys written as .key in a path.
+var jsonIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonPath appends an object key to a path.
+func jsonPath(path, key string) string {
+	if jsonIdent.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONEq(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		expected  string
+		opts      []Option
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name:     "formatting and key order ignored",
+			actual:   `{"b": [1, 2], "a": {"x": null}}`,
+			expected: "{\n  \"a\": {\"x\": null},\n  \"b\": [1, 2]\n}",
+		},
+		{
+			name:     "same number written differently",
+			actual:   `{"n": 1.50}`,
+			expected: `{"n": 15e-1}`,
+		},
+		{
+			name:      "large integers above 2^53",
+			actual:    `{"id": 9007199254740992}`,
+			expected:  `{"id": 9007199254740993}`,
+			wantError: true,
+			wantNote:  "$.id: expected 9007199254740993, got 9007199254740992",
+		},
+		{
+			name:     "large equal integers",
+			actual:   `[18446744073709551615]`,
+			expected: `[18446744073709551615.0]`,
+		},
+		{
+			name:     "within tolerance",
+			actual:   `{"score": 0.30000000000000004}`,
+			expected: `{"score": 0.3}`,
+			opts:     []Option{NumberTolerance(1e-9)},
+		},
+		{
+			name:      "outside tolerance",
+			actual:    `{"score": 0.31}`,
+			expected:  `{"score": 0.3}`,
+			opts:      []Option{NumberTolerance(1e-9)},
+			wantError: true,
+			wantNote:  "$.score: expected 0.3, got 0.31",
+		},
+		{
+			name:      "missing and unexpected keys",
+			actual:    `{"a": 1, "c": 3}`,
+			expected:  `{"a": 1, "b": 2}`,
+			wantError: true,
+			wantNote:  "$.b: missing, expected 2; $.c: unexpected, got 3",
+		},
+		{
+			name:      "nested arrays",
+			actual:    `{"users": [{"name": "ann"}, {"name": "bob"}]}`,
+			expected:  `{"users": [{"name": "ann"}, {"name": "eve"}, {"name": "joe"}]}`,
+			wantError: true,
+			wantNote:  `$.users: expected 3 elements, got 2; $.users[1].name: expected "eve", got "bob"`,
+		},
+		{
+			name:     "unordered arrays",
+			actual:   `{"tags": ["b", "a", "b"], "m": [[2, 1]]}`,
+			expected: `{"tags": ["a", "b", "b"], "m": [[1, 2]]}`,
+			opts:     []Option{UnorderedArrays()},
+		},
+		{
+			name:      "unordered arrays with different counts",
+			actual:    `["a", "b", "b"]`,
+			expected:  `["a", "a", "b"]`,
+			opts:      []Option{UnorderedArrays()},
+			wantError: true,
+			wantNote:  `$: missing element "a"; $: unexpected element "b"`,
+		},
+		{
+			name:      "order kept by default",
+			actual:    `["b", "a"]`,
+			expected:  `["a", "b"]`,
+			wantError: true,
+			wantNote:  `$[0]: expected "a", got "b"`,
+		},
+		{
+			name:     "unordered arrays keyed",
+			actual:   `{"users": [{"id": 2, "name": "bob"}, {"id": 1, "name": "ann"}]}`,
+			expected: `{"users": [{"id": 1, "name": "ann"}, {"id": 2, "name": "bob"}]}`,
+			opts:     []Option{UnorderedArrays("id")},
+		},
+		{
+			name:      "unordered arrays keyed with differences",
+			actual:    `{"users": [{"id": 2, "name": "bob"}, {"id": 3}, {"id": 1, "name": "ann"}]}`,
+			expected:  `{"users": [{"id": 1, "name": "ann"}, {"id": 2, "name": "eve"}, {"id": "4"}]}`,
+			opts:      []Option{UnorderedArrays("id")},
+			wantError: true,
+			wantNote:  `$.users[id=2].name: expected "eve", got "bob"; $.users: missing element {"id":"4"}; $.users: unexpected element {"id":3}`,
+		},
+		{
+			name:     "unordered arrays keyed with elements without key",
+			actual:   `[{"x": 1}, {"id": "a"}, 5]`,
+			expected: `[5, {"id": "a"}, {"x": 1}]`,
+			opts:     []Option{UnorderedArrays("id")},
+		},
+		{
+			name:      "different types",
+			actual:    `{"id": "1"}`,
+			expected:  `{"id": 1}`,
+			wantError: true,
+			wantNote:  "$.id: expected number, got string",
+		},
+		{
+			name:      "quoted keys",
+			actual:    `{"content-type": "text/plain"}`,
+			expected:  `{"content-type": "text/html"}`,
+			wantError: true,
+			wantNote:  `$["content-type"]: expected "text/html", got "text/plain"`,
+		},
+		{
+			name:      "invalid actual",
+			actual:    `{"a":`,
+			expected:  `{}`,
+			wantError: true,
+			wantNote:  "invalid actual JSON",
+		},
+		{
+			name:      "trailing data",
+			actual:    `{} {}`,
+			expected:  `{}`,
+			wantError: true,
+			wantNote:  "unexpected data after top-level value",
+		},
+		{
+			name:      "invalid expected",
+			actual:    `{}`,
+			expected:  `nope`,
+			wantError: true,
+			wantNote:  "invalid expected JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			JSONEq(rec, tt.actual, tt.expected, tt.opts...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("JSONEq() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("JSONEq() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		JSONEq(rec, `1`, `2`, Message("response body"))
+
+		if !strings.Contains(rec.ErrorMessage(), "Message: response body: JSON documents differ") {
+			t.Errorf("JSONEq() message missing custom message\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
@@ -0,0 +1,725 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// maxRefDepth is the maximum number of nested $ref followed while
+// validating a value, so that cyclic references terminate.
+const maxRefDepth = 256
+
+// MatchesJSONSchema checks that a JSON document is valid against a
+// JSON Schema (draft 2020-12), and reports each violation with the JSON
+// pointer of the invalid value:
+//
+//	assert.MatchesJSONSchema(t, body, `{
+//	    "type": "object",
+//	    "required": ["id", "name"],
+//	    "properties": {
+//	        "id":   {"type": "integer", "minimum": 1},
+//	        "tags": {"type": "array", "items": {"type": "string"}}
+//	    }
+//	}`)
+//	// Note: /tags/1: expected string, got number
+//
+// The validation vocabularies are supported, with the applicators,
+// unevaluatedProperties and unevaluatedItems, and references within the
+// schema ("#/$defs/user"). Remote references and $dynamicRef are not
+// supported, and reported as invalid schemas. Keyword format is an
+// annotation and is not checked, and patterns use the RE2 syntax of
+// package regexp rather than ECMA-262 regular expressions.
+func MatchesJSONSchema(t testing.TB, document, schema string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	s, err := readJSON(schema)
+	if err != nil {
+		failCompare(t, schema, "valid JSON", withMessage("invalid schema JSON: "+err.Error(), msg)...)
+		return
+	}
+	d, err := readJSON(document)
+	if err != nil {
+		failCompare(t, document, "valid JSON", withMessage("invalid document JSON: "+err.Error(), msg)...)
+		return
+	}
+
+	v := &schemaValidator{root: s}
+	v.validate(d, s, "")
+
+	if v.err != nil {
+		failCompare(t, schema, "valid JSON Schema", withMessage("invalid schema: "+v.err.Error(), msg)...)
+		return
+	}
+	if diffs := v.violations; len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, document, "document matching schema", strings.Join(diffs, "; "),
+			withMessage("document does not match schema", msg)...)
+	}
+}
+
+// schemaValidator validates JSON values decoded by readJSON against
+// the root schema and its subschemas.
+type schemaValidator struct {
+	root       any
+	patterns   map[string]*regexp.Regexp
+	refDepth   int
+	violations []string
+
+	// err is the first error found in the schema itself.
+	err error
+}
+
+// fail records a violation of the value at the JSON pointer ptr.
+func (v *schemaValidator) fail(ptr, format string, args ...any) {
+	if ptr == "" {
+		ptr = "(root)"
+	}
+	v.violations = append(v.violations, ptr+": "+fmt.Sprintf(format, args...))
+}
+
+// invalid records an error in the schema, if none was found before.
+func (v *schemaValidator) invalid(format string, args ...any) {
+	if v.err == nil {
+		v.err = fmt.Errorf(format, args...)
+	}
+}
+
+// evaluated holds the properties and items of an instance evaluated by a
+// schema and its valid subschemas, which unevaluatedProperties and
+// unevaluatedItems apply to the others.
+type evaluated struct {
+	properties map[string]bool
+	// items is the number of leading items evaluated, unless allItems.
+	items    int
+	allItems bool
+	indexes  map[int]bool
+}
+
+// merge adds the properties and items evaluated by o to e.
+func (e *evaluated) merge(o *evaluated) {
+	for name := range o.properties {
+		e.property(name)
+	}
+	e.items = maxInt(e.items, o.items)
+	e.allItems = e.allItems || o.allItems
+	for i := range o.indexes {
+		if e.indexes == nil {
+			e.indexes = map[int]bool{}
+		}
+		e.indexes[i] = true
+	}
+}
+
+// property records that the property name was evaluated.
+func (e *evaluated) property(name string) {
+	if e.properties == nil {
+		e.properties = map[string]bool{}
+	}
+	e.properties[name] = true
+}
+
+// item reports whether the item at index i was evaluated.
+func (e *evaluated) item(i int) bool {
+	return e.allItems || i < e.items || e.indexes[i]
+}
+
+// matches reports whether inst is valid against schema, without
+// recording its violations, and returns what schema evaluated.
+func (v *schemaValidator) matches(inst, schema any, ptr string) (bool, *evaluated) {
+	saved := v.violations
+	v.violations = nil
+	ev := v.validate(inst, schema, ptr)
+	ok := len(v.violations) == 0
+	v.violations = saved
+	return ok, ev
+}
+
+// validate records the violations of inst, found at ptr, against schema,
+// and returns the properties and items of inst it evaluated.
+func (v *schemaValidator) validate(inst, schema any, ptr string) *evaluated {
+	ev := &evaluated{}
+	if v.err != nil {
+		return ev
+	}
+
+	var s map[string]any
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			v.fail(ptr, "not allowed by schema")
+		}
+		return ev
+	case map[string]any:
+		s = schema
+	default:
+		v.invalid("schema must be an object or a boolean, got %s", jsonKind(schema))
+		return ev
+	}
+
+	if ref, ok := s["$ref"]; ok {
+		ev.merge(v.validateRef(inst, ref, ptr))
+	}
+	if ref, ok := s["$dynamicRef"]; ok {
+		v.invalid("unsupported $dynamicRef %s", formatJSON(ref))
+		return ev
+	}
+
+	v.validateType(inst, s, ptr)
+	v.validateEnum(inst, s, ptr)
+	ev.merge(v.validateApplicators(inst, s, ptr))
+
+	switch inst := inst.(type) {
+	case json.Number:
+		v.validateNumber(inst, s, ptr)
+	case string:
+		v.validateString(inst, s, ptr)
+	case []any:
+		ev.merge(v.validateArray(inst, s, ptr))
+		v.validateUnevaluatedItems(inst, s, ptr, ev)
+	case map[string]any:
+		ev.merge(v.validateObject(inst, s, ptr))
+		v.validateUnevaluatedProperties(inst, s, ptr, ev)
+	}
+	return ev
+}
+
+// validateUnevaluatedItems applies unevaluatedItems to the items of arr
+// not evaluated by ev, which then evaluates all of them.
+func (v *schemaValidator) validateUnevaluatedItems(arr []any, s map[string]any, ptr string, ev *evaluated) {
+	sub, ok := s["unevaluatedItems"]
+	if !ok {
+		return
+	}
+	for i, item := range arr {
+		if ev.item(i) {
+			continue
+		}
+		if allowed, ok := sub.(bool); ok && !allowed {
+			v.fail(ptr, "unexpected item %d", i)
+		} else {
+			v.validate(item, sub, pointerIndex(ptr, i))
+		}
+	}
+	ev.allItems = true
+}
+
+// validateUnevaluatedProperties applies unevaluatedProperties to the
+// properties of obj not evaluated by ev, which then evaluates all of them.
+func (v *schemaValidator) validateUnevaluatedProperties(obj map[string]any, s map[string]any, ptr string, ev *evaluated) {
+	sub, ok := s["unevaluatedProperties"]
+	if !ok {
+		return
+	}
+	for _, name := range sortedKeys(obj) {
+		if ev.properties[name] {
+			continue
+		}
+		if allowed, ok := sub.(bool); ok && !allowed {
+			v.fail(ptr, "unexpected property %q", name)
+		} else {
+			v.validate(obj[name], sub, pointerKey(ptr, name))
+		}
+		ev.property(name)
+	}
+}
+
+func (v *schemaValidator) validateRef(inst, ref any, ptr string) *evaluated {
+	r, ok := ref.(string)
+	if !ok || !strings.HasPrefix(r, "#") {
+		v.invalid("unsupported $ref %s: only references within the schema are supported", formatJSON(ref))
+		return &evaluated{}
+	}
+
+	target, err := resolvePointer(v.root, r[1:])
+	if err != nil {
+		v.invalid("cannot resolve $ref %q: %v", r, err)
+		return &evaluated{}
+	}
+
+	if v.refDepth >= maxRefDepth {
+		v.invalid("more than %d nested $ref, the schema may be cyclic", maxRefDepth)
+		return &evaluated{}
+	}
+	v.refDepth++
+	defer func() { v.refDepth-- }()
+	return v.validate(inst, target, ptr)
+}
+
+func (v *schemaValidator) validateType(inst any, s map[string]any, ptr string) {
+	typ, ok := s["type"]
+	if !ok {
+		return
+	}
+
+	var types []string
+	switch typ := typ.(type) {
+	case string:
+		types = []string{typ}
+	case []any:
+		for _, t := range typ {
+			name, ok := t.(string)
+			if !ok {
+				v.invalid("type must be a string or an array of strings")
+				return
+			}
+			types = append(types, name)
+		}
+	default:
+		v.invalid("type must be a string or an array of strings")
+		return
+	}
+
+	actual := jsonKind(inst)
+	for _, t := range types {
+		if t == actual || (t == "integer" && actual == "number" && isInteger(inst.(json.Number))) {
+			return
+		}
+	}
+	v.fail(ptr, "expected %s, got %s", strings.Join(types, " or "), actual)
+}
+
+func (v *schemaValidator) validateEnum(inst any, s map[string]any, ptr string) {
+	if c, ok := s["const"]; ok && !equalJSON(inst, c) {
+		v.fail(ptr, "expected %s, got %s", formatJSON(c), formatJSON(inst))
+	}
+
+	enum, ok := s["enum"]
+	if !ok {
+		return
+	}
+	values, ok := enum.([]any)
+	if !ok {
+		v.invalid("enum must be an array")
+		return
+	}
+	for _, e := range values {
+		if equalJSON(inst, e) {
+			return
+		}
+	}
+	v.fail(ptr, "%s is not one of %s", formatJSON(inst), formatJSON(values))
+}
+
+// validateApplicators applies the in-place applicators:
+// allOf, anyOf, oneOf, not, if/then/else and dependentSchemas.
+// It returns what their valid subschemas evaluated.
+func (v *schemaValidator) validateApplicators(inst any, s map[string]any, ptr string) *evaluated {
+	ev := &evaluated{}
+	for _, sub := range v.schemas(s, "allOf") {
+		ev.merge(v.validate(inst, sub, ptr))
+	}
+
+	if anyOf := v.schemas(s, "anyOf"); len(anyOf) > 0 {
+		matched := false
+		// All subschemas are applied, for what they evaluate.
+		for _, sub := range anyOf {
+			if ok, subEv := v.matches(inst, sub, ptr); ok {
+				matched = true
+				ev.merge(subEv)
+			}
+		}
+		if !matched {
+			v.fail(ptr, "does not match any schema of anyOf")
+		}
+	}
+
+	if oneOf := v.schemas(s, "oneOf"); len(oneOf) > 0 {
+		var matched []string
+		for i, sub := range oneOf {
+			if ok, subEv := v.matches(inst, sub, ptr); ok {
+				matched = append(matched, strconv.Itoa(i))
+				ev.merge(subEv)
+			}
+		}
+		switch len(matched) {
+		case 0:
+			v.fail(ptr, "does not match any schema of oneOf")
+		case 1:
+		default:
+			v.fail(ptr, "matches schemas %s of oneOf, expected exactly one", strings.Join(matched, ", "))
+		}
+	}
+
+	if not, ok := s["not"]; ok {
+		if matched, _ := v.matches(inst, not, ptr); matched {
+			v.fail(ptr, "matches the schema of not")
+		}
+	}
+
+	if cond, ok := s["if"]; ok {
+		if matched, condEv := v.matches(inst, cond, ptr); matched {
+			ev.merge(condEv)
+			if then, ok := s["then"]; ok {
+				ev.merge(v.validate(inst, then, ptr))
+			}
+		} else if els, ok := s["else"]; ok {
+			ev.merge(v.validate(inst, els, ptr))
+		}
+	}
+
+	if obj, ok := inst.(map[string]any); ok {
+		deps, _ := s["dependentSchemas"].(map[string]any)
+		for _, name := range sortedKeys(deps) {
+			if _, ok := obj[name]; ok {
+				ev.merge(v.validate(inst, deps[name], ptr))
+			}
+		}
+	}
+	return ev
+}
+
+func (v *schemaValidator) validateNumber(n json.Number, s map[string]any, ptr string) {
+	x, ok := jsonRat(n)
+	if !ok {
+		return
+	}
+
+	if min, ok := v.number(s, "minimum"); ok && x.Cmp(min) < 0 {
+		v.fail(ptr, "%s is less than minimum %s", n, s["minimum"])
+	}
+	if max, ok := v.number(s, "maximum"); ok && x.Cmp(max) > 0 {
+		v.fail(ptr, "%s is greater than maximum %s", n, s["maximum"])
+	}
+	if min, ok := v.number(s, "exclusiveMinimum"); ok && x.Cmp(min) <= 0 {
+		v.fail(ptr, "%s is not greater than exclusiveMinimum %s", n, s["exclusiveMinimum"])
+	}
+	if max, ok := v.number(s, "exclusiveMaximum"); ok && x.Cmp(max) >= 0 {
+		v.fail(ptr, "%s is not less than exclusiveMaximum %s", n, s["exclusiveMaximum"])
+	}
+	if div, ok := v.number(s, "multipleOf"); ok {
+		if div.Sign() <= 0 {
+			v.invalid("multipleOf must be greater than 0")
+		} else if !new(big.Rat).Quo(x, div).IsInt() {
+			v.fail(ptr, "%s is not a multiple of %s", n, s["multipleOf"])
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(str string, s map[string]any, ptr string) {
+	length := utf8.RuneCountInString(str)
+
+	if min, ok := v.count(s, "minLength"); ok && length < min {
+		v.fail(ptr, "length %d is less than minLength %d", length, min)
+	}
+	if max, ok := v.count(s, "maxLength"); ok && length > max {
+		v.fail(ptr, "length %d is greater than maxLength %d", length, max)
+	}
+
+	if pattern, ok := s["pattern"]; ok {
+		re := v.pattern(pattern)
+		if re != nil && !re.MatchString(str) {
+			v.fail(ptr, "%q does not match pattern %q", str, re)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(arr []any, s map[string]any, ptr string) *evaluated {
+	ev := &evaluated{}
+	if min, ok := v.count(s, "minItems"); ok && len(arr) < min {
+		v.fail(ptr, "%d items, fewer than minItems %d", len(arr), min)
+	}
+	if max, ok := v.count(s, "maxItems"); ok && len(arr) > max {
+		v.fail(ptr, "%d items, more than maxItems %d", len(arr), max)
+	}
+
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	outer:
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if equalJSON(arr[i], arr[j]) {
+					v.fail(ptr, "items %d and %d are equal, expected unique items", i, j)
+					break outer
+				}
+			}
+		}
+	}
+
+	prefix := v.schemas(s, "prefixItems")
+	for i, sub := range prefix {
+		if i < len(arr) {
+			v.validate(arr[i], sub, pointerIndex(ptr, i))
+		}
+	}
+	ev.items = minInt(len(prefix), len(arr))
+	if items, ok := s["items"]; ok {
+		for i := len(prefix); i < len(arr); i++ {
+			v.validate(arr[i], items, pointerIndex(ptr, i))
+		}
+		ev.allItems = true
+	}
+
+	if contains, ok := s["contains"]; ok {
+		n := 0
+		for i, item := range arr {
+			if matched, _ := v.matches(item, contains, pointerIndex(ptr, i)); matched {
+				n++
+				if ev.indexes == nil {
+					ev.indexes = map[int]bool{}
+				}
+				ev.indexes[i] = true
+			}
+		}
+		min, ok := v.count(s, "minContains")
+		if !ok {
+			min = 1
+		}
+		if n < min {
+			v.fail(ptr, "%d items match contains, expected at least %d", n, min)
+		}
+		if max, ok := v.count(s, "maxContains"); ok && n > max {
+			v.fail(ptr, "%d items match contains, expected at most %d", n, max)
+		}
+	}
+	return ev
+}
+
+func (v *schemaValidator) validateObject(obj map[string]any, s map[string]any, ptr string) *evaluated {
+	ev := &evaluated{}
+	if min, ok := v.count(s, "minProperties"); ok && len(obj) < min {
+		v.fail(ptr, "%d properties, fewer than minProperties %d", len(obj), min)
+	}
+	if max, ok := v.count(s, "maxProperties"); ok && len(obj) > max {
+		v.fail(ptr, "%d properties, more than maxProperties %d", len(obj), max)
+	}
+
+	for _, name := range v.names(s, "required") {
+		if _, ok := obj[name]; !ok {
+			v.fail(ptr, "missing required property %q", name)
+		}
+	}
+
+	deps, _ := s["dependentRequired"].(map[string]any)
+	for _, name := range sortedKeys(deps) {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+		for _, dep := range v.names(deps, name) {
+			if _, ok := obj[dep]; !ok {
+				v.fail(ptr, "property %q requires property %q", name, dep)
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	patterns, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	names, hasNames := s["propertyNames"]
+
+	for _, name := range sortedKeys(obj) {
+		value, p := obj[name], pointerKey(ptr, name)
+
+		if hasNames {
+			if matched, _ := v.matches(name, names, p); !matched {
+				v.fail(p, "property name %q does not match propertyNames", name)
+			}
+		}
+
+		sub, known := properties[name]
+		if known {
+			v.validate(value, sub, p)
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			if re := v.pattern(pattern); re != nil && re.MatchString(name) {
+				known = true
+				v.validate(value, patterns[pattern], p)
+			}
+		}
+
+		if !known && hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				v.fail(ptr, "unexpected property %q", name)
+			} else {
+				v.validate(value, additional, p)
+			}
+			known = true
+		}
+		if known {
+			ev.property(name)
+		}
+	}
+	return ev
+}
+
+// schemas returns the array of subschemas of keyword kw.
+func (v *schemaValidator) schemas(s map[string]any, kw string) []any {
+	value, ok := s[kw]
+	if !ok {
+		return nil
+	}
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		v.invalid("%s must be a non-empty array", kw)
+		return nil
+	}
+	return list
+}
+
+// names returns the array of strings of keyword kw.
+func (v *schemaValidator) names(s map[string]any, kw string) []string {
+	value, ok := s[kw]
+	if !ok {
+		return nil
+	}
+	list, ok := value.([]any)
+	if !ok {
+		v.invalid("%s must be an array of strings", kw)
+		return nil
+	}
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		name, ok := item.(string)
+		if !ok {
+			v.invalid("%s must be an array of strings", kw)
+			return nil
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// number returns the numeric value of keyword kw, if present.
+func (v *schemaValidator) number(s map[string]any, kw string) (*big.Rat, bool) {
+	value, ok := s[kw]
+	if !ok {
+		return nil, false
+	}
+	n, ok := value.(json.Number)
+	if !ok {
+		v.invalid("%s must be a number", kw)
+		return nil, false
+	}
+	return jsonRat(n)
+}
+
+// count returns the non-negative integer value of keyword kw, if present.
+func (v *schemaValidator) count(s map[string]any, kw string) (int, bool) {
+	value, ok := s[kw]
+	if !ok {
+		return 0, false
+	}
+	n, ok := value.(json.Number)
+	if ok {
+		r, ok := jsonRat(n)
+		if ok && r.IsInt() && r.Sign() >= 0 && r.Num().IsInt64() {
+			return int(r.Num().Int64()), true
+		}
+	}
+	v.invalid("%s must be a non-negative integer", kw)
+	return 0, false
+}
+
+// pattern returns the compiled regular expression of a pattern.
+func (v *schemaValidator) pattern(value any) *regexp.Regexp {
+	pattern, ok := value.(string)
+	if !ok {
+		v.invalid("pattern must be a string")
+		return nil
+	}
+	if re, ok := v.patterns[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		v.invalid("invalid pattern %q: %v", pattern, err)
+		return nil
+	}
+	if v.patterns == nil {
+		v.patterns = map[string]*regexp.Regexp{}
+	}
+	v.patterns[pattern] = re
+	return re
+}
+
+// equalJSON reports whether two decoded JSON values are equal,
+// with numbers compared by value.
+func equalJSON(a, b any) bool {
+	return len(diffJSON(a, b, "", &options{})) == 0
+}
+
+// jsonRat returns the exact value of a JSON number.
+func jsonRat(n json.Number) (*big.Rat, bool) {
+	return new(big.Rat).SetString(n.String())
+}
+
+// isInteger reports whether a JSON number has an integral value,
+// such as 1 or 1.0.
+func isInteger(n json.Number) bool {
+	r, ok := jsonRat(n)
+	return ok && r.IsInt()
+}
+
+// sortedKeys returns the keys of an object in order.
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pointerEscaper escapes the reference tokens of a JSON pointer.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointerKey appends an object key to a JSON pointer.
+func pointerKey(ptr, key string) string {
+	return ptr + "/" + pointerEscaper.Replace(key)
+}
+
+// pointerIndex appends an array index to a JSON pointer.
+func pointerIndex(ptr string, i int) string {
+	return ptr + "/" + strconv.Itoa(i)
+}
+
+// resolvePointer returns the value at a JSON pointer, given as
+// the fragment of a URI, such as "/$defs/user".
+func resolvePointer(root any, fragment string) (any, error) {
+	ptr, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, err
+	}
+	if ptr == "" {
+		return root, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, errors.New("anchors are not supported")
+	}
+
+	v := root
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("no index %q", token)
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("no member %q in %s", token, jsonKind(v))
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const userSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["id", "name"],
+	"properties": {
+		"id":    {"type": "integer", "minimum": 1},
+		"name":  {"type": "string", "minLength": 1, "maxLength": 8},
+		"email": {"type": ["string", "null"], "pattern": "^[^@]+@[^@]+$"},
+		"role":  {"enum": ["admin", "user"]},
+		"tags":  {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+		"manager": {"$ref": "#/$defs/ref"},
+		"a/b":   {"const": 1}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"ref": {"type": "object", "required": ["id"], "properties": {"id": {"$ref": "#/properties/id"}}}
+	}
+}`
+
+func TestMatchesJSONSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		schema    string
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name:     "valid document",
+			document: `{"id": 1.0, "name": "ann", "email": null, "role": "admin", "tags": ["a", "b"], "manager": {"id": 2}, "a/b": 1.0}`,
+			schema:   userSchema,
+		},
+		{
+			name:      "wrong types",
+			document:  `{"id": 1.5, "name": "ann", "tags": ["a", 2]}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  "/id: expected integer, got number; /tags/1: expected string, got number",
+		},
+		{
+			name:      "required and additional properties",
+			document:  `{"name": "ann", "extra": true}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  `(root): missing required property "id"; (root): unexpected property "extra"`,
+		},
+		{
+			name:      "string constraints",
+			document:  `{"id": 1, "name": "annabella", "email": "ann"}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  `/email: "ann" does not match pattern "^[^@]+@[^@]+$"; /name: length 9 is greater than maxLength 8`,
+		},
+		{
+			name:      "enum and const",
+			document:  `{"id": 1, "name": "ann", "role": "root", "a/b": 2}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  `/a~1b: expected 1, got 2; /role: "root" is not one of ["admin","user"]`,
+		},
+		{
+			name:      "references",
+			document:  `{"id": 1, "name": "ann", "manager": {"id": 0}}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  "/manager/id: 0 is less than minimum 1",
+		},
+		{
+			name:      "array constraints",
+			document:  `{"id": 1, "name": "ann", "tags": ["a", "b", "a", "c"]}`,
+			schema:    userSchema,
+			wantError: true,
+			wantNote:  "/tags: 4 items, more than maxItems 3; /tags: items 0 and 2 are equal, expected unique items",
+		},
+		{
+			name:      "numbers",
+			document:  `[10, 0.3, 5]`,
+			schema:    `{"prefixItems": [{"exclusiveMaximum": 10}, {"multipleOf": 0.1}, {"maximum": 4}]}`,
+			wantError: true,
+			wantNote:  "/0: 10 is not less than exclusiveMaximum 10; /2: 5 is greater than maximum 4",
+		},
+		{
+			name:      "items after prefix items",
+			document:  `[1, "a", "b", 3]`,
+			schema:    `{"prefixItems": [{"type": "integer"}], "items": {"type": "string"}}`,
+			wantError: true,
+			wantNote:  "/3: expected string, got number",
+		},
+		{
+			name:      "contains",
+			document:  `[1, 2, 3]`,
+			schema:    `{"contains": {"minimum": 2}, "maxContains": 1}`,
+			wantError: true,
+			wantNote:  "(root): 2 items match contains, expected at most 1",
+		},
+		{
+			name:      "anyOf",
+			document:  `true`,
+			schema:    `{"anyOf": [{"type": "string"}, {"type": "number"}]}`,
+			wantError: true,
+			wantNote:  "(root): does not match any schema of anyOf",
+		},
+		{
+			name:      "oneOf",
+			document:  `5`,
+			schema:    `{"oneOf": [{"type": "integer"}, {"minimum": 1}]}`,
+			wantError: true,
+			wantNote:  "(root): matches schemas 0, 1 of oneOf, expected exactly one",
+		},
+		{
+			name:      "not",
+			document:  `"x"`,
+			schema:    `{"not": {"type": "string"}}`,
+			wantError: true,
+			wantNote:  "(root): matches the schema of not",
+		},
+		{
+			name:      "if then else",
+			document:  `[{"kind": "card", "number": "1"}, {"kind": "cash"}]`,
+			schema:    `{"items": {"if": {"properties": {"kind": {"const": "card"}}}, "then": {"required": ["number"]}, "else": {"required": ["amount"]}}}`,
+			wantError: true,
+			wantNote:  `/1: missing required property "amount"`,
+		},
+		{
+			name:      "dependencies",
+			document:  `{"card": "1"}`,
+			schema:    `{"dependentRequired": {"card": ["cvv"]}, "dependentSchemas": {"card": {"maxProperties": 0}}}`,
+			wantError: true,
+			wantNote:  `(root): 1 properties, more than maxProperties 0; (root): property "card" requires property "cvv"`,
+		},
+		{
+			name:      "pattern properties and property names",
+			document:  `{"x-id": 1, "Name": "a"}`,
+			schema:    `{"patternProperties": {"^x-": {"type": "string"}}, "propertyNames": {"pattern": "^[a-z-]+$"}}`,
+			wantError: true,
+			wantNote:  `/Name: property name "Name" does not match propertyNames; /x-id: expected string, got number`,
+		},
+		{
+			name:      "false schema",
+			document:  `[1, 2]`,
+			schema:    `{"prefixItems": [true], "items": false}`,
+			wantError: true,
+			wantNote:  "/1: not allowed by schema",
+		},
+		{
+			name:      "unevaluated properties",
+			document:  `{"a": 1, "b": 2}`,
+			schema:    `{"properties": {"a": {}}, "unevaluatedProperties": false}`,
+			wantError: true,
+			wantNote:  `(root): unexpected property "b"`,
+		},
+		{
+			name:     "unevaluated properties through applicators",
+			document: `{"a": 1, "b": 2, "c": 3}`,
+			schema: `{
+				"$defs": {"b": {"properties": {"b": {"type": "integer"}}}},
+				"allOf": [{"properties": {"a": {}}}, {"$ref": "#/$defs/b"}],
+				"anyOf": [{"required": ["x"]}, {"properties": {"c": {}}}],
+				"unevaluatedProperties": false
+			}`,
+		},
+		{
+			name:      "unevaluated properties of a failed branch",
+			document:  `{"a": 1}`,
+			schema:    `{"anyOf": [{"properties": {"a": {"type": "string"}}}, true], "unevaluatedProperties": false}`,
+			wantError: true,
+			wantNote:  `(root): unexpected property "a"`,
+		},
+		{
+			name:      "unevaluated items",
+			document:  `[1, "a", 2]`,
+			schema:    `{"prefixItems": [{"type": "integer"}], "contains": {"type": "string"}, "unevaluatedItems": false}`,
+			wantError: true,
+			wantNote:  "(root): unexpected item 2",
+		},
+		{
+			name:      "unevaluated items schema",
+			document:  `[1, "a"]`,
+			schema:    `{"allOf": [{"prefixItems": [true]}], "unevaluatedItems": {"type": "integer"}}`,
+			wantError: true,
+			wantNote:  "/1: expected integer, got string",
+		},
+		{
+			name:      "dynamic reference",
+			document:  `{}`,
+			schema:    `{"$dynamicRef": "#node"}`,
+			wantError: true,
+			wantNote:  "invalid schema: unsupported $dynamicRef",
+		},
+		{
+			name:      "remote reference",
+			document:  `{}`,
+			schema:    `{"$ref": "https://example.com/user.json"}`,
+			wantError: true,
+			wantNote:  "invalid schema: unsupported $ref",
+		},
+		{
+			name:      "cyclic reference",
+			document:  `{}`,
+			schema:    `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+			wantError: true,
+			wantNote:  "the schema may be cyclic",
+		},
+		{
+			name:      "invalid keyword",
+			document:  `"a"`,
+			schema:    `{"minLength": -1}`,
+			wantError: true,
+			wantNote:  "invalid schema: minLength must be a non-negative integer",
+		},
+		{
+			name:      "invalid document",
+			document:  `{`,
+			schema:    `{}`,
+			wantError: true,
+			wantNote:  "invalid document JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			MatchesJSONSchema(rec, tt.document, tt.schema)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("MatchesJSONSchema() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("MatchesJSONSchema() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestResolvePointer(t *testing.T) {
+	root, _ := readJSON(`{"a/b": [0, {"~c": "x"}], "%": 1}`)
+
+	tests := []struct {
+		fragment string
+		want     string
+		wantErr  bool
+	}{
+		{"", `{"%":1,"a/b":[0,{"~c":"x"}]}`, false},
+		{"/a~1b/1/~0c", `"x"`, false},
+		{"/%25", "1", false},
+		{"/a~1b/2", "", true},
+		{"/missing", "", true},
+		{"anchor", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolvePointer(root, tt.fragment)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolvePointer(%q) error = %v, want error %v", tt.fragment, err, tt.wantErr)
+			continue
+		}
+		if err == nil && formatJSON(got) != tt.want {
+			t.Errorf("resolvePointer(%q) = %s, want %s", tt.fragment, formatJSON(got), tt.want)
+		}
+	}
+}
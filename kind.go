@@ -0,0 +1,68 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// IsFunc checks if a value is a function.
+func IsFunc(t testing.TB, value any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkKind(t, value, reflect.Func, msg)
+}
+
+// IsMap checks if a value is a map.
+func IsMap(t testing.TB, value any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkKind(t, value, reflect.Map, msg)
+}
+
+// IsPointer checks if a value is a pointer.
+func IsPointer(t testing.TB, value any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkKind(t, value, reflect.Ptr, msg)
+}
+
+// IsSlice checks if a value is a slice.
+// It is useful on values returned as any, before drilling further:
+//
+//	v := registry.Lookup("handlers")
+//	assert.IsSlice(t, v)
+func IsSlice(t testing.TB, value any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkKind(t, value, reflect.Slice, msg)
+}
+
+// IsStruct checks if a value is a struct.
+func IsStruct(t testing.TB, value any, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkKind(t, value, reflect.Struct, msg)
+}
+
+// checkKind reports a failure showing the actual kind and type of value
+// when it is not of the expected kind.
+func checkKind(t testing.TB, value any, kind reflect.Kind, msg []string) {
+	t.Helper()
+
+	if actual := reflect.ValueOf(value).Kind(); actual != kind {
+		failCompare(t,
+			fmt.Sprintf("%s (%s)", actual, typeName(value)),
+			kind.String(),
+			withMessage("unexpected kind", msg)...,
+		)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKindAssertions(t *testing.T) {
+	type point struct{ X, Y int }
+	value := 42
+
+	assertions := map[string]func(testing.TB, any, ...string){
+		"IsFunc":    IsFunc,
+		"IsMap":     IsMap,
+		"IsPointer": IsPointer,
+		"IsSlice":   IsSlice,
+		"IsStruct":  IsStruct,
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"func", func() {}, "IsFunc"},
+		{"nil func", (func())(nil), "IsFunc"},
+		{"map", map[string]int{}, "IsMap"},
+		{"pointer", &value, "IsPointer"},
+		{"nil pointer", (*point)(nil), "IsPointer"},
+		{"slice", []string{"a"}, "IsSlice"},
+		{"struct", point{}, "IsStruct"},
+		{"int", 42, ""},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		for name, assertion := range assertions {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				rec := NewTestRecorder(t)
+
+				assertion(rec, tt.value)
+
+				if wantError := name != tt.want; wantError != rec.HasError() {
+					t.Errorf("%s() error = %v, want %v", name, rec.HasError(), wantError)
+				}
+			})
+		}
+	}
+}
+
+func TestKindMessage(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	IsSlice(rec, map[string]int{}, "handlers")
+
+	for _, part := range []string{"Message: handlers: unexpected kind", `"slice"`, `"map (map[string]int)"`} {
+		if !strings.Contains(rec.ErrorMessage(), part) {
+			t.Errorf("IsSlice() message missing %q\ngot: %s", part, rec.ErrorMessage())
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// labelSeparator separates the labels of a failure.
+const labelSeparator = " > "
+
+// WithLabel returns a copy of a prefixing every failure with label,
+// after the labels already set on a, as breadcrumbs of the context
+// of the assertions:
+//
+//	for i := 1; i <= 3; i++ {
+//	    a := a.WithLabel(fmt.Sprintf("after retry %d", i))
+//	    assert.Equal(a, client.Status(), "ready")
+//	}
+//
+// The labels are shown on the first line of the failure message,
+// and inherited by subtests.
+func (a *Assert) WithLabel(label string) *Assert {
+	child := *a
+	child.labels = append(append([]string{}, a.labels...), label)
+	child.scoped = false
+	return &child
+}
+
+// Scope runs fn with an Assert prefixing its failures with path, the
+// logical path of the value examined by fn. The paths of nested scopes
+// are joined, so that failures show where they occurred:
+//
+//	assert.Scope(t, "response.user", func(a *assert.Assert) {
+//	    assert.Equal(a, user.Name, "alice")
+//	    assert.Scope(a, "roles[0]", func(a *assert.Assert) {
+//	        assert.Equal(a, user.Roles[0].Name, "admin") // response.user.roles[0]
+//	    })
+//	})
+//
+// Paths starting with "[" are joined without a dot.
+func Scope(t testing.TB, path string, fn func(a *Assert)) {
+	t.Helper()
+
+	a := &Assert{TB: t}
+	if parent, ok := t.(*Assert); ok {
+		child := *parent
+		a = &child
+	}
+
+	n := len(a.labels)
+	switch {
+	case !a.scoped:
+		a.labels = append(append([]string{}, a.labels...), path)
+	case strings.HasPrefix(path, "["):
+		a.labels = append(append([]string{}, a.labels[:n-1]...), a.labels[n-1]+path)
+	default:
+		a.labels = append(append([]string{}, a.labels[:n-1]...), a.labels[n-1]+"."+path)
+	}
+	a.scoped = true
+
+	fn(a)
+}
+
+// Error prefixes args with the labels of a before reporting them,
+// stopping the test when a was created by Require.
+func (a *Assert) Error(args ...any) {
+	a.TB.Helper()
+	tb, s := a.label(fmt.Sprint(args...))
+	a.fail(tb, s)
+}
+
+// Errorf prefixes the formatted message with the labels of a
+// before reporting it, stopping the test when a was created by Require.
+func (a *Assert) Errorf(format string, args ...any) {
+	a.TB.Helper()
+	tb, s := a.label(fmt.Sprintf(format, args...))
+	a.fail(tb, s)
+}
+
+// Fatal prefixes args with the labels of a before reporting them
+// and stopping the test.
+func (a *Assert) Fatal(args ...any) {
+	a.TB.Helper()
+	tb, s := a.label(fmt.Sprint(args...))
+	tb.Fatal(s)
+}
+
+// Fatalf prefixes the formatted message with the labels of a
+// before reporting it and stopping the test.
+func (a *Assert) Fatalf(format string, args ...any) {
+	a.TB.Helper()
+	tb, s := a.label(fmt.Sprintf(format, args...))
+	tb.Fatal(s)
+}
+
+// label prefixes a failure message with the labels of a, including
+// those of the Assert it wraps, and returns the TB to report it to.
+// Messages starting on a new line keep the labels on their first line.
+// The location of the failure comes first when a caller skip is set.
+// The failure is recorded for the trace and summary of the running
+// assertion, and collapsed on a single line when summarized.
+func (a *Assert) label(s string) (testing.TB, string) {
+	if tr := lookupTracer(a); tr != nil {
+		tr.fail()
+	}
+	if sm := lookupSummary(a); sm != nil {
+		sm.fail()
+		s = collapseFailure(s)
+	}
+
+	tb, labels := a.TB, a.labels
+	for {
+		inner, ok := tb.(*Assert)
+		if !ok {
+			break
+		}
+		labels = append(append([]string{}, inner.labels...), labels...)
+		tb = inner.TB
+	}
+
+	prefix := strings.Join(labels, labelSeparator)
+	if lookupCallerSkip(a) > 0 {
+		if location := callerLocation(a); location != "" {
+			prefix = strings.TrimSuffix(location+": "+prefix, ": ")
+		}
+	}
+	if prefix == "" {
+		return tb, s
+	}
+	if strings.HasPrefix(s, "\n") {
+		return tb, prefix + s
+	}
+	return tb, prefix + ": " + s
+}
+
+// Label prefixes the message of a single assertion with labels,
+// like Assert.WithLabel:
+//
+//	assert.JSONEq(t, body, want, assert.Label("user 42", "after update"))
+func Label(labels ...string) Option {
+	return func(o *options) {
+		o.labels = append(o.labels, labels...)
+	}
+}
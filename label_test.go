@@ -0,0 +1,160 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssertWithLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(a *Assert)
+		want   string
+	}{
+		{
+			name:   "failure message",
+			assert: func(a *Assert) { Equal(a.WithLabel("after retry 3"), 1, 2) },
+			want:   "after retry 3\n",
+		},
+		{
+			name:   "breadcrumbs",
+			assert: func(a *Assert) { True(a.WithLabel("scenario").WithLabel("step 2"), false) },
+			want:   "scenario > step 2\n",
+		},
+		{
+			name:   "nested Assert",
+			assert: func(a *Assert) { Equal(New(a.WithLabel("outer")).WithLabel("inner"), 1, 2) },
+			want:   "outer > inner\n",
+		},
+		{
+			name:   "single line message",
+			assert: func(a *Assert) { a.WithLabel("row 1").Errorf("bad %d", 1) },
+			want:   "row 1: bad 1",
+		},
+		{
+			name:   "without labels",
+			assert: func(a *Assert) { a.Error("bad") },
+			want:   "bad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(New(rec))
+
+			if !strings.HasPrefix(rec.ErrorMessage(), tt.want) {
+				t.Errorf("failure message = %q, want prefix %q", rec.ErrorMessage(), tt.want)
+			}
+		})
+	}
+
+	t.Run("copies the labels", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec).WithLabel("base")
+
+		a.WithLabel("first")
+		Equal(a.WithLabel("second"), 1, 2)
+
+		if !strings.HasPrefix(rec.ErrorMessage(), "base > second\n") {
+			t.Errorf("failure message = %q, want base > second", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("fatal", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { New(rec).WithLabel("setup").Fatalf("\nfailed") })
+
+		Equal(t, rec.ErrorMessage(), "setup\nfailed")
+	})
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"labels", []Option{Label("user 42", "after update")}, "Message: user 42 > after update: JSON documents differ\n"},
+		{"with message", []Option{Message("body"), Label("user 42")}, "Message: user 42: body: JSON documents"},
+		{"message only", []Option{Message("body")}, "Message: body: JSON documents"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			JSONEq(rec, `1`, `2`, tt.opts...)
+
+			if !strings.Contains(rec.ErrorMessage(), tt.want) {
+				t.Errorf("JSONEq() message missing %q\ngot: %s", tt.want, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		want   string
+	}{
+		{
+			name: "path",
+			assert: func(t testing.TB) {
+				Scope(t, "response.user", func(a *Assert) { Equal(a, "bob", "alice") })
+			},
+			want: "response.user\n",
+		},
+		{
+			name: "nested paths",
+			assert: func(t testing.TB) {
+				Scope(t, "response", func(a *Assert) {
+					Scope(a, "users", func(a *Assert) {
+						Scope(a, "[0]", func(a *Assert) { True(a, false) })
+					})
+				})
+			},
+			want: "response.users[0]\n",
+		},
+		{
+			name: "labels",
+			assert: func(t testing.TB) {
+				a := New(t).WithLabel("after retry 3")
+				Scope(a, "response", func(a *Assert) {
+					Scope(a.WithLabel("admin"), "user", func(a *Assert) { True(a, false) })
+				})
+			},
+			want: "after retry 3 > response > admin > user\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			if !strings.HasPrefix(rec.ErrorMessage(), tt.want) {
+				t.Errorf("failure message = %q, want prefix %q", rec.ErrorMessage(), tt.want)
+			}
+		})
+	}
+
+	t.Run("scope ends with fn", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+
+		Scope(a, "response", func(a *Assert) {})
+		Equal(a, 1, 2)
+
+		if !strings.HasPrefix(rec.ErrorMessage(), "\n") {
+			t.Errorf("failure message = %q, want no path", rec.ErrorMessage())
+		}
+	})
+}
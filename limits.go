@@ -0,0 +1,233 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MaxDepth makes EqualWith show values down to depth levels of fields,
+// elements and map entries in the failure message, for values holding
+// large nested structures such as caches. The values are still compared
+// in full, and the failure notes the path of the first difference, which
+// may be beyond the levels shown:
+//
+//	assert.EqualWith(t, got, want, assert.MaxDepth(3), assert.MaxElements(100))
+//	// Note: first difference at .Cache.Entries["a"][0].Value
+func MaxDepth(depth int) Option {
+	return func(o *options) {
+		o.equal.maxDepth = depth
+	}
+}
+
+// MaxElements makes EqualWith show at most n elements of each array, slice
+// and map in the failure message, the first ones in key order for maps.
+// The values are still compared in full, and the failure notes the path
+// of the first difference.
+func MaxElements(n int) Option {
+	return func(o *options) {
+		o.equal.maxElements = n
+	}
+}
+
+// limited reports whether the failure output is limited by MaxDepth or
+// MaxElements.
+func (e *equalizer) limited() bool {
+	return e.maxDepth > 0 || e.maxElements > 0
+}
+
+// mismatch records the path being compared as the first difference, when
+// limited. It is called from the innermost level out, so the deepest path
+// is kept.
+func (e *equalizer) mismatch() {
+	if e.limited() && e.diffPath == "" {
+		e.diffPath = e.location()
+	}
+}
+
+// diffNote describes where the values first differ, if below the root.
+func (e *equalizer) diffNote() string {
+	if e.diffPath == "" {
+		return ""
+	}
+	return "first difference at " + e.diffPath
+}
+
+// location returns the path of the values being compared.
+func (e *equalizer) location() string {
+	if len(e.path) == 0 {
+		return "value"
+	}
+	return strings.Join(e.path, "")
+}
+
+// enterIndex, enterKey and enterField descend into an element, a map entry
+// or a field of the compared values, and leave returns from it, recording
+// the path when limited.
+func (e *equalizer) enterIndex(i int) {
+	if e.limited() {
+		e.path = append(e.path, fmt.Sprintf("[%d]", i))
+	}
+}
+
+func (e *equalizer) enterKey(k reflect.Value) {
+	if e.limited() {
+		e.path = append(e.path, fmt.Sprintf("[%#v]", k))
+	}
+}
+
+func (e *equalizer) enterField(name string) {
+	if e.limited() {
+		e.path = append(e.path, "."+name)
+	}
+}
+
+func (e *equalizer) leave() {
+	if e.limited() {
+		e.path = e.path[:len(e.path)-1]
+	}
+}
+
+// mapEntryEqual reports whether the values xv and yv of the key k are
+// equal, yv being invalid when k is missing.
+func (e *equalizer) mapEntryEqual(k, xv, yv reflect.Value) bool {
+	e.enterKey(k)
+	defer e.leave()
+
+	ok := yv.IsValid() && e.deepEqual(xv, yv)
+	if !ok {
+		e.mismatch()
+	}
+	return ok
+}
+
+// sortedMapKeys returns the keys of the map m in order: natural order for
+// numbers, strings and booleans, and formatted order otherwise.
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+	return keys
+}
+
+func keyLess(x, y reflect.Value) bool {
+	switch x.Kind() {
+	case reflect.String:
+		return x.String() < y.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.Int() < y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.Uint() < y.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.Float() < y.Float()
+	case reflect.Bool:
+		return !x.Bool() && y.Bool()
+	}
+	return fmt.Sprintf("%#v", x) < fmt.Sprintf("%#v", y)
+}
+
+// truncated is a compared value formatted within the limits of an
+// equalizer, in place of the %#v verb.
+type truncated struct {
+	value       any
+	maxDepth    int
+	maxElements int
+}
+
+// truncate returns value formatted within the limits of e.
+func (e *equalizer) truncate(value any) truncated {
+	return truncated{value: value, maxDepth: e.maxDepth, maxElements: e.maxElements}
+}
+
+// String formats the value like the %#v verb, showing "..." for the levels
+// and elements beyond the limits. Nested pointers are shown as addresses,
+// as with %#v.
+func (v truncated) String() string {
+	var b strings.Builder
+	v.format(&b, reflect.ValueOf(v.value), 0)
+	return b.String()
+}
+
+func (v truncated) format(b *strings.Builder, x reflect.Value, depth int) {
+	if !x.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+
+	switch x.Kind() {
+	case reflect.Ptr:
+		if x.IsNil() {
+			fmt.Fprintf(b, "%#v", x)
+			return
+		}
+		if depth > 0 {
+			fmt.Fprintf(b, "(%s)(%#x)", x.Type(), x.Pointer())
+			return
+		}
+		b.WriteByte('&')
+		v.format(b, x.Elem(), depth)
+		return
+	case reflect.Interface:
+		if x.IsNil() {
+			fmt.Fprintf(b, "%#v", x)
+			return
+		}
+		v.format(b, x.Elem(), depth)
+		return
+	case reflect.Slice, reflect.Map:
+		if x.IsNil() {
+			fmt.Fprintf(b, "%#v", x)
+			return
+		}
+	case reflect.Array, reflect.Struct:
+	default:
+		fmt.Fprintf(b, "%#v", x)
+		return
+	}
+
+	b.WriteString(x.Type().String() + "{")
+	if v.maxDepth > 0 && depth >= v.maxDepth {
+		b.WriteString("...}")
+		return
+	}
+
+	if x.Kind() == reflect.Struct {
+		for i := 0; i < x.NumField(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(x.Type().Field(i).Name + ":")
+			v.format(b, x.Field(i), depth+1)
+		}
+		b.WriteByte('}')
+		return
+	}
+
+	n := x.Len()
+	if v.maxElements > 0 && n > v.maxElements {
+		n = v.maxElements
+	}
+	var keys []reflect.Value
+	if x.Kind() == reflect.Map {
+		keys = sortedMapKeys(x)
+	}
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if keys != nil {
+			v.format(b, keys[i], depth+1)
+			b.WriteByte(':')
+			v.format(b, x.MapIndex(keys[i]), depth+1)
+		} else {
+			v.format(b, x.Index(i), depth+1)
+		}
+	}
+	if n < x.Len() {
+		fmt.Fprintf(b, ", ... (%d more)", x.Len()-n)
+	}
+	b.WriteByte('}')
+}
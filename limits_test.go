@@ -0,0 +1,165 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type cacheEntry struct {
+	Value string
+	Next  *cacheEntry
+}
+
+type cache struct {
+	Name    string
+	Entries map[string][]cacheEntry
+	Size    int
+}
+
+func TestEqualWithLimits(t *testing.T) {
+	big := func(last int) []int {
+		s := make([]int, 1000)
+		s[len(s)-1] = last
+		return s
+	}
+	nested := func(value string) cache {
+		return cache{Name: "c", Entries: map[string][]cacheEntry{"a": {{Value: value}}}}
+	}
+
+	tests := []struct {
+		name      string
+		actual    any
+		expected  any
+		opts      []Option
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "difference beyond the depth",
+			actual:    nested("x"),
+			expected:  nested("y"),
+			opts:      []Option{MaxDepth(2)},
+			wantError: true,
+			wantParts: []string{
+				`Note: first difference at .Entries["a"][0].Value`,
+				`Entries:map[string][]assert.cacheEntry{"a":[]assert.cacheEntry{...}}`,
+			},
+		},
+		{
+			name:      "difference within the depth",
+			actual:    cache{Name: "a"},
+			expected:  cache{Name: "b"},
+			opts:      []Option{MaxDepth(2)},
+			wantError: true,
+			wantParts: []string{
+				`assert.cache{Name:"a", Entries:map[string][]assert.cacheEntry(nil), Size:0}`,
+				"Note: first difference at .Name",
+			},
+		},
+		{
+			name:     "equal beyond the depth",
+			actual:   nested("x"),
+			expected: nested("x"),
+			opts:     []Option{MaxDepth(1)},
+		},
+		{
+			name:      "difference beyond the elements",
+			actual:    big(1),
+			expected:  big(2),
+			opts:      []Option{MaxElements(10)},
+			wantError: true,
+			wantParts: []string{"Note: first difference at [999]", "[]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, ... (990 more)}"},
+		},
+		{
+			name:      "last element differs",
+			actual:    []int{1, 2, 3},
+			expected:  []int{1, 2, 4},
+			opts:      []Option{MaxElements(2)},
+			wantError: true,
+			wantParts: []string{"Note: first difference at [2]"},
+		},
+		{
+			name:      "lengths differ",
+			actual:    []int{1, 2, 3},
+			expected:  []int{1, 2},
+			opts:      []Option{MaxElements(1)},
+			wantError: true,
+			wantParts: []string{"Expected: ([]int) []int{1, ... (1 more)}", "Actual: ([]int) []int{1, ... (2 more)}"},
+		},
+		{
+			name:      "map entry shown",
+			actual:    map[string]int{"a": 1, "b": 2, "c": 3},
+			expected:  map[string]int{"a": 0, "b": 2, "c": 3},
+			opts:      []Option{MaxElements(2)},
+			wantError: true,
+			wantParts: []string{`Expected: (map[string]int) map[string]int{"a":0, "b":2, ... (1 more)}`, `first difference at ["a"]`},
+		},
+		{
+			name:      "map entry beyond the elements",
+			actual:    map[string]int{"a": 1, "b": 2, "c": 3},
+			expected:  map[string]int{"a": 1, "b": 2, "c": 4},
+			opts:      []Option{MaxElements(1)},
+			wantError: true,
+			wantParts: []string{`first difference at ["c"]`},
+		},
+		{
+			name:      "missing map key",
+			actual:    map[string]int{"a": 1, "b": 2},
+			expected:  map[string]int{"a": 1, "c": 2},
+			opts:      []Option{MaxElements(1)},
+			wantError: true,
+			wantParts: []string{`first difference at ["b"]`},
+		},
+		{
+			name:     "equal beyond the elements",
+			actual:   [][]int{big(0), big(1)},
+			expected: [][]int{big(0), big(1)},
+			opts:     []Option{MaxElements(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			EqualWith(rec, tt.actual, tt.expected, tt.opts...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualWith() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestTruncatedFormat(t *testing.T) {
+	entry := &cacheEntry{Value: "v", Next: &cacheEntry{}}
+	var nilMap map[string]int
+
+	tests := []struct {
+		name  string
+		value truncated
+		want  string
+	}{
+		{"scalar", truncated{value: 42}, "42"},
+		{"nil", truncated{value: nil}, "<nil>"},
+		{"nil map", truncated{value: nilMap}, "map[string]int(nil)"},
+		{"pointer", truncated{value: entry}, `&assert.cacheEntry{Value:"v", Next:(*assert.cacheEntry)(0x`},
+		{"slice", truncated{value: []string{"a", "b", "c"}, maxElements: 2}, `[]string{"a", "b", ... (1 more)}`},
+		{"depth", truncated{value: [][]int{{1}}, maxDepth: 1}, "[][]int{[]int{...}}"},
+		{"interface", truncated{value: []any{1, "a"}}, `[]interface {}{1, "a"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			HasPrefix(t, tt.value.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// LocationFormat sets how the locations of failures are formatted, such as
+// the Location of failure templates and the line prefixed by WithCallerSkip.
+// Its flags are combined with |, and its zero value formats locations as the
+// testing package does, such as "user_test.go:42". Other formats add a
+// Location line to the default layout of failures, except LocationHidden.
+// The location the testing package prefixes to every failure is kept.
+//
+// Go records the lines of the code it runs, but not their columns, so the
+// locations have no column.
+type LocationFormat uint8
+
+const (
+	// LocationRelative shows the path of the file relative to the working
+	// directory, the directory of the package under test.
+	LocationRelative LocationFormat = 1 << iota
+	// LocationAbsolute shows the absolute path of the file.
+	LocationAbsolute
+	// LocationFunc adds the name of the function, such as
+	// "user_test.go:42 (user.TestCreate)".
+	LocationFunc
+	// LocationHidden suppresses the locations.
+	LocationHidden
+)
+
+var (
+	locationFormatMu sync.RWMutex
+	locationFormat   LocationFormat
+)
+
+// SetLocationFormat sets the format of the locations of failures of all
+// tests, and returns the previous format. For editors and CI systems
+// linking failures to absolute paths:
+//
+//	assert.SetLocationFormat(assert.LocationAbsolute | assert.LocationFunc)
+//
+// Assert.SetLocationFormat sets the format of a single Assert.
+func SetLocationFormat(f LocationFormat) LocationFormat {
+	locationFormatMu.Lock()
+	defer locationFormatMu.Unlock()
+
+	prev := locationFormat
+	locationFormat = f
+	return prev
+}
+
+// SetLocationFormat sets the format of the locations of the failures of
+// the assertions made through a, and the Assert of its subtests, like the
+// package-level SetLocationFormat.
+func (a *Assert) SetLocationFormat(f LocationFormat) {
+	a.locationFormat = &f
+}
+
+// lookupLocationFormat returns the format of the locations of the failures
+// reported to t.
+func lookupLocationFormat(t testing.TB) LocationFormat {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			break
+		}
+		if a.locationFormat != nil {
+			return *a.locationFormat
+		}
+		t = a.TB
+	}
+
+	locationFormatMu.RLock()
+	defer locationFormatMu.RUnlock()
+
+	return locationFormat
+}
+
+// packageDir is the directory of the source files of this package.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerLocation returns the location of the first caller outside of this
+// package, like the location reported by the testing package, or of the
+// caller skipped frames above it, see WithCallerSkip. It is formatted with
+// the location format of t, and empty when hidden.
+func callerLocation(t testing.TB) string {
+	format := lookupLocationFormat(t)
+	if format&LocationHidden != 0 {
+		return ""
+	}
+	frame, ok := callerFrame(t)
+	if !ok {
+		return ""
+	}
+	return formatLocation(frame, format)
+}
+
+// callerFrame returns the frame of the caller located by callerLocation.
+func callerFrame(t testing.TB) (runtime.Frame, bool) {
+	skip := lookupCallerSkip(t)
+
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.File, packageDir+"/") &&
+			!strings.HasSuffix(frame.File, "_test.go")
+		if !internal && frame.File != "" && skip == 0 {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+		if !internal && frame.File != "" {
+			skip--
+		}
+	}
+}
+
+// formatLocation formats the location of frame.
+func formatLocation(frame runtime.Frame, format LocationFormat) string {
+	file := filepath.Base(frame.File)
+	switch {
+	case format&LocationAbsolute != 0:
+		file = frame.File
+	case format&LocationRelative != 0:
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, frame.File); err == nil {
+				file = rel
+			}
+		}
+	}
+
+	location := fmt.Sprintf("%s:%d", file, frame.Line)
+	if format&LocationFunc != 0 && frame.Function != "" {
+		name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+		location += " (" + name + ")"
+	}
+	return location
+}
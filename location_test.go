@@ -0,0 +1,113 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"text/template"
+)
+
+func TestFormatLocation(t *testing.T) {
+	wd, err := os.Getwd()
+	NoError(t, err)
+	frame := runtime.Frame{
+		File:     filepath.Join(wd, "testdata", "user_test.go"),
+		Line:     42,
+		Function: "github.com/nanoninja/user.TestCreate.func1",
+	}
+
+	tests := []struct {
+		name   string
+		format LocationFormat
+		want   string
+	}{
+		{"base name", 0, "user_test.go:42"},
+		{"relative path", LocationRelative, filepath.Join("testdata", "user_test.go") + ":42"},
+		{"absolute path", LocationAbsolute, frame.File + ":42"},
+		{"function", LocationFunc, "user_test.go:42 (user.TestCreate.func1)"},
+		{"absolute path and function", LocationAbsolute | LocationFunc, frame.File + ":42 (user.TestCreate.func1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Equal(t, formatLocation(frame, tt.format), tt.want)
+		})
+	}
+}
+
+func TestSetLocationFormat(t *testing.T) {
+	tmpl := template.Must(template.New("").Parse("at {{.Location}}"))
+
+	t.Run("package-level format", func(t *testing.T) {
+		defer SetLocationFormat(SetLocationFormat(LocationFunc))
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(tmpl)
+
+		want := nextLine()
+		True(a, false)
+
+		Equal(t, rec.ErrorMessage(), "at "+want+" (assert.TestSetLocationFormat.func1)")
+	})
+
+	t.Run("Assert format", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetFailureTemplate(tmpl)
+		a.SetLocationFormat(LocationAbsolute)
+
+		_, file, line, _ := runtime.Caller(0)
+		True(New(a), false)
+
+		Equal(t, rec.ErrorMessage(), "at "+file+":"+strconv.Itoa(line+1))
+		Equal(t, lookupLocationFormat(a.WithLabel("x")), LocationAbsolute)
+	})
+
+	t.Run("default layout", func(t *testing.T) {
+		defer SetLocationFormat(SetLocationFormat(LocationAbsolute | LocationFunc))
+		rec := NewTestRecorder(t)
+
+		_, file, line, _ := runtime.Caller(0)
+		Equal(rec, 1, 2)
+
+		HasPrefix(t, rec.ErrorMessage(), "\nLocation: "+file+":"+strconv.Itoa(line+1)+" (assert.TestSetLocationFormat.func3)\nExpected: (int) 2\n")
+	})
+
+	t.Run("default layout hidden", func(t *testing.T) {
+		defer SetLocationFormat(SetLocationFormat(LocationAbsolute))
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetLocationFormat(LocationHidden)
+
+		Equal(a, 1, 2)
+		Equal(rec, 1, 2)
+
+		failures := rec.Failures()
+		Len(t, failures, 2)
+		HasPrefix(t, failures[0], "\nExpected: (int) 2\n")
+		HasPrefix(t, failures[1], "\nLocation: /")
+	})
+
+	t.Run("default format", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Equal(rec, 1, 2)
+
+		HasPrefix(t, rec.ErrorMessage(), "\nExpected: (int) 2\n")
+	})
+
+	t.Run("hidden", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec)
+		a.SetLocationFormat(LocationHidden)
+
+		checkPositive(a, -1)
+
+		Equal(t, rec.ErrorMessage()[:1], "\n", "no location prefix")
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Matcher describes a condition that a value is expected to satisfy.
+// Matchers can be combined with And, Or and Not to express complex
+// conditions that are reported as a single failure.
+//
+// Custom matchers only need to implement this interface (or be built with
+// NewMatcher) to be usable with Match and formatted like the built-ins.
+type Matcher interface {
+	// Match reports whether the actual value satisfies the condition.
+	Match(actual any) bool
+
+	// String describes the expected condition, e.g. "have length 3".
+	String() string
+}
+
+// Match checks if a value satisfies the given matcher.
+// On failure, the description of the whole matcher is reported.
+func Match(t testing.TB, value any, matcher Matcher, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if !matcher.Match(value) {
+		failCompare[any](t, value, "value to "+matcher.String(), msg...)
+	}
+}
+
+// NewMatcher creates a custom matcher from a description and a predicate.
+// Values that are not of type T never match.
+//
+//	func BeWithinBudget(limit int) assert.Matcher {
+//	    return assert.NewMatcher(fmt.Sprintf("be within budget %d", limit), func(o Order) bool {
+//	        return o.Total <= limit
+//	    })
+//	}
+func NewMatcher[T any](desc string, fn func(actual T) bool) Matcher {
+	return matcherFunc{
+		desc: desc,
+		match: func(actual any) bool {
+			v, ok := actual.(T)
+			return ok && fn(v)
+		},
+	}
+}
+
+// matcherFunc is the implementation shared by all built-in matchers.
+type matcherFunc struct {
+	desc  string
+	match func(actual any) bool
+}
+
+func (m matcherFunc) Match(actual any) bool { return m.match(actual) }
+func (m matcherFunc) String() string        { return m.desc }
+
+// BeEmpty matches nil values, empty slices, arrays, maps, strings and channels,
+// and values considered empty by Empty.
+func BeEmpty() Matcher {
+	return matcherFunc{
+		desc: "be empty",
+		match: func(actual any) bool {
+			if actual == nil {
+				return true
+			}
+			if empty, _, ok := emptiness(actual); ok {
+				return empty
+			}
+			n, ok := lengthOf(actual)
+			return ok && n == 0
+		},
+	}
+}
+
+// HaveLen matches slices, arrays, maps, strings and channels of the given length.
+func HaveLen(length int) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("have length %d", length),
+		match: func(actual any) bool {
+			n, ok := lengthOf(actual)
+			return ok && n == length
+		},
+	}
+}
+
+// BeNumerically compares a numeric value against n using the comparator,
+// which must be one of "==", "!=", "<", "<=", ">" or ">=".
+// Values of different numeric types are compared by their exact value,
+// so that 64-bit integers above 2^53 are not rounded. NaN is only
+// different from any value.
+func BeNumerically(comparator string, n any) Matcher {
+	var cmp func(c int) bool
+
+	switch comparator {
+	case "==":
+		cmp = func(c int) bool { return c == 0 }
+	case "!=":
+		cmp = func(c int) bool { return c != 0 }
+	case "<":
+		cmp = func(c int) bool { return c < 0 }
+	case "<=":
+		cmp = func(c int) bool { return c <= 0 }
+	case ">":
+		cmp = func(c int) bool { return c > 0 }
+	case ">=":
+		cmp = func(c int) bool { return c >= 0 }
+	default:
+		panic(fmt.Sprintf("assert: unknown comparator %q", comparator))
+	}
+
+	return matcherFunc{
+		desc: fmt.Sprintf("be numerically %s %v", comparator, n),
+		match: func(actual any) bool {
+			a, ok := toBigFloat(actual)
+			if !ok {
+				return false
+			}
+			b, ok := toBigFloat(n)
+			if !ok {
+				return false
+			}
+			if a == nil || b == nil {
+				return comparator == "!="
+			}
+			return cmp(a.Cmp(b))
+		},
+	}
+}
+
+// ContainElement matches slices and arrays containing the element,
+// maps containing it as a value, and strings containing it as a substring.
+func ContainElement(element any) Matcher {
+	return matcherFunc{
+		desc: fmt.Sprintf("contain element %#v", element),
+		match: func(actual any) bool {
+			v := reflect.ValueOf(actual)
+
+			switch v.Kind() {
+			case reflect.String:
+				s, ok := element.(string)
+				return ok && strings.Contains(v.String(), s)
+			case reflect.Slice, reflect.Array:
+				for i := 0; i < v.Len(); i++ {
+					if reflect.DeepEqual(v.Index(i).Interface(), element) {
+						return true
+					}
+				}
+			case reflect.Map:
+				iter := v.MapRange()
+				for iter.Next() {
+					if reflect.DeepEqual(iter.Value().Interface(), element) {
+						return true
+					}
+				}
+			}
+			return false
+		},
+	}
+}
+
+// And matches when all the given matchers match.
+func And(matchers ...Matcher) Matcher {
+	return matcherFunc{
+		desc: joinMatchers(matchers, " and "),
+		match: func(actual any) bool {
+			for _, m := range matchers {
+				if !m.Match(actual) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// Or matches when at least one of the given matchers matches.
+func Or(matchers ...Matcher) Matcher {
+	return matcherFunc{
+		desc: joinMatchers(matchers, " or "),
+		match: func(actual any) bool {
+			for _, m := range matchers {
+				if m.Match(actual) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// Not inverts the given matcher.
+func Not(matcher Matcher) Matcher {
+	return matcherFunc{
+		desc: "not " + matcher.String(),
+		match: func(actual any) bool {
+			return !matcher.Match(actual)
+		},
+	}
+}
+
+// joinMatchers builds the description of a composed matcher.
+func joinMatchers(matchers []Matcher, sep string) string {
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = m.String()
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+// lengthOf returns the length of values supporting the built-in len function.
+func lengthOf(value any) (int, bool) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return v.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+// toBigFloat converts any numeric value to a big.Float holding it exactly,
+// nil for NaN, and reports whether value is numeric.
+func toBigFloat(value any) (*big.Float, bool) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) {
+			return nil, true
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	default:
+		return nil, false
+	}
+}
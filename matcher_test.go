@@ -0,0 +1,277 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		matcher   Matcher
+		wantError bool
+	}{
+		{
+			name:      "empty slice",
+			value:     []int{},
+			matcher:   BeEmpty(),
+			wantError: false,
+		},
+		{
+			name:      "nil value is empty",
+			value:     nil,
+			matcher:   BeEmpty(),
+			wantError: false,
+		},
+		{
+			name:      "non-empty string",
+			value:     "hello",
+			matcher:   BeEmpty(),
+			wantError: true,
+		},
+		{
+			name:      "matching length",
+			value:     map[string]int{"a": 1, "b": 2, "c": 3},
+			matcher:   HaveLen(3),
+			wantError: false,
+		},
+		{
+			name:      "length of unsupported type",
+			value:     42,
+			matcher:   HaveLen(0),
+			wantError: true,
+		},
+		{
+			name:      "numerically greater",
+			value:     10,
+			matcher:   BeNumerically(">", 5),
+			wantError: false,
+		},
+		{
+			name:      "numerically mixed types",
+			value:     uint8(5),
+			matcher:   BeNumerically("<=", 5.0),
+			wantError: false,
+		},
+		{
+			name:      "numerically not equal",
+			value:     1.5,
+			matcher:   BeNumerically("==", 2),
+			wantError: true,
+		},
+		{
+			name:      "numerically large integers",
+			value:     int64(1<<53 + 1),
+			matcher:   BeNumerically(">", int64(1<<53)),
+			wantError: false,
+		},
+		{
+			name:      "numerically large unsigned integers",
+			value:     uint64(math.MaxUint64),
+			matcher:   BeNumerically("==", uint64(math.MaxUint64-1)),
+			wantError: true,
+		},
+		{
+			name:      "numerically integer and float",
+			value:     int64(1<<53 + 1),
+			matcher:   BeNumerically(">", float64(1<<53)),
+			wantError: false,
+		},
+		{
+			name:      "numerically negative and unsigned",
+			value:     -1,
+			matcher:   BeNumerically("<", uint64(math.MaxUint64)),
+			wantError: false,
+		},
+		{
+			name:      "numerically NaN",
+			value:     math.NaN(),
+			matcher:   And(BeNumerically("!=", 1), Not(BeNumerically(">=", 1)), Not(BeNumerically("<", 1))),
+			wantError: false,
+		},
+		{
+			name:      "numerically non-numeric value",
+			value:     "10",
+			matcher:   BeNumerically(">", 5),
+			wantError: true,
+		},
+		{
+			name:      "slice contains element",
+			value:     []string{"a", "b"},
+			matcher:   ContainElement("b"),
+			wantError: false,
+		},
+		{
+			name:      "map contains value",
+			value:     map[string]int{"a": 1},
+			matcher:   ContainElement(1),
+			wantError: false,
+		},
+		{
+			name:      "string contains substring",
+			value:     "hello world",
+			matcher:   ContainElement("world"),
+			wantError: false,
+		},
+		{
+			name:      "element missing",
+			value:     []int{1, 2},
+			matcher:   ContainElement(3),
+			wantError: true,
+		},
+		{
+			name:      "and all match",
+			value:     []int{1, 2, 3},
+			matcher:   And(HaveLen(3), ContainElement(2)),
+			wantError: false,
+		},
+		{
+			name:      "and one fails",
+			value:     []int{1, 2, 3},
+			matcher:   And(HaveLen(3), ContainElement(4)),
+			wantError: true,
+		},
+		{
+			name:      "or one matches",
+			value:     7,
+			matcher:   Or(BeNumerically("<", 0), BeNumerically(">", 5)),
+			wantError: false,
+		},
+		{
+			name:      "or none match",
+			value:     3,
+			matcher:   Or(BeNumerically("<", 0), BeNumerically(">", 5)),
+			wantError: true,
+		},
+		{
+			name:      "not inverts",
+			value:     []int{1},
+			matcher:   Not(BeEmpty()),
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			Match(rec, tt.value, tt.matcher)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("Match() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMatchDescription(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	Match(rec, []int{1}, And(Not(BeEmpty()), Or(HaveLen(2), ContainElement(5))), "custom message")
+
+	wantParts := []string{
+		"Message: custom message",
+		"value to (not be empty and (have length 2 or contain element 5))",
+		"[]int{1}",
+	}
+
+	for _, part := range wantParts {
+		if !strings.Contains(rec.ErrorMessage(), part) {
+			t.Errorf("Match() message missing %q\ngot: %s", part, rec.ErrorMessage())
+		}
+	}
+}
+
+func TestBeNumericallyUnknownComparator(t *testing.T) {
+	Panics(t, func() { BeNumerically("<>", 1) }, `assert: unknown comparator "<>"`)
+}
+
+// invoice is a domain type used to exercise custom matchers.
+type invoice struct {
+	total int
+	paid  bool
+}
+
+// beWithinBudget is a custom matcher implementing the Matcher interface directly.
+type beWithinBudget struct {
+	limit int
+}
+
+func (m beWithinBudget) Match(actual any) bool {
+	inv, ok := actual.(invoice)
+	return ok && inv.total <= m.limit
+}
+
+func (m beWithinBudget) String() string {
+	return "be within budget " + strings.Repeat("$", m.limit/100)
+}
+
+func TestCustomMatcher(t *testing.T) {
+	bePaid := NewMatcher("be paid", func(inv invoice) bool { return inv.paid })
+
+	tests := []struct {
+		name      string
+		value     any
+		matcher   Matcher
+		wantError bool
+	}{
+		{
+			name:      "interface implementation matches",
+			value:     invoice{total: 100},
+			matcher:   beWithinBudget{limit: 200},
+			wantError: false,
+		},
+		{
+			name:      "interface implementation fails",
+			value:     invoice{total: 300},
+			matcher:   beWithinBudget{limit: 200},
+			wantError: true,
+		},
+		{
+			name:      "NewMatcher matches",
+			value:     invoice{paid: true},
+			matcher:   bePaid,
+			wantError: false,
+		},
+		{
+			name:      "NewMatcher wrong type",
+			value:     "invoice",
+			matcher:   bePaid,
+			wantError: true,
+		},
+		{
+			name:      "composed with built-ins",
+			value:     invoice{total: 100, paid: true},
+			matcher:   And(bePaid, beWithinBudget{limit: 100}),
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			Match(rec, tt.value, tt.matcher)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("Match() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("description is reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Match(rec, invoice{}, Not(bePaid))
+		Match(rec, invoice{}, bePaid)
+
+		if !strings.Contains(rec.ErrorMessage(), "value to be paid") {
+			t.Errorf("Match() message missing description\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// msgPackTimestamp is the extension type of MessagePack timestamps.
+const msgPackTimestamp = -1
+
+// decodeMsgPack decodes a MessagePack payload.
+func decodeMsgPack(data []byte) (any, error) {
+	return decodeAll(data, readMsgPack)
+}
+
+// readMsgPack reads a single object.
+func readMsgPack(r *binaryReader) (any, error) {
+	start := r.pos
+	head, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := head[0]
+
+	switch {
+	case c <= 0x7f:
+		return big.NewInt(int64(c)), nil
+	case c >= 0xe0:
+		return big.NewInt(int64(int8(c))), nil
+	case c <= 0x8f:
+		return readMsgPackContainer(r, false, uint64(c&0x0f))
+	case c <= 0x9f:
+		return readMsgPackContainer(r, true, uint64(c&0x0f))
+	case c <= 0xbf:
+		return readMsgPackString(r, uint64(c&0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.uint(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackExt(r, n)
+	case 0xca:
+		bits, err := r.uint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(bits))), nil
+	case 0xcb:
+		bits, err := r.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(bits), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := r.uint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(u), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err := r.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign-extend the integer of size bytes.
+		shift := 64 - 8*size
+		return big.NewInt(int64(u<<shift) >> shift), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readMsgPackExt(r, 1<<(c-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackString(r, n)
+	case 0xdc, 0xdd:
+		n, err := r.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackContainer(r, true, n)
+	case 0xde, 0xdf:
+		n, err := r.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackContainer(r, false, n)
+	}
+	return nil, fmt.Errorf("invalid type byte 0x%02x at offset %d", c, start)
+}
+
+func readMsgPackString(r *binaryReader, n uint64) (any, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return r.text(b)
+}
+
+// readMsgPackExt reads the type and n bytes of data of an extension.
+func readMsgPackExt(r *binaryReader, n uint64) (any, error) {
+	typ, err := r.uint(1)
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(typ) == msgPackTimestamp {
+		if ts, ok := msgPackTime(data); ok {
+			return ts, nil
+		}
+	}
+	return decodedExt{typ: int8(typ), data: append([]byte{}, data...)}, nil
+}
+
+// msgPackTime decodes the data of a timestamp extension,
+// in its 32, 64 or 96-bit format.
+func msgPackTime(data []byte) (time.Time, bool) {
+	r := &binaryReader{data: data}
+	switch len(data) {
+	case 4:
+		sec, _ := r.uint(4)
+		return time.Unix(int64(sec), 0).UTC(), true
+	case 8:
+		v, _ := r.uint(8)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), true
+	case 12:
+		nsec, _ := r.uint(4)
+		sec, _ := r.uint(8)
+		return time.Unix(int64(sec), int64(nsec)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// readMsgPackContainer reads the n items of an array, or the n entries
+// of a map.
+func readMsgPackContainer(r *binaryReader, array bool, n uint64) (any, error) {
+	if err := r.nest(); err != nil {
+		return nil, err
+	}
+	defer func() { r.depth-- }()
+
+	// Each item takes at least a byte.
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errUnexpectedEnd
+	}
+
+	if array {
+		list := make([]any, 0, n)
+		for i := uint64(0); i < n; i++ {
+			v, err := readMsgPack(r)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+
+	m := make(decodedMap, 0, n)
+	for i := uint64(0); i < n; i++ {
+		k, err := readMsgPack(r)
+		if err != nil {
+			return nil, err
+		}
+		v, err := readMsgPack(r)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, decodedEntry{key: k, value: v})
+	}
+	return m, nil
+}
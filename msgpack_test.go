@@ -0,0 +1,48 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMsgPackEq(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		expected  string
+		wantError bool
+		wantNote  string
+	}{
+		{"same bytes", "81 a1 61 01", "81 a1 61 01", false, ""},
+		{"wider encodings", "de 00 02 d9 01 61 cc 01 a1 62 dc 00 02 02 d0 03", "82 a1 61 01 a1 62 92 02 03", false, ""},
+		{"negative integers", "d1 ff ff", "ff", false, ""},
+		{"large integers", "cf ff ff ff ff ff ff ff ff", "cf ff ff ff ff ff ff ff ff", false, ""},
+		{"float sizes", "ca 3f c0 00 00", "cb 3f f8 00 00 00 00 00 00", false, ""},
+		{"timestamps", "d6 ff 00 00 00 01", "d7 ff 00 00 00 00 00 00 00 01", false, ""},
+		{"different value", "81 a1 61 01", "81 a1 61 02", true, "$.a: expected 2, got 1"},
+		{"different timestamps", "d6 ff 00 00 00 01", "d6 ff 00 00 00 02", true, "$: expected 1970-01-01T00:00:02Z, got 1970-01-01T00:00:01Z"},
+		{"extensions", "d4 05 01", "d4 05 02", true, "$: expected ext(5, h'02'), got ext(5, h'01')"},
+		{"bytes and text", "c4 02 68 69", "a2 68 69", true, "$: expected text string, got byte string"},
+		{"missing key", "80", "81 a1 61 c0", true, "$.a: missing, expected null"},
+		{"never used byte", "c1", "c0", true, "invalid actual MessagePack: invalid type byte 0xc1 at offset 0"},
+		{"truncated", "dc 00 03 01", "90", true, "unexpected end of data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			MsgPackEq(rec, unhex(tt.actual), unhex(tt.expected))
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("MsgPackEq() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("MsgPackEq() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ErrorCountIs checks if err aggregates n errors, such as the errors joined
+// by errors.Join, including those of nested joined errors. An error that
+// aggregates no other error counts as one, and nil as none:
+//
+//	err := user.Validate()
+//	assert.ErrorCountIs(t, err, 3)
+//
+// The messages of the errors are listed on failure.
+func ErrorCountIs(t testing.TB, err error, n int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	errs := flattenErrors(err)
+	if len(errs) == n {
+		return
+	}
+
+	notes := make([]string, 0, len(errs))
+	for _, e := range errs {
+		notes = append(notes, fmt.Sprintf("%q", e.Error()))
+	}
+	if len(notes) > maxSliceDiffs {
+		notes = append(notes[:maxSliceDiffs], fmt.Sprintf("and %d more", len(notes)-maxSliceDiffs))
+	}
+	note := "no error"
+	if len(notes) > 0 {
+		note = "errors: " + strings.Join(notes, "; ")
+	}
+
+	failCompareNote(t, fmt.Sprintf("%d errors", len(errs)), fmt.Sprintf("%d errors", n), note,
+		withMessage("unexpected number of errors", msg)...)
+}
+
+// EachError runs fn for each error aggregated by err, as counted by
+// ErrorCountIs, with an Assert labelled by the position of the error:
+//
+//	assert.EachError(t, user.Validate(), func(e error, a *assert.Assert) {
+//	    var fe *FieldError
+//	    assert.ErrorAs(a, e, &fe)
+//	})
+//
+// It fails when err is nil.
+func EachError(t testing.TB, err error, fn func(e error, a *Assert), msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if isNil(err) {
+		failCompareNote[any](t, err, "non-nil error", typedNilNote(err), withMessage("expected an error", msg)...)
+		return
+	}
+
+	a := &Assert{TB: t}
+	if parent, ok := t.(*Assert); ok {
+		a = parent
+	}
+
+	errs := flattenErrors(err)
+	for i, e := range errs {
+		fn(e, a.WithLabel(fmt.Sprintf("error %d of %d", i+1, len(errs))))
+	}
+}
+
+// flattenErrors returns the errors aggregated by err, following the chain
+// of wrapped errors down to the first error aggregating several ones.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	for e := err; e != nil; {
+		if multi, ok := e.(interface{ Unwrap() []error }); ok {
+			var errs []error
+			for _, inner := range multi.Unwrap() {
+				errs = append(errs, flattenErrors(inner)...)
+			}
+			return errs
+		}
+		wrapper, ok := e.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		e = wrapper.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// joinedError aggregates errors like the errors returned by errors.Join.
+type joinedError []error
+
+func (e joinedError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e joinedError) Unwrap() []error {
+	return e
+}
+
+func TestErrorCountIs(t *testing.T) {
+	errName := errors.New("name is required")
+	errAge := errors.New("age must be positive")
+	errMail := errors.New("invalid email")
+
+	tests := []struct {
+		name      string
+		err       error
+		n         int
+		wantError bool
+		wantNote  string
+	}{
+		{"joined errors", joinedError{errName, errAge, errMail}, 3, false, ""},
+		{"nested joined errors", joinedError{errName, joinedError{errAge, errMail}}, 3, false, ""},
+		{"wrapped joined errors", fmt.Errorf("validate: %w", joinedError{errName, errAge}), 2, false, ""},
+		{"single error", fmt.Errorf("user: %w", errName), 1, false, ""},
+		{"nil", nil, 0, false, ""},
+		{
+			name:      "wrong count",
+			err:       joinedError{errName, errAge},
+			n:         3,
+			wantError: true,
+			wantNote:  `errors: "name is required"; "age must be positive"`,
+		},
+		{
+			name:      "no error",
+			err:       nil,
+			n:         1,
+			wantError: true,
+			wantNote:  "Note: no error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			ErrorCountIs(rec, tt.err, tt.n)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ErrorCountIs() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("ErrorCountIs() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestEachError(t *testing.T) {
+	t.Run("runs for each error", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		var got []string
+
+		EachError(rec, joinedError{errors.New("a"), joinedError{errors.New("b"), errors.New("c")}}, func(e error, a *Assert) {
+			got = append(got, e.Error())
+		})
+
+		Equal(t, got, []string{"a", "b", "c"})
+		False(t, rec.HasError())
+	})
+
+	t.Run("labels failures", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		EachError(rec, joinedError{errors.New("a"), &testError{code: 1}}, func(e error, a *Assert) {
+			var te *testError
+			ErrorAs(a, e, &te)
+		})
+
+		if !strings.HasPrefix(rec.ErrorMessage(), "error 1 of 2\n") {
+			t.Errorf("EachError() message = %q, want label error 1 of 2", rec.ErrorMessage())
+		}
+		Equal(t, len(rec.Failures()), 1)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		called := false
+
+		EachError(rec, nil, func(e error, a *Assert) { called = true })
+
+		False(t, called)
+		if !strings.Contains(rec.ErrorMessage(), "expected an error") {
+			t.Errorf("EachError() message = %q", rec.ErrorMessage())
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+// dialInterval is the delay between two dial attempts.
+const dialInterval = 50 * time.Millisecond
+
+// DialSucceeds checks that a connection to addr on the given network can be
+// established within timeout, retrying failed attempts. It lets integration
+// tests wait for a server they started to come up:
+//
+//	go srv.ListenAndServe()
+//	assert.DialSucceeds(t, "tcp", srv.Addr, 5*time.Second)
+func DialSucceeds(t testing.TB, network, addr string, timeout time.Duration, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	dial(t, network, addr, timeout, msg)
+}
+
+// PortListening checks that a TCP server is listening on addr within timeout.
+// It is DialSucceeds for the "tcp" network.
+func PortListening(t testing.TB, addr string, timeout time.Duration, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	dial(t, "tcp", addr, timeout, msg)
+}
+
+// dial connects to addr until it succeeds or timeout is reached.
+func dial(t testing.TB, network, addr string, timeout time.Duration, msg []string) {
+	t.Helper()
+
+	clk := lookupClock(t)
+	deadline := clk.Now().Add(timeout)
+	attempts := 0
+
+	for {
+		attempts++
+		conn, err := net.DialTimeout(network, addr, dialTimeout(deadline.Sub(clk.Now())))
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+		if clk.Now().Add(dialInterval).After(deadline) {
+			failCompare(t, err.Error(), fmt.Sprintf("%s connection to %s", network, addr),
+				withMessage(fmt.Sprintf("dial failed after %d attempts in %v", attempts, timeout), msg)...)
+			return
+		}
+		clk.Sleep(dialInterval)
+	}
+}
+
+// dialTimeout returns the time left for a single attempt,
+// never less than dialInterval.
+func dialTimeout(left time.Duration) time.Duration {
+	if left > dialInterval {
+		return left
+	}
+	return dialInterval
+}
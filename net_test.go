@@ -0,0 +1,116 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// closedAddr returns the address of a TCP port nothing listens on.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr
+}
+
+func TestPortListening(t *testing.T) {
+	t.Run("listening", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skipf("cannot listen: %v", err)
+		}
+		defer ln.Close()
+
+		rec := NewTestRecorder(t)
+
+		PortListening(rec, ln.Addr().String(), time.Second)
+
+		if rec.HasError() {
+			t.Errorf("PortListening() failed: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("comes up later", func(t *testing.T) {
+		addr := closedAddr(t)
+		ready := make(chan net.Listener, 1)
+		go func() {
+			time.Sleep(3 * dialInterval)
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				ready <- nil
+				return
+			}
+			ready <- ln
+		}()
+
+		rec := NewTestRecorder(t)
+
+		PortListening(rec, addr, 2*time.Second)
+
+		if ln := <-ready; ln == nil {
+			t.Skip("port was taken in the meantime")
+		} else {
+			defer ln.Close()
+		}
+		if rec.HasError() {
+			t.Errorf("PortListening() failed: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("not listening", func(t *testing.T) {
+		addr := closedAddr(t)
+		rec := NewTestRecorder(t)
+
+		PortListening(rec, addr, 3*dialInterval, "api server")
+
+		if !rec.HasError() {
+			t.Fatal("PortListening() did not fail")
+		}
+		for _, part := range []string{"Message: api server: dial failed after", "tcp connection to " + addr} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("PortListening() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestDialSucceeds(t *testing.T) {
+	ln, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer ln.Close()
+
+	tests := []struct {
+		name      string
+		network   string
+		addr      string
+		wantError bool
+	}{
+		{"udp", "udp", ln.LocalAddr().String(), false},
+		{"unknown network", "carrier-pigeon", "127.0.0.1:1", true},
+		{"invalid address", "tcp", "not an address", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			DialSucceeds(rec, tt.network, tt.addr, dialInterval)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("DialSucceeds() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
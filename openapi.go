@@ -0,0 +1,224 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// MatchesOpenAPI checks that a response body is valid against the schema
+// declared by an OpenAPI spec for an operation and status code. Each
+// mismatched property is reported with its JSON pointer:
+//
+//	resp := get(t, srv.URL+"/users/42")
+//	assert.MatchesOpenAPI(t, "testdata/openapi.json", "GET", "/users/42", resp.StatusCode, body)
+//	// Note: /email: expected string, got null
+//
+// The path is matched against the templated paths of the spec, such as
+// /users/{id}. The status code is looked up exactly, then by range (2XX),
+// then as default. Specs are read as JSON, in version 3.0 or 3.1, and
+// schemas are validated like MatchesJSONSchema, with references to
+// components resolved within the spec.
+func MatchesOpenAPI(t testing.TB, specPath, method, path string, statusCode int, body string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		failCompare(t, specPath, "readable OpenAPI spec", withMessage("cannot read spec: "+err.Error(), msg)...)
+		return
+	}
+	spec, err := readJSON(string(data))
+	if err != nil {
+		failCompare(t, specPath, "OpenAPI spec in JSON", withMessage("invalid spec JSON: "+err.Error(), msg)...)
+		return
+	}
+	root, _ := spec.(map[string]any)
+	if version, _ := root["openapi"].(string); strings.HasPrefix(version, "3.0") {
+		upgradeOpenAPI30(spec)
+	}
+
+	operation := method + " " + path
+	schema, found, note := openAPIResponseSchema(root, method, path, statusCode)
+	if !found {
+		failCompareNote(t, fmt.Sprintf("%s %d", operation, statusCode), "response declared in spec", note,
+			withMessage("response not declared in OpenAPI spec", msg)...)
+		return
+	}
+
+	if schema == nil {
+		if body != "" {
+			failCompareNote(t, body, "", "the spec declares no content for "+operation,
+				withMessage("unexpected response body", msg)...)
+		}
+		return
+	}
+
+	b, err := readJSON(body)
+	if err != nil {
+		failCompare(t, body, "valid JSON", withMessage("invalid response JSON: "+err.Error(), msg)...)
+		return
+	}
+
+	v := &schemaValidator{root: spec}
+	v.validate(b, schema, "")
+
+	if v.err != nil {
+		failCompare(t, specPath, "valid OpenAPI spec", withMessage("invalid schema: "+v.err.Error(), msg)...)
+		return
+	}
+	if diffs := v.violations; len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, body, fmt.Sprintf("%s %d response", operation, statusCode), strings.Join(diffs, "; "),
+			withMessage("response does not match OpenAPI schema", msg)...)
+	}
+}
+
+// openAPIResponseSchema returns the JSON schema of the response of an
+// operation, or nil if the response has no content. When the response
+// is not declared, found is false and note describes what is declared.
+func openAPIResponseSchema(spec map[string]any, method, path string, statusCode int) (schema any, found bool, note string) {
+	paths, _ := spec["paths"].(map[string]any)
+	template, ok := matchOpenAPIPath(paths, path)
+	if !ok {
+		return nil, false, "paths: " + strings.Join(sortedKeys(paths), ", ")
+	}
+
+	item, _ := resolveOpenAPIRef(spec, paths[template]).(map[string]any)
+	op, ok := item[strings.ToLower(method)].(map[string]any)
+	if !ok {
+		var methods []string
+		for k := range item {
+			switch k {
+			case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+				methods = append(methods, strings.ToUpper(k))
+			}
+		}
+		sort.Strings(methods)
+		return nil, false, fmt.Sprintf("methods of %s: %s", template, strings.Join(methods, ", "))
+	}
+
+	responses, _ := op["responses"].(map[string]any)
+	code := strconv.Itoa(statusCode)
+	response, ok := responses[code]
+	if !ok {
+		response, ok = responses[code[:1]+"XX"]
+	}
+	if !ok {
+		response, ok = responses["default"]
+	}
+	if !ok {
+		return nil, false, "status codes: " + strings.Join(sortedKeys(responses), ", ")
+	}
+
+	r, _ := resolveOpenAPIRef(spec, response).(map[string]any)
+	content, _ := r["content"].(map[string]any)
+	if len(content) == 0 {
+		return nil, true, ""
+	}
+	for _, mediaType := range sortedKeys(content) {
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || mediaType == "*/*" {
+			media, _ := content[mediaType].(map[string]any)
+			if s, ok := media["schema"]; ok {
+				return s, true, ""
+			}
+			return true, true, ""
+		}
+	}
+	return nil, false, "content types: " + strings.Join(sortedKeys(content), ", ")
+}
+
+// matchOpenAPIPath returns the templated path of paths matching path.
+// Paths without templates take precedence, then the ones with fewer
+// templated segments.
+func matchOpenAPIPath(paths map[string]any, path string) (string, bool) {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+
+	best, bestParams := "", -1
+	for _, template := range sortedKeys(paths) {
+		parts := strings.Split(template, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+
+		params, ok := 0, true
+		for i, part := range parts {
+			switch {
+			case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+				if segments[i] == "" {
+					ok = false
+				}
+				params++
+			case part != segments[i]:
+				ok = false
+			}
+		}
+		if ok && (bestParams < 0 || params < bestParams) {
+			best, bestParams = template, params
+		}
+	}
+	return best, bestParams >= 0
+}
+
+// resolveOpenAPIRef follows the $ref of a spec object, such as
+// {"$ref": "#/components/responses/NotFound"}.
+func resolveOpenAPIRef(spec, v any) any {
+	for i := 0; i < maxRefDepth; i++ {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return v
+		}
+		target, err := resolvePointer(spec, ref[1:])
+		if err != nil {
+			return nil
+		}
+		v = target
+	}
+	return nil
+}
+
+// upgradeOpenAPI30 rewrites the schemas of an OpenAPI 3.0 spec in place,
+// where nullable and boolean exclusive bounds differ from JSON Schema.
+func upgradeOpenAPI30(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			upgradeOpenAPI30(item)
+		}
+	case map[string]any:
+		for _, item := range v {
+			upgradeOpenAPI30(item)
+		}
+
+		if nullable, _ := v["nullable"].(bool); nullable {
+			delete(v, "nullable")
+			if typ, ok := v["type"].(string); ok {
+				v["type"] = []any{typ, "null"}
+			}
+		}
+		for bound, exclusive := range map[string]string{"minimum": "exclusiveMinimum", "maximum": "exclusiveMaximum"} {
+			if flag, ok := v[exclusive].(bool); ok {
+				delete(v, exclusive)
+				if flag {
+					v[exclusive] = v[bound]
+					delete(v, bound)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const usersSpec = `{
+	"openapi": "3.1.0",
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"responses": {
+					"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+					"4XX": {"$ref": "#/components/responses/Error"}
+				}
+			},
+			"delete": {"responses": {"204": {"description": "deleted"}}}
+		},
+		"/users/me": {
+			"get": {
+				"responses": {
+					"200": {"content": {"application/json": {"schema": {"type": "string"}}}}
+				}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["id", "email"],
+				"properties": {
+					"id":    {"type": "integer"},
+					"email": {"type": "string"}
+				}
+			}
+		},
+		"responses": {
+			"Error": {"content": {"application/problem+json": {"schema": {"required": ["title"]}}}}
+		}
+	}
+}`
+
+const legacySpec = `{
+	"openapi": "3.0.3",
+	"paths": {
+		"/items": {
+			"get": {
+				"responses": {
+					"default": {"content": {"application/json": {"schema": {
+						"type": "object",
+						"properties": {
+							"name":  {"type": "string", "nullable": true},
+							"price": {"type": "number", "minimum": 0, "exclusiveMinimum": true}
+						}
+					}}}}
+				}
+			}
+		}
+	}
+}`
+
+func TestMatchesOpenAPI(t *testing.T) {
+	spec := TempFileWith(t, usersSpec)
+	legacy := TempFileWith(t, legacySpec)
+
+	tests := []struct {
+		name      string
+		spec      string
+		method    string
+		path      string
+		status    int
+		body      string
+		wantError bool
+		wantNote  string
+	}{
+		{"valid response", spec, "GET", "/users/42", 200, `{"id": 42, "email": "a@example.com"}`, false, ""},
+		{"exact path first", spec, "GET", "/users/me", 200, `"me"`, false, ""},
+		{"query ignored", spec, "GET", "/users/42?fields=id", 200, `{"id": 42, "email": "a"}`, false, ""},
+		{"status range", spec, "get", "/users/42", 404, `{"title": "not found"}`, false, ""},
+		{"no content", spec, "DELETE", "/users/42", 204, "", false, ""},
+		{
+			"mismatched properties", spec, "GET", "/users/42", 200, `{"id": "42"}`, true,
+			`(root): missing required property "email"; /id: expected integer, got string`,
+		},
+		{"mismatched error", spec, "GET", "/users/42", 404, `{}`, true, `missing required property "title"`},
+		{"unexpected content", spec, "DELETE", "/users/42", 204, `{}`, true, "the spec declares no content for DELETE /users/42"},
+		{"undeclared path", spec, "GET", "/orders/1", 200, `{}`, true, "paths: /users/me, /users/{id}"},
+		{"undeclared method", spec, "POST", "/users/42", 200, `{}`, true, "methods of /users/{id}: DELETE, GET"},
+		{"undeclared status", spec, "GET", "/users/42", 500, `{}`, true, "status codes: 200, 4XX"},
+		{"invalid body", spec, "GET", "/users/42", 200, `{`, true, "invalid response JSON"},
+		{"missing spec", "testdata/none.json", "GET", "/", 200, `{}`, true, "cannot read spec"},
+		{"nullable", legacy, "GET", "/items", 200, `{"name": null, "price": 1}`, false, ""},
+		{"exclusive minimum", legacy, "GET", "/items", 200, `{"price": 0}`, true, "/price: 0 is not greater than exclusiveMinimum 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			MatchesOpenAPI(rec, tt.spec, tt.method, tt.path, tt.status, tt.body)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("MatchesOpenAPI() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("MatchesOpenAPI() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestMatchOpenAPIPath(t *testing.T) {
+	paths := map[string]any{"/a/{id}": nil, "/a/b": nil, "/{x}/{y}": nil, "/": nil}
+
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"/a/b", "/a/b", true},
+		{"/a/1", "/a/{id}", true},
+		{"/c/1", "/{x}/{y}", true},
+		{"/", "/", true},
+		{"/a/", "", false},
+		{"/a/b/c", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := matchOpenAPIPath(paths, tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("matchOpenAPIPath(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Option configures the assertions accepting options, such as EqualWith.
+type Option func(*options)
+
+// options holds the configuration built from a list of Option.
+type options struct {
+	msg    string
+	labels []string
+	equal  equalizer
+
+	// csvHeader compares CSV records by the columns named in their header.
+	csvHeader bool
+	// trimSpace ignores leading and trailing spaces of values.
+	trimSpace bool
+	// numberTolerance is the maximum difference between equal JSON numbers.
+	numberTolerance float64
+	// unorderedArrays compares JSON arrays as multisets, matching the
+	// objects by their arrayKey field when set.
+	unorderedArrays bool
+	arrayKey        string
+}
+
+// newOptions applies opts over the default configuration.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// messages returns the optional message in the form expected by failCompare.
+// Labels set with Label come first.
+func (o *options) messages() []string {
+	msg := o.msg
+	if len(o.labels) > 0 {
+		msg = strings.Join(o.labels, labelSeparator)
+		if o.msg != "" {
+			msg += ": " + o.msg
+		}
+	}
+	if msg == "" {
+		return nil
+	}
+	return []string{msg}
+}
+
+// Message sets the custom message printed when the assertion fails. It is
+// the optional message of the assertions taking options, such as EqualWith,
+// JSONEq and CSVEquals, given last like the message of other assertions:
+//
+//	assert.JSONEq(t, body, want, assert.UnorderedArrays(""), assert.Message("user list"))
+func Message(msg string) Option {
+	return func(o *options) {
+		o.msg = msg
+	}
+}
+
+// NilEqualsEmpty makes nil and empty slices or maps equal, as in EqualLoose.
+func NilEqualsEmpty() Option {
+	return func(o *options) {
+		o.equal.nilEqualsEmpty = true
+	}
+}
+
+// IgnoreUnexported skips the unexported fields of the structs of the given
+// types, given as values of those types or pointers to them. Without types,
+// unexported fields of all structs are skipped:
+//
+//	assert.EqualWith(t, got, want, assert.IgnoreUnexported(Cache{}))
+func IgnoreUnexported(types ...any) Option {
+	return func(o *options) {
+		if len(types) == 0 {
+			o.equal.ignoreAllUnexported = true
+			return
+		}
+		if o.equal.ignoreUnexported == nil {
+			o.equal.ignoreUnexported = map[reflect.Type]bool{}
+		}
+		for _, v := range types {
+			typ := reflect.TypeOf(v)
+			if typ != nil && typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			o.equal.ignoreUnexported[typ] = true
+		}
+	}
+}
+
+// SameAliasing makes EqualWith also require the values to share references
+// the same way: pointers, maps and slices referenced twice in expected must
+// be the same reference in actual, and references distinct in expected must
+// be distinct in actual. It checks that Clone methods preserve the
+// structure of graphs, which DeepEqual cannot tell apart:
+//
+//	assert.EqualWith(t, graph.Clone(), graph, assert.SameAliasing())
+func SameAliasing() Option {
+	return func(o *options) {
+		o.equal.sameAliasing = true
+	}
+}
+
+// CSVHeader makes CSVEquals read the first record as a header, and compare
+// the other records by column name, so that column order does not matter.
+func CSVHeader() Option {
+	return func(o *options) {
+		o.csvHeader = true
+	}
+}
+
+// TrimSpace ignores the leading and trailing spaces of compared values,
+// such as CSV fields.
+func TrimSpace() Option {
+	return func(o *options) {
+		o.trimSpace = true
+	}
+}
+
+// NumberTolerance makes JSONEq consider numbers equal when they differ
+// by at most delta, instead of requiring the same decimal value.
+func NumberTolerance(delta float64) Option {
+	return func(o *options) {
+		o.numberTolerance = delta
+	}
+}
+
+// UnorderedArrays makes JSONEq ignore the order of array elements, which
+// are compared as multisets. With a key, object elements are matched by
+// the value of that field, and their differences are reported by key:
+//
+//	assert.JSONEq(t, body, expected, assert.UnorderedArrays("id"))
+//	// Note: $.users[id=2].name: expected "eve", got "bob"
+//
+// Elements without the key field are matched by value.
+func UnorderedArrays(key ...string) Option {
+	return func(o *options) {
+		o.unorderedArrays = true
+		if len(key) > 0 {
+			o.arrayKey = key[0]
+		}
+	}
+}
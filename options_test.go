@@ -0,0 +1,62 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		o := newOptions(nil)
+
+		if o.messages() != nil {
+			t.Errorf("messages() = %v, want nil", o.messages())
+		}
+		if o.equal.nilEqualsEmpty || o.equal.ignoreAllUnexported {
+			t.Error("newOptions() relaxed comparison by default")
+		}
+	})
+
+	t.Run("applied in order", func(t *testing.T) {
+		o := newOptions([]Option{Message("first"), Message("second"), NilEqualsEmpty()})
+
+		if got := o.messages(); len(got) != 1 || got[0] != "second" {
+			t.Errorf("messages() = %v, want [second]", got)
+		}
+		if !o.equal.nilEqualsEmpty {
+			t.Error("NilEqualsEmpty() was not applied")
+		}
+	})
+
+	t.Run("ignore unexported types", func(t *testing.T) {
+		o := newOptions([]Option{IgnoreUnexported(profile{}, &counter{})})
+
+		for _, typ := range []reflect.Type{reflect.TypeOf(profile{}), reflect.TypeOf(counter{})} {
+			if !o.equal.ignoreUnexported[typ] {
+				t.Errorf("IgnoreUnexported() did not register %v", typ)
+			}
+		}
+		if o.equal.ignoreAllUnexported {
+			t.Error("IgnoreUnexported() with types ignored all structs")
+		}
+	})
+
+	t.Run("ignore all unexported", func(t *testing.T) {
+		o := newOptions([]Option{IgnoreUnexported()})
+
+		if !o.equal.ignoreAllUnexported {
+			t.Error("IgnoreUnexported() without types did not ignore all structs")
+		}
+	})
+
+	t.Run("document options", func(t *testing.T) {
+		o := newOptions([]Option{CSVHeader(), TrimSpace()})
+
+		if !o.csvHeader || !o.trimSpace {
+			t.Error("CSVHeader() and TrimSpace() were not applied")
+		}
+	})
+}
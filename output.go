@@ -0,0 +1,168 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// minWrapWidth is the minimum width of wrapped values.
+	minWrapWidth = 20
+
+	// sideBySideWidth is the minimum output width showing expected
+	// and actual values side by side, when they do not fit on a line.
+	sideBySideWidth = 100
+)
+
+var (
+	outputWidthMu      sync.Mutex
+	outputWidthSet     bool
+	outputWidthColumns int
+)
+
+// SetOutputWidth sets the width, in columns, of failure messages.
+// Compared values too long for the width are wrapped: side by side when
+// the width is at least 100 columns, so that the differing parts face
+// each other, and stacked otherwise. A width of 0 never wraps values.
+// It returns the previous setting.
+//
+// Without a call, the width is read from the COLUMNS environment
+// variable, which shells set for interactive sessions; values are not
+// wrapped when it is not exported:
+//
+//	COLUMNS=$COLUMNS go test ./...
+func SetOutputWidth(columns int) int {
+	if columns < 0 {
+		columns = 0
+	}
+	prev := outputWidth()
+
+	outputWidthMu.Lock()
+	defer outputWidthMu.Unlock()
+
+	outputWidthSet, outputWidthColumns = true, columns
+	return prev
+}
+
+// outputWidth returns the width of failure messages, or 0 when unknown.
+func outputWidth() int {
+	outputWidthMu.Lock()
+	set, columns := outputWidthSet, outputWidthColumns
+	outputWidthMu.Unlock()
+
+	if set {
+		return columns
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// writeCompared writes the expected and actual lines of a failure
+// message to b, laid out for the given width.
+func writeCompared(b *bytes.Buffer, labels failureLabels, expectedType, expected, actualType, actual string, width int) {
+	if width <= 0 || (comparedWidth(labels.expected, expectedType, expected) <= width &&
+		comparedWidth(labels.actual, actualType, actual) <= width) {
+		b.WriteByte('\n')
+		writeComparedLine(b, labels.expected, expectedType, expected)
+		writeComparedLine(b, labels.actual, actualType, actual)
+		return
+	}
+	if width >= sideBySideWidth {
+		writeSideBySide(b, labels, expectedType, expected, actualType, actual, width)
+		return
+	}
+
+	indent := strings.Repeat(" ", runeCount(labels.expected)+1)
+	size := maxInt(width-len(indent), minWrapWidth)
+
+	b.WriteByte('\n')
+	writeComparedLine(b, labels.expected, expectedType, "")
+	for _, line := range wrapLine(expected, size) {
+		b.WriteString(indent + line + "\n")
+	}
+	writeComparedLine(b, labels.actual, actualType, "")
+	for _, line := range wrapLine(actual, size) {
+		b.WriteString(indent + line + "\n")
+	}
+}
+
+// writeComparedLine writes a line such as "Expected: (int) 42" to b,
+// leaving out the value when empty.
+func writeComparedLine(b *bytes.Buffer, label, typ, value string) {
+	b.WriteString(label)
+	b.WriteString(" (")
+	b.WriteString(typ)
+	b.WriteByte(')')
+	if value != "" {
+		b.WriteByte(' ')
+		b.WriteString(value)
+	}
+	b.WriteByte('\n')
+}
+
+// comparedWidth returns the width of the line written by writeComparedLine.
+func comparedWidth(label, typ, value string) int {
+	return runeCount(label) + runeCount(typ) + runeCount(value) + 4
+}
+
+// writeSideBySide lays out the compared values in two columns, whose
+// differing rows are separated by "≠" instead of "|".
+func writeSideBySide(b *bytes.Buffer, labels failureLabels, expectedType, expected, actualType, actual string, width int) {
+	size := (width - 3) / 2
+	left := wrapLine(expected, size)
+	right := wrapLine(actual, size)
+
+	b.WriteString("\n")
+	writeColumns(b, strings.TrimSpace(labels.expected)+" ("+expectedType+")", " | ",
+		strings.TrimSpace(labels.actual)+" ("+actualType+")", size)
+
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		sep := " | "
+		if l != r {
+			sep = " ≠ "
+		}
+		writeColumns(b, l, sep, r, size)
+	}
+}
+
+// writeColumns writes a row of two columns, the left one padded to size.
+func writeColumns(b *bytes.Buffer, left, sep, right string, size int) {
+	b.WriteString(left)
+	b.WriteString(strings.Repeat(" ", maxInt(size-runeCount(left), 0)))
+	b.WriteString(strings.TrimRight(sep+right, " "))
+	b.WriteString("\n")
+}
+
+// wrapLine splits s into lines of at most size runes.
+func wrapLine(s string, size int) []string {
+	var lines []string
+	start, n := 0, 0
+	for i := range s {
+		if n == size {
+			lines = append(lines, s[start:i])
+			start, n = i, 0
+		}
+		n++
+	}
+	return append(lines, s[start:])
+}
+
+func runeCount(s string) int {
+	return utf8.RuneCountInString(s)
+}
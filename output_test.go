@@ -0,0 +1,168 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// formatCompared returns the lines written by writeCompared.
+func formatCompared(expectedType, expected, actualType, actual string, width int) string {
+	var b bytes.Buffer
+	writeCompared(&b, newFailureLabels(), expectedType, expected, actualType, actual, width)
+	return b.String()
+}
+
+func TestWriteCompared(t *testing.T) {
+	long := `"` + strings.Repeat("a", 30) + `"`
+	other := `"` + strings.Repeat("a", 20) + "b" + strings.Repeat("a", 9) + `"`
+
+	tests := []struct {
+		name     string
+		typ      string
+		expected string
+		actual   string
+		width    int
+		want     string
+	}{
+		{
+			name:     "unknown width",
+			typ:      "string",
+			expected: long,
+			actual:   other,
+			want:     "\nExpected: (string) " + long + "\n  Actual: (string) " + other + "\n",
+		},
+		{
+			name:     "values fit",
+			typ:      "int",
+			expected: "1",
+			actual:   "2",
+			width:    20,
+			want:     "\nExpected: (int) 1\n  Actual: (int) 2\n",
+		},
+		{
+			name:     "stacked",
+			typ:      "string",
+			expected: long,
+			actual:   other,
+			width:    30,
+			want: "\nExpected: (string)\n" +
+				`          "aaaaaaaaaaaaaaaaaaa` + "\n" +
+				`          aaaaaaaaaaa"` + "\n" +
+				"  Actual: (string)\n" +
+				`          "aaaaaaaaaaaaaaaaaaa` + "\n" +
+				`          abaaaaaaaaa"` + "\n",
+		},
+		{
+			name:     "minimum wrap width",
+			typ:      "string",
+			expected: long,
+			actual:   "1",
+			width:    12,
+			want: "\nExpected: (string)\n" +
+				`          "aaaaaaaaaaaaaaaaaaa` + "\n" +
+				`          aaaaaaaaaaa"` + "\n" +
+				"  Actual: (string)\n" +
+				"          1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCompared(tt.typ, tt.expected, tt.typ, tt.actual, tt.width); got != tt.want {
+				t.Errorf("writeCompared() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("side by side", func(t *testing.T) {
+		x := strings.Repeat("x", 49)
+		expected := `"` + x + x + "xx" + `"`
+		actual := `"` + x + "x" + "y" + x + `"`
+
+		want := "\n" +
+			"Expected: (string)" + strings.Repeat(" ", 32) + " | Actual: (string)\n" +
+			`"` + x + " | " + `"` + x + "\n" +
+			"x" + x + " ≠ " + "xy" + x[:48] + "\n" +
+			`x"` + strings.Repeat(" ", 48) + ` | x"` + "\n"
+		if got := formatCompared("string", expected, "string", actual, 103); got != want {
+			t.Errorf("writeCompared() =\n%s\nwant:\n%s", got, want)
+		}
+	})
+}
+
+func TestWrapLine(t *testing.T) {
+	tests := []struct {
+		s    string
+		size int
+		want []string
+	}{
+		{"", 3, []string{""}},
+		{"abc", 3, []string{"abc"}},
+		{"abcdefg", 3, []string{"abc", "def", "g"}},
+		{"éàüöç", 2, []string{"éà", "üö", "ç"}},
+		{"abcdef", 3, []string{"abc", "def"}},
+		{"a\xffb", 1, []string{"a", "\xff", "b"}},
+	}
+
+	for _, tt := range tests {
+		if got := wrapLine(tt.s, tt.size); !equalSlices(got, tt.want) {
+			t.Errorf("wrapLine(%q, %d) = %q, want %q", tt.s, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestSetOutputWidth(t *testing.T) {
+	defer SetOutputWidth(SetOutputWidth(0))
+
+	t.Run("wraps failures", func(t *testing.T) {
+		SetOutputWidth(40)
+		rec := NewTestRecorder(t)
+
+		Equal(rec, strings.Repeat("a", 40), "b")
+
+		if !strings.Contains(rec.ErrorMessage(), "Expected: (string)\n          \"b\"\n") {
+			t.Errorf("Equal() message not wrapped\ngot: %s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("previous setting", func(t *testing.T) {
+		SetOutputWidth(80)
+
+		if got := SetOutputWidth(-1); got != 80 {
+			t.Errorf("SetOutputWidth() = %d, want 80", got)
+		}
+		if got := outputWidth(); got != 0 {
+			t.Errorf("outputWidth() = %d, want 0", got)
+		}
+	})
+
+	t.Run("COLUMNS", func(t *testing.T) {
+		t.Setenv("COLUMNS", "120")
+
+		outputWidthMu.Lock()
+		set := outputWidthSet
+		outputWidthSet = false
+		outputWidthMu.Unlock()
+		defer func() {
+			outputWidthMu.Lock()
+			outputWidthSet = set
+			outputWidthMu.Unlock()
+		}()
+
+		if got := outputWidth(); got != 120 {
+			t.Errorf("outputWidth() = %d, want 120", got)
+		}
+	})
+}
+
+func BenchmarkWrapLine(b *testing.B) {
+	s := strings.Repeat("é", 1<<20)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		wrapLine(s, 80)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// allocRuns is the number of runs averaged when measuring allocations.
+const allocRuns = 100
+
+// Allocates checks that fn allocates at most maxAllocs times.
+// On failure, the measured count and how far it exceeds the limit are reported.
+// Use it to pin the allocations of constructors or hot paths:
+//
+//	assert.Allocates(t, 1, func() { NewBuffer() })
+func Allocates(t testing.TB, maxAllocs int, fn func(), msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	allocs := int(testing.AllocsPerRun(allocRuns, fn))
+	if allocs > maxAllocs {
+		failCompare[any](t,
+			fmt.Sprintf("%d allocs (%d over)", allocs, allocs-maxAllocs),
+			fmt.Sprintf("at most %d allocs", maxAllocs),
+			msg...,
+		)
+	}
+}
+
+// MaxAllocsPerRun checks that fn allocates at most max times per run,
+// on average, as measured by testing.AllocsPerRun.
+func MaxAllocsPerRun(t testing.TB, max float64, fn func(), msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	allocs := testing.AllocsPerRun(allocRuns, fn)
+	if allocs > max {
+		failCompare[any](t,
+			fmt.Sprintf("%v allocs per run", allocs),
+			fmt.Sprintf("<= %v allocs per run", max),
+			msg...,
+		)
+	}
+}
+
+// RunsWithin checks that fn completes within the given duration.
+// The measured duration is reported on failure. It measures the real
+// execution time of fn, so a Clock set with SetClock does not apply.
+func RunsWithin(t testing.TB, d time.Duration, fn func(), msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+
+	if elapsed > d {
+		failCompare[any](t,
+			fmt.Sprintf("completed in %v", elapsed),
+			fmt.Sprintf("completion within %v", d),
+			msg...,
+		)
+	}
+}
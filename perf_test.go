@@ -0,0 +1,102 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// sink prevents the compiler from optimizing allocations away.
+var sink []byte
+
+func TestAllocates(t *testing.T) {
+	t.Run("exact allocation count", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Allocates(rec, 1, func() { sink = make([]byte, 64) })
+
+		if rec.HasError() {
+			t.Errorf("Allocates() reported %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("reports measured count and delta", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Allocates(rec, 0, func() {
+			sink = make([]byte, 64)
+			sink = make([]byte, 128)
+		})
+
+		for _, part := range []string{"2 allocs (2 over)", "at most 0 allocs"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Allocates() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestMaxAllocsPerRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       float64
+		fn        func()
+		wantError bool
+	}{
+		{
+			name:      "no allocation",
+			max:       0,
+			fn:        func() {},
+			wantError: false,
+		},
+		{
+			name:      "allocation within limit",
+			max:       1,
+			fn:        func() { sink = make([]byte, 64) },
+			wantError: false,
+		},
+		{
+			name:      "allocation over limit",
+			max:       0,
+			fn:        func() { sink = make([]byte, 64) },
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			MaxAllocsPerRun(rec, tt.max, tt.fn)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("MaxAllocsPerRun() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRunsWithin(t *testing.T) {
+	t.Run("fast function", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RunsWithin(rec, time.Second, func() {})
+
+		if rec.HasError() {
+			t.Errorf("RunsWithin() reported %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("slow function", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RunsWithin(rec, time.Millisecond, func() { time.Sleep(10 * time.Millisecond) })
+
+		if !rec.HasError() || !strings.Contains(rec.ErrorMessage(), "completion within 1ms") {
+			t.Errorf("RunsWithin() reported %q", rec.ErrorMessage())
+		}
+	})
+}
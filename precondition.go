@@ -0,0 +1,99 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// SkipUnlessEnv skips the test unless all the given environment variables
+// are set to a non-empty value, and returns the value of the first one.
+// It gates integration tests on the configuration they need:
+//
+//	dsn := assert.SkipUnlessEnv(t, "DATABASE_URL")
+func SkipUnlessEnv(t testing.TB, name string, more ...string) string {
+	t.Helper()
+
+	var missing []string
+	for _, n := range append([]string{name}, more...) {
+		if os.Getenv(n) == "" {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		t.Skipf("skipping: environment variable %s not set", strings.Join(missing, ", "))
+	}
+	return os.Getenv(name)
+}
+
+// networkProbeAddr is the address dialed by SkipUnlessNetwork by default.
+const networkProbeAddr = "go.dev:443"
+
+// networkProbeTimeout is the time allowed to a network probe.
+const networkProbeTimeout = 3 * time.Second
+
+var (
+	networkProbes   = map[string]error{}
+	networkProbesMu sync.Mutex
+)
+
+// SkipUnlessNetwork skips the test unless TCP connections can be made to
+// the given addresses, or to go.dev:443 when none are given. The result
+// of each probe is cached for the whole test binary:
+//
+//	assert.SkipUnlessNetwork(t)
+//	assert.SkipUnlessNetwork(t, "registry.internal:5000")
+func SkipUnlessNetwork(t testing.TB, addrs ...string) {
+	t.Helper()
+
+	if len(addrs) == 0 {
+		addrs = []string{networkProbeAddr}
+	}
+	for _, addr := range addrs {
+		if err := probeNetwork(addr); err != nil {
+			t.Skipf("skipping: network unavailable: %v", err)
+		}
+	}
+}
+
+// probeNetwork dials addr once per test binary and returns the result.
+func probeNetwork(addr string) error {
+	networkProbesMu.Lock()
+	defer networkProbesMu.Unlock()
+
+	if err, ok := networkProbes[addr]; ok {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, networkProbeTimeout)
+	if err == nil {
+		_ = conn.Close()
+	}
+	networkProbes[addr] = err
+
+	return err
+}
+
+// RequireCommand fails the test immediately unless the named command is
+// found in the PATH, and returns its path. Unlike the skip helpers, it is
+// meant for tools the test suite cannot run without:
+//
+//	docker := assert.RequireCommand(t, "docker")
+func RequireCommand(t testing.TB, name string) string {
+	t.Helper()
+	defer observe(t)()
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		failCompare(t, err.Error(), "command "+name+" in PATH", "required command not found")
+		t.FailNow()
+	}
+	return path
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSkipUnlessEnv(t *testing.T) {
+	t.Setenv("ASSERT_TEST_SET", "value")
+	t.Setenv("ASSERT_TEST_EMPTY", "")
+
+	tests := []struct {
+		name     string
+		vars     []string
+		wantSkip bool
+		wantLog  string
+	}{
+		{"set", []string{"ASSERT_TEST_SET"}, false, ""},
+		{"empty", []string{"ASSERT_TEST_EMPTY"}, true, "ASSERT_TEST_EMPTY not set"},
+		{"one of several missing", []string{"ASSERT_TEST_SET", "ASSERT_TEST_UNSET"}, true, "ASSERT_TEST_UNSET not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			var got string
+
+			rec.Call(func() { got = SkipUnlessEnv(rec, tt.vars[0], tt.vars[1:]...) })
+
+			if rec.Skipped() != tt.wantSkip {
+				t.Errorf("SkipUnlessEnv() skipped = %v, want %v", rec.Skipped(), tt.wantSkip)
+			}
+			if !tt.wantSkip && got != "value" {
+				t.Errorf("SkipUnlessEnv() = %q, want %q", got, "value")
+			}
+			if !strings.Contains(strings.Join(rec.Logs(), "\n"), tt.wantLog) {
+				t.Errorf("SkipUnlessEnv() logs = %q, want %q", rec.Logs(), tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestSkipUnlessNetwork(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	open := ln.Addr().String()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := closed.Addr().String()
+	_ = closed.Close()
+
+	t.Run("reachable", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { SkipUnlessNetwork(rec, open) })
+
+		if rec.Skipped() {
+			t.Error("SkipUnlessNetwork() skipped with a reachable address")
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { SkipUnlessNetwork(rec, open, unreachable) })
+
+		if !rec.Skipped() {
+			t.Error("SkipUnlessNetwork() did not skip with an unreachable address")
+		}
+	})
+
+	t.Run("probes are cached", func(t *testing.T) {
+		_ = ln.Close()
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { SkipUnlessNetwork(rec, open) })
+
+		if rec.Skipped() {
+			t.Error("SkipUnlessNetwork() probed a cached address again")
+		}
+	})
+}
+
+func TestRequireCommand(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		path := RequireCommand(rec, os.Args[0])
+
+		if rec.HasError() || path == "" {
+			t.Errorf("RequireCommand() = %q\n%s", path, rec.ErrorMessage())
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		reached := false
+
+		rec.Call(func() {
+			RequireCommand(rec, "assert-no-such-command")
+			reached = true
+		})
+
+		if reached {
+			t.Error("RequireCommand() did not stop the test")
+		}
+		if !strings.Contains(rec.ErrorMessage(), "required command not found") {
+			t.Errorf("RequireCommand() message missing reason\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// ForAll checks that a property holds for values produced by a generator.
+// The property is evaluated for the given number of iterations, and the first
+// failing input is reported together with the seed used to produce it,
+// the seed of the test as returned by Seed.
+func ForAll[T any](t testing.TB, generator func(*rand.Rand) T, property func(T) bool, iterations int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	seed := Seed(t)
+	r := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < iterations; i++ {
+		input := generator(r)
+
+		if !property(input) {
+			failCompare[any](t, input, "property to hold",
+				withMessage(fmt.Sprintf("property failed at iteration %d (seed %d)", i+1, seed), msg)...)
+			return
+		}
+	}
+}
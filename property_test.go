@@ -0,0 +1,66 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestForAll(t *testing.T) {
+	genInt := func(r *rand.Rand) int { return r.Intn(100) }
+
+	tests := []struct {
+		name      string
+		property  func(int) bool
+		wantError bool
+	}{
+		{
+			name:      "property always holds",
+			property:  func(n int) bool { return n >= 0 && n < 100 },
+			wantError: false,
+		},
+		{
+			name:      "property never holds",
+			property:  func(n int) bool { return n < 0 },
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			ForAll(rec, genInt, tt.property, 50)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ForAll() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+
+	t.Run("failure reports seed and iteration", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		ForAll(rec, genInt, func(int) bool { return false }, 10)
+
+		for _, part := range []string{"iteration 1", "seed"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("ForAll() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("zero iterations", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		calls := 0
+
+		ForAll(rec, func(*rand.Rand) int { calls++; return 0 }, func(int) bool { return false }, 0)
+
+		if rec.HasError() || calls != 0 {
+			t.Errorf("ForAll() ran %d iterations, want 0", calls)
+		}
+	})
+}
@@ -0,0 +1,694 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoassert compares protobuf messages generated by protoc-gen-go.
+// Messages are read through the protobuf struct tags of the generated code,
+// so the package does not depend on the protobuf runtime. Fields are named
+// by their proto name, and fields left at their default value are the same
+// as unset fields, as with proto.Equal:
+//
+//	protoassert.EqualIgnoringFields(t, got, want, "created_at", "trace_id")
+//	protoassert.EqualUnmarshaledJSON(t, body, want, "created_at")
+//
+// Differences are reported by path in the JSON mapping of the messages,
+// as in "$.user.name: expected "alice", got "bob"". Timestamp and Duration
+// are compared in their JSON string form. Other well-known types, and
+// extensions, are compared as regular messages.
+package protoassert
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nanoninja/assert"
+)
+
+// EqualIgnoringFields checks that two messages are equal, except for the
+// given fields, which are ignored in the messages and in all their nested
+// messages. A field is named by its proto name, its JSON name or the name
+// of its Go field:
+//
+//	protoassert.EqualIgnoringFields(t, got, want, "created_at", "trace_id")
+//
+// Unknown fields and the internal state of the messages are not compared.
+func EqualIgnoringFields[T any](t testing.TB, actual, expected T, fields ...string) {
+	t.Helper()
+
+	ignored := newFieldSet(fields)
+	e, err := messageJSON(reflect.ValueOf(expected), ignored)
+	if err != nil {
+		assert.NoError(t, err, "invalid expected message")
+		return
+	}
+	a, err := messageJSON(reflect.ValueOf(actual), ignored)
+	if err != nil {
+		assert.NoError(t, err, "invalid actual message")
+		return
+	}
+	assert.JSONEq(t, a, e, assert.Message("messages differ"))
+}
+
+// EqualUnmarshaledJSON checks that a JSON document, as produced by protojson,
+// decodes into a message equal to expected, except for the given fields.
+// Fields are matched by their proto name or their JSON name, 64-bit integers
+// may be strings or numbers, enums may be names or numbers, and fields set
+// to null or to their default value are the same as missing fields:
+//
+//	protoassert.EqualUnmarshaledJSON(t, body, &pb.User{Name: "alice"}, "created_at")
+//
+// The members of a oneof are only known when they are set in expected.
+func EqualUnmarshaledJSON[T any](t testing.TB, actual string, expected T, fields ...string) {
+	t.Helper()
+
+	ignored := newFieldSet(fields)
+	ev := reflect.ValueOf(expected)
+	e, err := messageJSON(ev, ignored)
+	if err != nil {
+		assert.NoError(t, err, "invalid expected message")
+		return
+	}
+	a, err := unmarshalJSON(actual, ev, ignored)
+	if err != nil {
+		assert.NoError(t, err, "invalid actual JSON")
+		return
+	}
+	assert.JSONEq(t, a, e, assert.Message("messages differ"))
+}
+
+// unmarshalJSON returns the JSON mapping of the message of the type of
+// expected decoded from doc, in the form of messageJSON.
+func unmarshalJSON(doc string, expected reflect.Value, ignored fieldSet) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	if dec.More() {
+		return "", errors.New("unexpected data after top-level value")
+	}
+
+	elem := reflect.Zero(expected.Type().Elem())
+	if !expected.IsNil() {
+		elem = expected.Elem()
+	}
+	m, err := decodeMessage(v, elem, ignored, "$")
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// fieldSet holds the names of the ignored fields.
+type fieldSet map[string]bool
+
+// newFieldSet returns the set of the given names.
+func newFieldSet(names []string) fieldSet {
+	s := make(fieldSet, len(names))
+	for _, name := range names {
+		s[name] = true
+	}
+	return s
+}
+
+// has reports whether field f is in the set, by any of its names.
+func (s fieldSet) has(f field) bool {
+	return s[f.name] || s[f.jsonName] || s[f.goName]
+}
+
+// field describes a field of a generated message, from its protobuf tag.
+type field struct {
+	name     string
+	jsonName string
+	goName   string
+	index    int
+	typ      reflect.Type
+	// presence is set for the members of a oneof, which are set
+	// even when they hold their default value.
+	presence bool
+}
+
+// parseField returns the field described by the protobuf tag of sf.
+// The tag holds the wire type, the field number, the cardinality and
+// key=value pairs, as in "bytes,1,opt,name=created_at,json=createdAt,proto3".
+func parseField(sf reflect.StructField, index int) (field, bool) {
+	tag, ok := sf.Tag.Lookup("protobuf")
+	if !ok {
+		return field{}, false
+	}
+	f := field{goName: sf.Name, index: index, typ: sf.Type}
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case strings.HasPrefix(part, "name="):
+			f.name = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "json="):
+			f.jsonName = strings.TrimPrefix(part, "json=")
+		}
+	}
+	if f.name == "" {
+		return field{}, false
+	}
+	if f.jsonName == "" {
+		f.jsonName = f.name
+	}
+	return f, true
+}
+
+// messageJSON returns the JSON mapping of a message, given as a pointer
+// to a generated struct, without the ignored fields.
+func messageJSON(v reflect.Value, ignored fieldSet) (string, error) {
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("%s is not a message", v.Type())
+	}
+	var m map[string]any
+	if !v.IsNil() {
+		var err error
+		if m, err = encodeMessage(v.Elem(), ignored); err != nil {
+			return "", err
+		}
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// encodeMessage returns the JSON object of the set fields of message v,
+// keyed by their proto name.
+func encodeMessage(v reflect.Value, ignored fieldSet) (map[string]any, error) {
+	m := make(map[string]any)
+	for _, f := range messageFields(v) {
+		if ignored.has(f) {
+			continue
+		}
+		fv := v.Field(f.index)
+		if f.presence {
+			// The member of a oneof is the only field of its wrapper.
+			fv = fv.Elem().Elem().Field(0)
+		}
+		value, ok, err := encodeValue(fv, ignored, f.presence)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		if ok {
+			m[f.name] = value
+		}
+	}
+	return m, nil
+}
+
+// messageFields returns the fields of message v, with the set members
+// of its oneofs. Unexported fields, like the internal state of the
+// message and its unknown fields, are left out.
+func messageFields(v reflect.Value) []field {
+	var fields []field
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if _, ok := sf.Tag.Lookup("protobuf_oneof"); ok {
+			w := v.Field(i)
+			if w.IsNil() || w.Elem().Kind() != reflect.Ptr || w.Elem().IsNil() {
+				continue
+			}
+			if f, ok := parseField(w.Elem().Elem().Type().Field(0), i); ok {
+				f.presence = true
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if f, ok := parseField(sf, i); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// encodeValue returns the JSON value of a field. It reports false for
+// the fields to leave out: unset fields and, unless presence is set,
+// fields holding their default value.
+func encodeValue(v reflect.Value, ignored fieldSet, presence bool) (any, bool, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			// Scalar field with explicit presence.
+			return encodeValue(v.Elem(), ignored, true)
+		}
+		if s, ok, err := encodeWellKnown(v.Elem()); ok {
+			return s, true, err
+		}
+		m, err := encodeMessage(v.Elem(), ignored)
+		return m, true, err
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Len() == 0 && !presence {
+				return nil, false, nil
+			}
+			return base64.StdEncoding.EncodeToString(v.Bytes()), true, nil
+		}
+		if v.Len() == 0 {
+			return nil, false, nil
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			value, _, err := encodeValue(v.Index(i), ignored, true)
+			if err != nil {
+				return nil, false, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list[i] = value
+		}
+		return list, true, nil
+
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, false, nil
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			value, _, err := encodeValue(iter.Value(), ignored, true)
+			if err != nil {
+				return nil, false, fmt.Errorf("[%s]: %w", key, err)
+			}
+			m[key] = value
+		}
+		return m, true, nil
+	}
+
+	if v.IsZero() && !presence {
+		return nil, false, nil
+	}
+	switch v.Kind() {
+	case reflect.Bool, reflect.String:
+		return v.Interface(), true, nil
+	case reflect.Int32, reflect.Int64:
+		if isEnum(v.Type()) {
+			// Enums are named by their value.
+			return v.Interface().(fmt.Stringer).String(), true, nil
+		}
+		return json.Number(strconv.FormatInt(v.Int(), 10)), true, nil
+	case reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(v.Uint(), 10)), true, nil
+	case reflect.Float32, reflect.Float64:
+		return encodeFloat(v.Float(), v.Type().Bits()), true, nil
+	}
+	return nil, false, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// encodeFloat returns the JSON value of a float, where the values
+// which are not numbers are strings.
+func encodeFloat(f float64, bits int) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, bits))
+}
+
+// wellKnown returns the name of the well-known type with a JSON string
+// form that v is, Timestamp or Duration, or "" for other messages.
+func wellKnown(typ reflect.Type) string {
+	name := typ.Name()
+	if name != "Timestamp" && name != "Duration" {
+		return ""
+	}
+	seconds, ok := typ.FieldByName("Seconds")
+	if !ok || seconds.Type.Kind() != reflect.Int64 {
+		return ""
+	}
+	nanos, ok := typ.FieldByName("Nanos")
+	if !ok || nanos.Type.Kind() != reflect.Int32 {
+		return ""
+	}
+	return name
+}
+
+// encodeWellKnown returns the JSON string of a Timestamp or a Duration,
+// and reports false for other messages.
+func encodeWellKnown(v reflect.Value) (string, bool, error) {
+	kind := wellKnown(v.Type())
+	if kind == "" {
+		return "", false, nil
+	}
+	seconds := v.FieldByName("Seconds").Int()
+	nanos := v.FieldByName("Nanos").Int()
+	if kind == "Timestamp" {
+		return time.Unix(seconds, nanos).UTC().Format(time.RFC3339Nano), true, nil
+	}
+	return formatDuration(seconds, nanos), true, nil
+}
+
+// formatDuration formats a duration as protojson does, in seconds
+// with 0, 3, 6 or 9 fractional digits, as in "1.500s".
+func formatDuration(seconds, nanos int64) string {
+	sign := ""
+	if seconds < 0 || nanos < 0 {
+		sign = "-"
+		seconds, nanos = -seconds, -nanos
+	}
+	s := sign + strconv.FormatInt(seconds, 10)
+	if nanos != 0 {
+		frac := fmt.Sprintf("%09d", nanos)
+		for strings.HasSuffix(frac, "000") {
+			frac = frac[:len(frac)-3]
+		}
+		s += "." + frac
+	}
+	return s + "s"
+}
+
+// decodeMessage returns the JSON object of the message of type v.Type()
+// decoded from doc, in the form of encodeMessage. The oneof members set
+// in v, the expected message, are the only ones known.
+func decodeMessage(doc any, v reflect.Value, ignored fieldSet, path string) (map[string]any, error) {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected object, got %s", path, jsonKind(doc))
+	}
+
+	fields := make(map[string]field)
+	for _, f := range messageFields(v) {
+		fields[f.name] = f
+		fields[f.jsonName] = f
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	m := make(map[string]any)
+	for _, key := range keys {
+		f, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown field %q", path, key)
+		}
+		if ignored.has(f) || obj[key] == nil {
+			continue
+		}
+		typ, expected := f.typ, v.Field(f.index)
+		if f.presence {
+			expected = expected.Elem().Elem().Field(0)
+			typ = expected.Type()
+		}
+		value, ok, err := decodeValue(obj[key], typ, expected, ignored, path+"."+key, f.presence)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m[f.name] = value
+		}
+	}
+	return m, nil
+}
+
+// decodeValue returns the JSON value of a field of type typ decoded from
+// doc, in the form of encodeValue. The expected value of the field, which
+// may be invalid, gives the oneof members of the nested messages.
+func decodeValue(doc any, typ reflect.Type, expected reflect.Value, ignored fieldSet, path string, presence bool) (any, bool, error) {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if typ.Elem().Kind() != reflect.Struct {
+			return decodeValue(doc, typ.Elem(), reflect.Value{}, ignored, path, true)
+		}
+		if kind := wellKnown(typ.Elem()); kind != "" {
+			s, err := decodeWellKnown(doc, kind, path)
+			return s, err == nil, err
+		}
+		elem := reflect.Zero(typ.Elem())
+		if expected.IsValid() && !expected.IsNil() {
+			elem = expected.Elem()
+		}
+		m, err := decodeMessage(doc, elem, ignored, path)
+		return m, err == nil, err
+
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			s, ok := doc.(string)
+			if !ok {
+				return nil, false, fmt.Errorf("%s: expected base64 string, got %s", path, jsonKind(doc))
+			}
+			b, err := decodeBase64(s)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", path, err)
+			}
+			if len(b) == 0 && !presence {
+				return nil, false, nil
+			}
+			return base64.StdEncoding.EncodeToString(b), true, nil
+		}
+		list, ok := doc.([]any)
+		if !ok {
+			return nil, false, fmt.Errorf("%s: expected array, got %s", path, jsonKind(doc))
+		}
+		if len(list) == 0 {
+			return nil, false, nil
+		}
+		values := make([]any, len(list))
+		for i, item := range list {
+			var elem reflect.Value
+			if expected.IsValid() && i < expected.Len() {
+				elem = expected.Index(i)
+			}
+			value, _, err := decodeValue(item, typ.Elem(), elem, ignored, fmt.Sprintf("%s[%d]", path, i), true)
+			if err != nil {
+				return nil, false, err
+			}
+			values[i] = value
+		}
+		return values, true, nil
+
+	case reflect.Map:
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return nil, false, fmt.Errorf("%s: expected object, got %s", path, jsonKind(doc))
+		}
+		if len(obj) == 0 {
+			return nil, false, nil
+		}
+		m := make(map[string]any, len(obj))
+		for key, item := range obj {
+			k, err := scalarValue(key, typ.Key())
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: key %q: %w", path, key, err)
+			}
+			var elem reflect.Value
+			if expected.IsValid() {
+				elem = expected.MapIndex(k)
+			}
+			value, _, err := decodeValue(item, typ.Elem(), elem, ignored, path+"."+key, true)
+			if err != nil {
+				return nil, false, err
+			}
+			m[fmt.Sprint(k.Interface())] = value
+		}
+		return m, true, nil
+	}
+	return decodeScalar(doc, typ, path, presence)
+}
+
+// decodeScalar returns the JSON value of a scalar field of type typ
+// decoded from doc, in the form of encodeValue.
+func decodeScalar(doc any, typ reflect.Type, path string, presence bool) (any, bool, error) {
+	if s, ok := doc.(string); ok && isEnum(typ) {
+		// Enums given by name are kept as is.
+		return s, true, nil
+	}
+	v, err := scalarValue(doc, typ)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	return encodeValue(v, nil, presence)
+}
+
+// isEnum reports whether typ is a generated enum type.
+func isEnum(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Int32 && typ.PkgPath() != "" &&
+		typ.Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+}
+
+// scalarValue returns the value of type typ given by doc. Booleans, numbers
+// and the special float values may be given as strings, as in map keys.
+func scalarValue(doc any, typ reflect.Type) (reflect.Value, error) {
+	v := reflect.New(typ).Elem()
+	s, isString := doc.(string)
+	if n, ok := doc.(json.Number); ok {
+		s = string(n)
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		b, ok := doc.(bool)
+		if isString && (s == "true" || s == "false") {
+			b, ok = s == "true", true
+		}
+		if !ok {
+			return v, fmt.Errorf("expected boolean, got %s", jsonKind(doc))
+		}
+		v.SetBool(b)
+
+	case reflect.String:
+		if !isString {
+			return v, fmt.Errorf("expected string, got %s", jsonKind(doc))
+		}
+		v.SetString(s)
+
+	case reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			f, ok := integral(doc, s)
+			if !ok || v.OverflowInt(int64(f)) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return v, fmt.Errorf("expected %s, got %s", typ.Kind(), describe(doc, s))
+			}
+			i = int64(f)
+		}
+		v.SetInt(i)
+
+	case reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			f, ok := integral(doc, s)
+			if !ok || f < 0 || f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+				return v, fmt.Errorf("expected %s, got %s", typ.Kind(), describe(doc, s))
+			}
+			u = uint64(f)
+		}
+		v.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		var err error
+		switch {
+		case isString && s == "NaN":
+			f = math.NaN()
+		case isString && s == "Infinity":
+			f = math.Inf(1)
+		case isString && s == "-Infinity":
+			f = math.Inf(-1)
+		case s != "":
+			f, err = strconv.ParseFloat(s, typ.Bits())
+		default:
+			err = errors.New("invalid syntax")
+		}
+		if err != nil {
+			return v, fmt.Errorf("expected %s, got %s", typ.Kind(), describe(doc, s))
+		}
+		v.SetFloat(f)
+
+	default:
+		return v, fmt.Errorf("unsupported type %s", typ)
+	}
+	return v, nil
+}
+
+// integral returns the value of an integer given as a number or a string
+// written with a fraction or an exponent, as in "1e3", which protojson
+// accepts.
+func integral(doc any, s string) (float64, bool) {
+	switch doc.(type) {
+	case json.Number, string:
+	default:
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil && f == math.Trunc(f)
+}
+
+// describe returns the JSON value doc for an error message.
+func describe(doc any, s string) string {
+	switch doc.(type) {
+	case json.Number:
+		return s
+	case string:
+		return strconv.Quote(s)
+	}
+	return jsonKind(doc)
+}
+
+// decodeBase64 decodes bytes written in standard or URL base64,
+// with or without padding, as protojson accepts.
+func decodeBase64(s string) ([]byte, error) {
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	if len(s)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid base64 bytes")
+	}
+	return b, nil
+}
+
+// decodeWellKnown returns the JSON string of a Timestamp or a Duration
+// decoded from doc, in the form of encodeWellKnown.
+func decodeWellKnown(doc any, kind, path string) (string, error) {
+	s, ok := doc.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: expected %s string, got %s", path, kind, jsonKind(doc))
+	}
+	if kind == "Timestamp" {
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid Timestamp %q", path, s)
+		}
+		return ts.UTC().Format(time.RFC3339Nano), nil
+	}
+
+	secs, frac, _ := strings.Cut(strings.TrimSuffix(s, "s"), ".")
+	neg := strings.HasPrefix(secs, "-")
+	seconds, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil || !strings.HasSuffix(s, "s") || len(frac) > 9 {
+		return "", fmt.Errorf("%s: invalid Duration %q", path, s)
+	}
+	var nanos int64
+	if frac != "" {
+		if nanos, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64); err != nil || nanos < 0 {
+			return "", fmt.Errorf("%s: invalid Duration %q", path, s)
+		}
+	}
+	if neg {
+		nanos = -nanos
+	}
+	return formatDuration(seconds, nanos), nil
+}
+
+// jsonKind returns the name of the JSON type of a decoded value.
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
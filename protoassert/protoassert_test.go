@@ -0,0 +1,323 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package protoassert
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert/asserttest"
+)
+
+// The types below have the shape of the code generated by protoc-gen-go.
+
+type Status int32
+
+const (
+	Status_UNKNOWN Status = 0
+	Status_ACTIVE  Status = 1
+)
+
+func (s Status) String() string {
+	switch s {
+	case Status_UNKNOWN:
+		return "UNKNOWN"
+	case Status_ACTIVE:
+		return "ACTIVE"
+	}
+	return strconv.Itoa(int(s))
+}
+
+type Timestamp struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Seconds int64 `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Nanos   int32 `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+}
+
+type Duration struct {
+	Seconds int64 `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Nanos   int32 `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+}
+
+type Address struct {
+	City    string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	TraceId string `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+}
+
+type User struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Id        int64             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status    Status            `protobuf:"varint,3,opt,name=status,proto3,enum=test.Status" json:"status,omitempty"`
+	Score     float32           `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
+	Avatar    []byte            `protobuf:"bytes,5,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Tags      []string          `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels    map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Quotas    map[int32]uint64  `protobuf:"bytes,8,rep,name=quotas,proto3" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Address   *Address          `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
+	Nickname  *string           `protobuf:"bytes,10,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+	CreatedAt *Timestamp        `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Ttl       *Duration         `protobuf:"bytes,12,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	TraceId   string            `protobuf:"bytes,13,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Types that are assignable to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string `protobuf:"bytes,14,opt,name=email,proto3,oneof"`
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,15,opt,name=phone,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+
+func newUser() *User {
+	return &User{
+		Id:        9007199254740993,
+		Name:      "alice",
+		Status:    Status_ACTIVE,
+		Score:     0.1,
+		Avatar:    []byte{0xfb, 0xff},
+		Tags:      []string{"a", "b"},
+		Labels:    map[string]string{"team": "core"},
+		Quotas:    map[int32]uint64{1: 10},
+		Address:   &Address{City: "Paris", TraceId: "x"},
+		CreatedAt: &Timestamp{Seconds: 1700000000, Nanos: 500000000},
+		Ttl:       &Duration{Seconds: 1, Nanos: 500000000},
+		TraceId:   "abc",
+		Contact:   &User_Email{Email: "alice@example.com"},
+	}
+}
+
+func TestEqualIgnoringFields(t *testing.T) {
+	nickname := ""
+
+	tests := []struct {
+		name      string
+		actual    func(u *User)
+		fields    []string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:   "equal",
+			actual: func(u *User) {},
+		},
+		{
+			name:   "internal state",
+			actual: func(u *User) { u.sizeCache = 42; u.unknownFields = []byte{1} },
+		},
+		{
+			name:      "different field",
+			actual:    func(u *User) { u.Name = "bob" },
+			wantError: true,
+			wantParts: []string{"messages differ", `$.name: expected "alice", got "bob"`},
+		},
+		{
+			name:   "ignored fields",
+			actual: func(u *User) { u.CreatedAt = nil; u.TraceId = "def"; u.Address.TraceId = "y" },
+			fields: []string{"created_at", "trace_id"},
+		},
+		{
+			name:   "ignored by JSON or Go name",
+			actual: func(u *User) { u.CreatedAt = nil; u.TraceId = "def" },
+			fields: []string{"createdAt", "TraceId"},
+		},
+		{
+			name:      "ignored elsewhere only",
+			actual:    func(u *User) { u.TraceId = "def" },
+			fields:    []string{"created_at"},
+			wantError: true,
+			wantParts: []string{`$.trace_id: expected "abc", got "def"`},
+		},
+		{
+			name:      "nested field",
+			actual:    func(u *User) { u.Address.City = "Lyon" },
+			wantError: true,
+			wantParts: []string{`$.address.city: expected "Paris", got "Lyon"`},
+		},
+		{
+			name:      "unset and empty message",
+			actual:    func(u *User) { u.Address = &Address{} },
+			fields:    []string{"trace_id"},
+			wantError: true,
+			wantParts: []string{`$.address.city: missing, expected "Paris"`},
+		},
+		{
+			name:      "enum",
+			actual:    func(u *User) { u.Status = Status_UNKNOWN },
+			wantError: true,
+			wantParts: []string{`$.status: missing, expected "ACTIVE"`},
+		},
+		{
+			name:      "oneof",
+			actual:    func(u *User) { u.Contact = &User_Phone{Phone: "1"} },
+			wantError: true,
+			wantParts: []string{`$.email: missing, expected "alice@example.com"`, `$.phone: unexpected, got "1"`},
+		},
+		{
+			name:      "empty oneof member is set",
+			actual:    func(u *User) { u.Contact = &User_Email{} },
+			wantError: true,
+			wantParts: []string{`$.email: expected "alice@example.com", got ""`},
+		},
+		{
+			name:      "explicit presence",
+			actual:    func(u *User) { u.Nickname = &nickname },
+			wantError: true,
+			wantParts: []string{`$.nickname: unexpected, got ""`},
+		},
+		{
+			name:      "map entry",
+			actual:    func(u *User) { u.Quotas[1] = 11 },
+			wantError: true,
+			wantParts: []string{`$.quotas["1"]: expected 10, got 11`},
+		},
+		{
+			name:      "timestamp",
+			actual:    func(u *User) { u.CreatedAt.Nanos = 0 },
+			wantError: true,
+			wantParts: []string{`$.created_at: expected "2023-11-14T22:13:20.5Z", got "2023-11-14T22:13:20Z"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := asserttest.NewRecorder(t)
+			actual := newUser()
+			tt.actual(actual)
+
+			EqualIgnoringFields(rec, actual, newUser(), tt.fields...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualIgnoringFields() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualIgnoringFields() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+
+	t.Run("not a message", func(t *testing.T) {
+		rec := asserttest.NewRecorder(t)
+
+		EqualIgnoringFields(rec, User{}, User{})
+
+		if !strings.Contains(rec.ErrorMessage(), "protoassert.User is not a message") {
+			t.Errorf("EqualIgnoringFields() message missing reason\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestEqualUnmarshaledJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		fields    []string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name: "protojson output",
+			actual: `{
+				"id": "9007199254740993", "name": "alice", "status": "ACTIVE", "score": 0.1,
+				"avatar": "+/8=", "tags": ["a", "b"], "labels": {"team": "core"}, "quotas": {"1": "10"},
+				"address": {"city": "Paris", "traceId": "x"}, "createdAt": "2023-11-14T22:13:20.500Z",
+				"ttl": "1.500s", "traceId": "abc", "email": "alice@example.com"
+			}`,
+		},
+		{
+			name: "proto names and alternative forms",
+			actual: `{
+				"id": 9007199254740993, "name": "alice", "status": 1, "score": "0.1",
+				"avatar": "-_8", "tags": ["a", "b"], "labels": {"team": "core"}, "quotas": {"1": 1e1},
+				"address": {"city": "Paris", "trace_id": "x"}, "created_at": "2023-11-14T23:13:20.5+01:00",
+				"ttl": "1.5s", "trace_id": "abc", "email": "alice@example.com", "nickname": null
+			}`,
+		},
+		{
+			name:   "ignored fields",
+			actual: `{"id": "9007199254740993", "name": "alice", "status": "ACTIVE", "score": 0.1, "avatar": "+/8=", "tags": ["a", "b"], "labels": {"team": "core"}, "quotas": {"1": "10"}, "address": {"city": "Paris"}, "ttl": "1.5s", "email": "alice@example.com"}`,
+			fields: []string{"created_at", "trace_id"},
+		},
+		{
+			name:      "different values",
+			actual:    `{"id": "9007199254740992", "name": "alice", "status": "UNKNOWN", "email": "bob@example.com"}`,
+			fields:    []string{"score", "avatar", "tags", "labels", "quotas", "address", "created_at", "ttl", "trace_id"},
+			wantError: true,
+			wantParts: []string{
+				"$.id: expected 9007199254740993, got 9007199254740992",
+				`$.email: expected "alice@example.com", got "bob@example.com"`,
+				`$.status: expected "ACTIVE", got "UNKNOWN"`,
+			},
+		},
+		{
+			name:      "unknown field",
+			actual:    `{"phone": "1"}`,
+			wantError: true,
+			wantParts: []string{"invalid actual JSON", `$: unknown field \"phone\"`},
+		},
+		{
+			name:      "wrong type",
+			actual:    `{"address": {"city": 1}}`,
+			wantError: true,
+			wantParts: []string{"$.address.city: expected string, got number"},
+		},
+		{
+			name:      "integer overflow",
+			actual:    `{"quotas": {"1": -1}}`,
+			wantError: true,
+			wantParts: []string{"$.quotas.1: expected uint64, got -1"},
+		},
+		{
+			name:      "invalid timestamp",
+			actual:    `{"createdAt": "yesterday"}`,
+			wantError: true,
+			wantParts: []string{`$.createdAt: invalid Timestamp \"yesterday\"`},
+		},
+		{
+			name:      "invalid JSON",
+			actual:    `{"id": 1} x`,
+			wantError: true,
+			wantParts: []string{"invalid actual JSON"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := asserttest.NewRecorder(t)
+
+			EqualUnmarshaledJSON(rec, tt.actual, newUser(), tt.fields...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("EqualUnmarshaledJSON() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("EqualUnmarshaledJSON() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
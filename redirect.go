@@ -0,0 +1,117 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// maxRedirects is the number of redirects followed by RedirectChain,
+// as by the default http.Client.
+const maxRedirects = 10
+
+// RedirectsTo checks that resp is a redirect (3xx) whose Location header,
+// resolved against the request URL, is target:
+//
+//	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+//	    return http.ErrUseLastResponse
+//	}}
+//	resp, _ := client.Get(srv.URL + "/account")
+//	assert.RedirectsTo(t, resp, srv.URL+"/login?next=%2Faccount")
+func RedirectsTo(t testing.TB, resp *http.Response, target string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	if resp.StatusCode < 300 || resp.StatusCode > 399 {
+		failCompare(t, resp.Status, "redirect to "+target, withMessage("response is not a redirect", msg)...)
+		return
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		failCompare(t, resp.Header.Get("Location"), target, withMessage("invalid Location header: "+err.Error(), msg)...)
+		return
+	}
+	if actual := location.String(); actual != target {
+		failCompare(t, actual, target, withMessage("unexpected redirect location", msg)...)
+	}
+}
+
+// RedirectChain sends req with client, following redirects, and checks
+// that the URLs redirected to are expected, in order. Each hop is reported
+// with its status code on failure. The final response is returned, and
+// its body must be closed by the caller:
+//
+//	resp := assert.RedirectChain(t, http.DefaultClient, req, []string{
+//	    srv.URL + "/login",
+//	    srv.URL + "/login/sso",
+//	})
+//	defer resp.Body.Close()
+//
+// The client is not modified: its CheckRedirect policy, if any, still
+// applies, and its Jar receives the cookies set along the chain.
+func RedirectChain(t testing.TB, client *http.Client, req *http.Request, expected []string, msg ...string) *http.Response {
+	t.Helper()
+	defer observe(t)()
+
+	var hops []string
+	var targets []string
+
+	c := *client
+	c.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		hops = append(hops, fmt.Sprintf("%d %s", next.Response.StatusCode, next.URL))
+		targets = append(targets, next.URL.String())
+
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(next, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		reason := err.Error()
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			reason = ue.Err.Error()
+		}
+		failCompareNote(t, reason, "redirect chain", formatHops(hops), withMessage("request failed", msg)...)
+		return resp
+	}
+
+	if !sameHops(targets, expected) {
+		failCompareNote(t, targets, expected, formatHops(hops)+fmt.Sprintf(", then %d", resp.StatusCode),
+			withMessage("unexpected redirect chain", msg)...)
+	}
+	return resp
+}
+
+// formatHops formats the hops of a redirect chain.
+func formatHops(hops []string) string {
+	if len(hops) == 0 {
+		return "no redirect"
+	}
+	return "hops: " + strings.Join(hops, " -> ")
+}
+
+// sameHops reports whether the URLs redirected to are expected.
+func sameHops(actual, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// redirectServer serves a chain of redirects: /account to /login,
+// then /login to /login/sso with a 307 and a relative location, which serves the page.
+func redirectServer(t *testing.T) *Server {
+	return NewServer(t, map[string]Response{
+		"/account":   {Status: http.StatusFound, Header: http.Header{"Location": {"/login"}}},
+		"/login":     {Status: http.StatusTemporaryRedirect, Header: http.Header{"Location": {"login/sso"}}},
+		"/login/sso": {Body: "sign in"},
+		"/loop":      {Status: http.StatusFound, Header: http.Header{"Location": {"/loop"}}},
+	})
+}
+
+func TestRedirectsTo(t *testing.T) {
+	srv := redirectServer(t)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	tests := []struct {
+		name      string
+		path      string
+		target    string
+		wantError bool
+		wantPart  string
+	}{
+		{"absolute target", "/account", srv.URL + "/login", false, ""},
+		{"relative location", "/login", srv.URL + "/login/sso", false, ""},
+		{"other target", "/account", srv.URL + "/home", true, "unexpected redirect location"},
+		{"not a redirect", "/login/sso", srv.URL + "/login", true, "response is not a redirect"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			resp, err := client.Get(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			RedirectsTo(rec, resp, tt.target)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("RedirectsTo() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("RedirectsTo() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("invalid location", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		resp := &http.Response{
+			StatusCode: http.StatusFound,
+			Header:     http.Header{"Location": {"%zz"}},
+		}
+
+		RedirectsTo(rec, resp, srv.URL+"/login")
+
+		if !strings.Contains(rec.ErrorMessage(), "invalid Location header") {
+			t.Errorf("RedirectsTo() message missing invalid Location header\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestRedirectChain(t *testing.T) {
+	srv := redirectServer(t)
+
+	tests := []struct {
+		name      string
+		client    *http.Client
+		path      string
+		expected  []string
+		wantError bool
+		wantPart  string
+	}{
+		{
+			name:     "followed chain",
+			client:   &http.Client{},
+			path:     "/account",
+			expected: []string{srv.URL + "/login", srv.URL + "/login/sso"},
+		},
+		{
+			name:   "no redirect",
+			client: &http.Client{},
+			path:   "/login/sso",
+		},
+		{
+			name:      "different chain",
+			client:    &http.Client{},
+			path:      "/account",
+			expected:  []string{srv.URL + "/login/sso"},
+			wantError: true,
+			wantPart:  "hops: 302 " + srv.URL + "/login -> 307 " + srv.URL + "/login/sso, then 200",
+		},
+		{
+			name:      "redirect loop",
+			client:    &http.Client{},
+			path:      "/loop",
+			wantError: true,
+			wantPart:  "stopped after 10 redirects",
+		},
+		{
+			name: "client policy",
+			client: &http.Client{CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+				if len(via) > 1 {
+					return errors.New("too far")
+				}
+				return nil
+			}},
+			path:      "/account",
+			expected:  []string{srv.URL + "/login", srv.URL + "/login/sso"},
+			wantError: true,
+			wantPart:  "too far",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			req, _ := http.NewRequest("GET", srv.URL+tt.path, nil)
+			resp := RedirectChain(rec, tt.client, req, tt.expected)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("RedirectChain() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("RedirectChain() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("client unchanged", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		client := &http.Client{}
+
+		req, _ := http.NewRequest("GET", srv.URL+"/account", nil)
+		RedirectChain(rec, client, req, []string{srv.URL + "/login", srv.URL + "/login/sso"}).Body.Close()
+
+		if client.CheckRedirect != nil {
+			t.Error("RedirectChain() modified the client")
+		}
+	})
+}
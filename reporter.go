@@ -0,0 +1,205 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Reporter receives the failures of the assertions comparing values, those
+// showing an expected and an actual value, in addition to the test log.
+// It forwards them to CI systems, such as GitHubReporter. The failures
+// recorded by Check and by the attempts of Retry are not reported.
+type Reporter interface {
+	// Report receives a failure of the test t.
+	Report(t testing.TB, f Failure)
+}
+
+const (
+	// githubActionsEnv is set to "true" by GitHub Actions.
+	githubActionsEnv = "GITHUB_ACTIONS"
+	// githubAnnotationsEnv disables the annotations of GitHub Actions
+	// when set to a false value, such as "0" or "false".
+	githubAnnotationsEnv = "ASSERT_GITHUB_ANNOTATIONS"
+)
+
+var (
+	reporterMu sync.RWMutex
+	reporter   = defaultReporter()
+)
+
+// defaultReporter returns a GitHubReporter writing to the standard output
+// when running on GitHub Actions, unless disabled by ASSERT_GITHUB_ANNOTATIONS.
+func defaultReporter() Reporter {
+	if os.Getenv(githubActionsEnv) != "true" {
+		return nil
+	}
+	if enabled, err := strconv.ParseBool(os.Getenv(githubAnnotationsEnv)); err == nil && !enabled {
+		return nil
+	}
+	return GitHubReporter(os.Stdout)
+}
+
+// SetReporter sets the reporter of the failures of all tests, or removes
+// it when r is nil, and returns the previous one. On GitHub Actions, a
+// GitHubReporter is set by default, unless the ASSERT_GITHUB_ANNOTATIONS
+// environment variable is set to a false value, such as "0":
+//
+//	func TestMain(m *testing.M) {
+//	    assert.SetReporter(nil)
+//	    os.Exit(m.Run())
+//	}
+func SetReporter(r Reporter) Reporter {
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+
+	prev := reporter
+	reporter = r
+	return prev
+}
+
+// currentReporter returns the reporter of the failures, or nil.
+func currentReporter() Reporter {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+
+	return reporter
+}
+
+// reported reports whether the failures of t are failures of a test,
+// forwarded to the reporter, rather than failures recorded by Check,
+// Retry or package verify, which may not fail the test.
+func reported(t testing.TB) bool {
+	for {
+		switch tb := t.(type) {
+		case *checkTB:
+			return false
+		case *Assert:
+			t = tb.TB
+		case *SafeTB:
+			t = tb.TB
+		case *FuzzT:
+			t = tb.TB
+		default:
+			return true
+		}
+	}
+}
+
+// githubReporter writes failures as workflow commands of GitHub Actions.
+type githubReporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// GitHubReporter returns a Reporter writing failures to w as "::error"
+// workflow commands, shown by GitHub Actions as annotations on the lines
+// of the failed assertions in pull requests:
+//
+//	::error file=user/user_test.go,line=42,title=TestCreate::unexpected name%0AExpected: ...
+//
+// Files are relative to the GITHUB_WORKSPACE directory when set. The
+// commands are not recognized in the output of go test -json.
+func GitHubReporter(w io.Writer) Reporter {
+	return &githubReporter{w: w}
+}
+
+func (r *githubReporter) Report(t testing.TB, f Failure) {
+	file := f.File
+	if ws := os.Getenv("GITHUB_WORKSPACE"); ws != "" && file != "" {
+		if rel, err := filepath.Rel(ws, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+
+	labels := newFailureLabels()
+	lines := make([]string, 0, 4)
+	if f.Message != "" {
+		lines = append(lines, f.Message)
+	}
+	lines = append(lines,
+		fmt.Sprintf("%s (%s) %s", strings.TrimSpace(labels.expected), f.Types.Expected, f.Expected),
+		fmt.Sprintf("%s (%s) %s", strings.TrimSpace(labels.actual), f.Types.Actual, f.Actual))
+	if f.Note != "" {
+		lines = append(lines, strings.TrimSpace(labels.note)+" "+f.Note)
+	}
+
+	var props string
+	if file != "" {
+		props = fmt.Sprintf(" file=%s,line=%d,", escapeProperty(file), f.Line)
+	} else {
+		props = " "
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fmt.Fprintf(r.w, "::error%stitle=%s::%s\n", props, escapeProperty(t.Name()), escapeData(strings.Join(lines, "\n")))
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// teamCityReporter writes failures as TeamCity service messages.
+type teamCityReporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// TeamCityReporter returns a Reporter writing failures to w as TeamCity
+// "testFailed" service messages of type comparisonFailure, so that TeamCity
+// shows the expected and actual values in its diff viewer. Like every
+// Reporter, it is not given the failures recorded by Check or Retry:
+//
+//	func TestMain(m *testing.M) {
+//	    if os.Getenv("TEAMCITY_VERSION") != "" {
+//	        assert.SetReporter(assert.TeamCityReporter(os.Stdout))
+//	    }
+//	    os.Exit(m.Run())
+//	}
+func TeamCityReporter(w io.Writer) Reporter {
+	return &teamCityReporter{w: w}
+}
+
+func (r *teamCityReporter) Report(t testing.TB, f Failure) {
+	details := make([]string, 0, 3)
+	if f.Location != "" {
+		details = append(details, f.Location)
+	}
+	if f.Note != "" {
+		details = append(details, f.Note)
+	}
+	if f.Diff != "" {
+		details = append(details, f.Diff)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fmt.Fprintf(r.w, "##teamcity[testFailed type='comparisonFailure' name='%s' message='%s' details='%s' expected='%s' actual='%s']\n",
+		escapeTeamCity(t.Name()), escapeTeamCity(f.Message), escapeTeamCity(strings.Join(details, "\n")),
+		escapeTeamCity(f.Expected), escapeTeamCity(f.Actual))
+}
+
+// escapeTeamCity escapes a value of a TeamCity service message.
+func escapeTeamCity(s string) string {
+	return strings.NewReplacer(
+		"|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]",
+		"\u0085", "|x", "\u2028", "|l", "\u2029", "|p",
+	).Replace(s)
+}
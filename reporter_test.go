@@ -0,0 +1,198 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	wd, err := os.Getwd()
+	NoError(t, err)
+
+	tests := []struct {
+		name      string
+		workspace string
+		failure   Failure
+		want      string
+	}{
+		{
+			name: "failure",
+			failure: Failure{
+				File: "/src/app/user_test.go", Line: 42,
+				Message: "unexpected name", Expected: `"alice"`, Actual: `"bob"`,
+				Types: FailureTypes{Expected: "string", Actual: "string"},
+			},
+			want: "::error file=/src/app/user_test.go,line=42,title=TestGitHubReporter/failure::" +
+				`unexpected name%0AExpected: (string) "alice"%0AActual: (string) "bob"` + "\n",
+		},
+		{
+			name:      "relative to the workspace",
+			workspace: "/src",
+			failure: Failure{
+				File: "/src/app/user_test.go", Line: 42,
+				Expected: "1", Actual: "2", Note: "100% off",
+				Types: FailureTypes{Expected: "int", Actual: "int"},
+			},
+			want: "::error file=app/user_test.go,line=42,title=TestGitHubReporter/relative_to_the_workspace::" +
+				"Expected: (int) 1%0AActual: (int) 2%0ANote: 100%25 off\n",
+		},
+		{
+			name:      "outside the workspace",
+			workspace: filepath.Join(wd, "other"),
+			failure: Failure{
+				File: "/tmp/a,b:c.go", Line: 1,
+				Expected: "1", Actual: "2",
+				Types: FailureTypes{Expected: "int", Actual: "int"},
+			},
+			want: "::error file=/tmp/a%2Cb%3Ac.go,line=1,title=TestGitHubReporter/outside_the_workspace::" +
+				"Expected: (int) 1%0AActual: (int) 2\n",
+		},
+		{
+			name: "unknown location",
+			failure: Failure{
+				Expected: "1", Actual: "2",
+				Types: FailureTypes{Expected: "int", Actual: "int"},
+			},
+			want: "::error title=TestGitHubReporter/unknown_location::Expected: (int) 1%0AActual: (int) 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_WORKSPACE", tt.workspace)
+			var buf bytes.Buffer
+
+			GitHubReporter(&buf).Report(t, tt.failure)
+
+			Equal(t, buf.String(), tt.want)
+		})
+	}
+}
+
+// failureRecorder is a Reporter recording failures.
+type failureRecorder struct{ failures []Failure }
+
+func (r *failureRecorder) Report(_ testing.TB, f Failure) {
+	r.failures = append(r.failures, f)
+}
+
+func TestSetReporter(t *testing.T) {
+	r := &failureRecorder{}
+	defer SetReporter(SetReporter(r))
+	rec := NewTestRecorder(t)
+
+	want := nextLine()
+	Equal(rec, 1, 2, "counts differ")
+
+	Len(t, r.failures, 1)
+	f := r.failures[0]
+	Equal(t, f.Message, "counts differ")
+	Equal(t, f.Location, want)
+	Equal(t, filepath.Base(f.File), "reporter_test.go")
+	Equal(t, f.Expected, "2")
+	True(t, rec.HasError(), "failure still reported to the test")
+}
+
+func TestReporterIgnoresChecks(t *testing.T) {
+	r := &failureRecorder{}
+	defer SetReporter(SetReporter(r))
+	rec := NewTestRecorder(t)
+
+	Error(t, Check(func(t testing.TB) { Equal(t, 1, 2) }))
+	Error(t, Check(func(t testing.TB) {
+		Concurrently(t, 2, func(i int, a *Assert) { Equal(a, i, 0) })
+	}))
+	attempts := 0
+	Retry(rec, 3, 0, func(a *Assert) {
+		attempts++
+		Equal(a, attempts, 3)
+	})
+
+	False(t, rec.HasError(), rec.ErrorMessage())
+	Len(t, r.failures, 0)
+
+	Equal(New(rec).WithLabel("user"), 1, 2)
+	Len(t, r.failures, 1)
+}
+
+func TestDefaultReporter(t *testing.T) {
+	tests := []struct {
+		name        string
+		actions     string
+		annotations string
+		want        bool
+	}{
+		{"not on GitHub Actions", "", "", false},
+		{"on GitHub Actions", "true", "", true},
+		{"disabled", "true", "0", false},
+		{"invalid opt-out", "true", "maybe", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(githubActionsEnv, tt.actions)
+			t.Setenv(githubAnnotationsEnv, tt.annotations)
+
+			Equal(t, defaultReporter() != nil, tt.want)
+		})
+	}
+}
+
+func TestTeamCityReporter(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure Failure
+		want    string
+	}{
+		{
+			name: "failure",
+			failure: Failure{
+				Location: "user_test.go:42", Message: "unexpected name",
+				Expected: `"alice"`, Actual: `"bob"`,
+			},
+			want: "##teamcity[testFailed type='comparisonFailure' name='TestTeamCityReporter/failure' " +
+				`message='unexpected name' details='user_test.go:42' expected='"alice"' actual='"bob"']` + "\n",
+		},
+		{
+			name: "escaped values",
+			failure: Failure{
+				Note: "it's [1]", Diff: "-a|b\n+c\r\n",
+				Expected: `"l'été"`, Actual: "\"x\u2028y\u0085\u2029\"",
+			},
+			want: "##teamcity[testFailed type='comparisonFailure' name='TestTeamCityReporter/escaped_values' " +
+				"message='' details='it|'s |[1|]|n-a||b|n+c|r|n' expected='\"l|'été\"' actual='\"x|ly|x|p\"']\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			TeamCityReporter(&buf).Report(t, tt.failure)
+
+			Equal(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestTeamCityReporterIgnoresChecks(t *testing.T) {
+	var buf bytes.Buffer
+	defer SetReporter(SetReporter(TeamCityReporter(&buf)))
+	rec := NewTestRecorder(t)
+
+	Error(t, Check(func(t testing.TB) { Equal(t, "a", "b") }))
+	attempts := 0
+	Retry(rec, 2, 0, func(a *Assert) {
+		attempts++
+		Equal(a, attempts, 2)
+	})
+	Equal(t, buf.String(), "")
+
+	Equal(rec, "a", "b")
+	StringContains(t, buf.String(), "##teamcity[testFailed")
+}
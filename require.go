@@ -0,0 +1,53 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import "testing"
+
+// Require creates an Assert bound to t which stops the test at the first
+// failure, like t.Fatal, instead of letting it continue. Every assertion
+// of this package is available this way, to guard the checks that later
+// ones depend on and avoid cascading nil pointer panics:
+//
+//	r := assert.Require(t)
+//	user, err := store.Find(42)
+//	assert.NoError(r, err)
+//	assert.NotNil(r, user)
+//	assert.Equal(t, user.Name, "alice") // not reached when user is nil
+//
+// Like t.FailNow, failures stop the goroutine they are reported from, so
+// the Assert must only be used from the test goroutine. The goroutines of
+// Concurrently assert through their own Assert instead, whose failures are
+// reported on the test goroutine once they are done, and stop it there.
+// Subtests run with Assert.Run inherit the behavior, and t may be an
+// Assert whose settings are kept.
+func Require(t testing.TB) *Assert {
+	return &Assert{TB: t, required: true}
+}
+
+// lookupRequired reports whether the failures reported to t stop the test.
+func lookupRequired(t testing.TB) bool {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			return false
+		}
+		if a.required {
+			return true
+		}
+		t = a.TB
+	}
+}
+
+// fail reports the failure message s to tb, the TB wrapped by a,
+// stopping the test when a requires it.
+func (a *Assert) fail(tb testing.TB, s string) {
+	tb.Helper()
+
+	if lookupRequired(a) {
+		tb.Fatal(s)
+		return
+	}
+	tb.Error(s)
+}
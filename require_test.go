@@ -0,0 +1,103 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// runStopping runs fn in a new goroutine, like a test, and reports
+// whether it was stopped before returning.
+func runStopping(fn func()) bool {
+	returned := false
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		fn()
+		returned = true
+	}()
+	<-done
+
+	return !returned
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		assert   func(t testing.TB)
+		wantStop bool
+	}{
+		{"Equal passes", func(t testing.TB) { Equal(t, 1, 1) }, false},
+		{"Equal fails", func(t testing.TB) { Equal(t, 1, 2) }, true},
+		{"Nil fails", func(t testing.TB) { Nil(t, &struct{}{}) }, true},
+		{"ErrorIs fails", func(t testing.TB) { ErrorIs(t, io.EOF, io.ErrClosedPipe) }, true},
+		{"Contains fails", func(t testing.TB) { Contains(t, []string{"a"}, "b") }, true},
+		{"unsupported type", func(t testing.TB) { Len(t, 42, 1) }, true},
+		{"Check does not stop", func(t testing.TB) { _ = Check(func(t testing.TB) { Equal(t, 1, 2) }) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			stopped := runStopping(func() { tt.assert(Require(rec)) })
+
+			Equal(t, stopped, tt.wantStop)
+			Equal(t, rec.Failed(), tt.wantStop)
+		})
+	}
+}
+
+func TestRequireStopsAtFirstFailure(t *testing.T) {
+	rec := NewTestRecorder(t)
+	r := Require(rec)
+
+	runStopping(func() {
+		err := errors.New("not found")
+		NoError(r, err)
+		Equal(r, 1, 2)
+	})
+
+	Len(t, rec.Failures(), 1)
+	StringContains(t, rec.ErrorMessage(), "not found")
+}
+
+func TestRequireKeepsSettings(t *testing.T) {
+	rec := NewTestRecorder(t)
+	r := Require(New(rec).WithLabel("user 42")).WithLabel("roles")
+
+	stopped := runStopping(func() { Equal(r, "admin", "guest") })
+
+	True(t, stopped)
+	True(t, strings.HasPrefix(rec.ErrorMessage(), "user 42 > roles\n"), rec.ErrorMessage())
+}
+
+func TestRequireConcurrently(t *testing.T) {
+	rec := NewTestRecorder(t)
+	r := Require(rec)
+
+	stopped := runStopping(func() {
+		Concurrently(r, 2, func(i int, a *Assert) {
+			Equal(a, i, 0)
+			Equal(a, i, 0)
+		})
+	})
+
+	True(t, stopped)
+	Len(t, rec.Failures(), 1)
+	StringContains(t, rec.ErrorMessage(), "goroutine 1 of 2 failed")
+}
+
+func TestLookupRequired(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	False(t, lookupRequired(rec))
+	False(t, lookupRequired(New(rec)))
+	True(t, lookupRequired(Require(rec)))
+	True(t, lookupRequired(New(Require(rec)).WithLabel("nested")))
+}
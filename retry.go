@@ -0,0 +1,79 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Retry runs the assertions of fn until they all pass, at most attempts
+// times, waiting delay between two attempts. Failures of the attempts are
+// recorded instead of being reported; when no attempt passes, the failures
+// of the last one are reported, along with a summary of the earlier ones.
+// It suits integration tests against eventually consistent systems:
+//
+//	assert.Retry(t, 5, 200*time.Millisecond, func(a *assert.Assert) {
+//	    resp := search(query)
+//	    assert.Len(a, resp.Hits, 1)
+//	})
+//
+// Fatal assertions stop the current attempt only.
+func Retry(t testing.TB, attempts int, delay time.Duration, fn func(a *Assert)) {
+	t.Helper()
+	defer observe(t)()
+
+	clk := lookupClock(t)
+	var earlier []string
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		c := &checkTB{}
+		c.run(func(t testing.TB) { fn(New(t)) })
+
+		switch {
+		case c.skipped:
+			t.Skip(strings.Join(c.logs, "\n"))
+			return
+		case !c.failed:
+			return
+		case attempt == attempts:
+			var b strings.Builder
+			fmt.Fprintf(&b, "\nfailed after %d attempts, %v apart", attempts, delay)
+			for _, summary := range earlier {
+				b.WriteString("\n  " + summary)
+			}
+			fmt.Fprintf(&b, "\nattempt %d:", attempt)
+			for _, failure := range c.failures {
+				b.WriteString("\n" + failure)
+			}
+			t.Error(b.String())
+			return
+		}
+
+		earlier = append(earlier, summarizeAttempt(attempt, c.failures))
+		clk.Sleep(delay)
+	}
+}
+
+// summarizeAttempt describes the failures of an attempt on a single line.
+func summarizeAttempt(attempt int, failures []string) string {
+	switch len(failures) {
+	case 0:
+		return fmt.Sprintf("attempt %d: failed", attempt)
+	case 1:
+		return fmt.Sprintf("attempt %d: %s", attempt, firstLine(failures[0]))
+	default:
+		return fmt.Sprintf("attempt %d: %d failures, first: %s", attempt, len(failures), firstLine(failures[0]))
+	}
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("passes after failed attempts", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		calls := 0
+
+		Retry(rec, 5, 0, func(a *Assert) {
+			calls++
+			Equal(a, calls, 3)
+		})
+
+		if rec.HasError() {
+			t.Errorf("Retry() recorded error\n%s", rec.ErrorMessage())
+		}
+		if calls != 3 {
+			t.Errorf("Retry() made %d attempts, want 3", calls)
+		}
+	})
+
+	t.Run("reports the last attempt", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		calls := 0
+
+		Retry(rec, 3, 0, func(a *Assert) {
+			calls++
+			Equal(a, calls, 0, "hits")
+			if calls == 1 {
+				True(a, false)
+			}
+		})
+
+		if rec.FailureCount() != 1 {
+			t.Fatalf("Retry() reported %d failures, want 1", rec.FailureCount())
+		}
+		for _, part := range []string{
+			"failed after 3 attempts, 0s apart",
+			"  attempt 1: 2 failures, first: Message: hits",
+			"  attempt 2: Message: hits",
+			"attempt 3:\nMessage: hits\nExpected: (int) 0\n  Actual: (int) 3",
+		} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("Retry() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("fatal stops the attempt", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		calls := 0
+
+		Retry(rec, 2, 0, func(a *Assert) {
+			calls++
+			a.Fatal("not ready")
+			t.Error("Fatal() did not stop the attempt")
+		})
+
+		if calls != 2 || !strings.Contains(rec.ErrorMessage(), "attempt 1: not ready") {
+			t.Errorf("Retry() calls = %d\n%s", calls, rec.ErrorMessage())
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() {
+			Retry(rec, 2, 0, func(a *Assert) {
+				a.Skip("no backend")
+			})
+		})
+
+		if !rec.Skipped() || rec.HasError() {
+			t.Error("Retry() did not skip the test")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// SafeTB wraps a testing.TB so that assertions can be made from goroutines
+// other than the test goroutine. Failures are queued and reported on the
+// test goroutine by Flush, which is registered as a cleanup of the wrapped TB.
+//
+// Fatal, Fatalf and FailNow queue the failure and stop only the calling
+// goroutine, instead of calling FailNow on the test from the wrong goroutine.
+// Skip, Skipf and SkipNow likewise log their reason and stop the calling goroutine.
+type SafeTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []string
+	failed   bool
+}
+
+// NewSafeTB creates a SafeTB wrapping t.
+// It must be called from the test goroutine.
+func NewSafeTB(t testing.TB) *SafeTB {
+	t.Helper()
+
+	s := &SafeTB{TB: t}
+	t.Cleanup(s.Flush)
+
+	return s
+}
+
+// Error queues a failure with the given arguments.
+func (s *SafeTB) Error(args ...any) {
+	s.queue(fmt.Sprint(args...))
+}
+
+// Errorf queues a failure with the formatted message.
+func (s *SafeTB) Errorf(format string, args ...any) {
+	s.queue(fmt.Sprintf(format, args...))
+}
+
+// Fail marks the test as failed without a message.
+func (s *SafeTB) Fail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed = true
+}
+
+// FailNow marks the test as failed and stops the calling goroutine.
+func (s *SafeTB) FailNow() {
+	s.Fail()
+	runtime.Goexit()
+}
+
+// Failed reports whether a failure was queued or the wrapped test failed.
+func (s *SafeTB) Failed() bool {
+	s.mu.Lock()
+	failed := s.failed
+	s.mu.Unlock()
+
+	return failed || s.TB.Failed()
+}
+
+// Fatal queues a failure with the given arguments and stops the calling goroutine.
+func (s *SafeTB) Fatal(args ...any) {
+	s.Error(args...)
+	runtime.Goexit()
+}
+
+// Fatalf queues a failure with the formatted message and stops the calling goroutine.
+func (s *SafeTB) Fatalf(format string, args ...any) {
+	s.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// Skip logs the given arguments and stops the calling goroutine.
+func (s *SafeTB) Skip(args ...any) {
+	s.TB.Log(args...)
+	runtime.Goexit()
+}
+
+// Skipf logs the formatted message and stops the calling goroutine.
+func (s *SafeTB) Skipf(format string, args ...any) {
+	s.TB.Logf(format, args...)
+	runtime.Goexit()
+}
+
+// SkipNow stops the calling goroutine.
+func (s *SafeTB) SkipNow() {
+	runtime.Goexit()
+}
+
+// Failures returns the queued failure messages that were not flushed yet.
+func (s *SafeTB) Failures() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.failures...)
+}
+
+// Flush reports the queued failures on the wrapped TB.
+// It must be called from the test goroutine.
+func (s *SafeTB) Flush() {
+	s.TB.Helper()
+
+	s.mu.Lock()
+	failures := s.failures
+	failed := s.failed
+	s.failures = nil
+	s.failed = false
+	s.mu.Unlock()
+
+	for _, msg := range failures {
+		s.TB.Error(msg)
+	}
+	if failed && len(failures) == 0 {
+		s.TB.Fail()
+	}
+}
+
+func (s *SafeTB) queue(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed = true
+	s.failures = append(s.failures, msg)
+}
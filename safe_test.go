@@ -0,0 +1,102 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSafeTB(t *testing.T) {
+	t.Run("failures from goroutines are flushed", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		s := NewSafeTB(rec)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				Equal(s, i, -1)
+			}(i)
+		}
+		wg.Wait()
+
+		if rec.HasError() {
+			t.Fatal("failures reported before Flush")
+		}
+		if !s.Failed() || len(s.Failures()) != 5 {
+			t.Fatalf("Failures() = %d, want 5", len(s.Failures()))
+		}
+
+		s.Flush()
+
+		if rec.FailureCount() != 5 {
+			t.Errorf("Flush() reported %d failures, want 5", rec.FailureCount())
+		}
+		if len(s.Failures()) != 0 {
+			t.Error("Flush() did not empty the queue")
+		}
+	})
+
+	t.Run("fatal stops only the calling goroutine", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		s := NewSafeTB(rec)
+		reached := false
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			s.Fatalf("stop %d", 1)
+			reached = true
+		}()
+		<-done
+
+		if reached {
+			t.Error("Fatalf() did not stop the goroutine")
+		}
+
+		rec.RunCleanups()
+
+		if rec.ErrorMessage() != "stop 1" {
+			t.Errorf("cleanup flushed %q, want %q", rec.ErrorMessage(), "stop 1")
+		}
+	})
+
+	t.Run("fail without message", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		s := NewSafeTB(rec)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			s.FailNow()
+		}()
+		<-done
+
+		s.Flush()
+
+		if !rec.Failed() || rec.HasError() {
+			t.Errorf("Flush() failed=%v hasError=%v, want failed without error", rec.Failed(), rec.HasError())
+		}
+	})
+
+	t.Run("skip stops the goroutine without failing", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		s := NewSafeTB(rec)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			s.Skipf("skip %s", "me")
+		}()
+		<-done
+
+		s.Flush()
+
+		if rec.Failed() || len(rec.Logs()) != 1 {
+			t.Errorf("Skipf() failed=%v logs=%q", rec.Failed(), rec.Logs())
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// seedEnv is the environment variable setting the seed of all tests.
+const seedEnv = "ASSERT_SEED"
+
+var (
+	seedsMu sync.Mutex
+	seeds   = map[testing.TB]int64{}
+)
+
+// Seed returns the random seed of the test t, to seed its generators.
+// The seed is drawn on the first call, or read from the ASSERT_SEED
+// environment variable, and the same seed is returned by later calls for
+// the same test. Once drawn, it is shown in every failure message of the
+// test, so that randomized failures can be reproduced:
+//
+//	r := rand.New(rand.NewSource(assert.Seed(t)))
+//	input := randomOrder(r)
+//	assert.NoError(t, Validate(input))
+//
+// A failure showing "Seed: 1718035200123456789" is reproduced with:
+//
+//	ASSERT_SEED=1718035200123456789 go test -run TestValidate
+//
+// ForAll uses the seed of its test.
+func Seed(t testing.TB) int64 {
+	t.Helper()
+
+	root := rootTB(t)
+	seedsMu.Lock()
+	seed, ok := seeds[root]
+	seedsMu.Unlock()
+	if ok {
+		return seed
+	}
+
+	seed = time.Now().UnixNano()
+	if env := os.Getenv(seedEnv); env != "" {
+		n, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			t.Fatalf("\ninvalid %s %q: must be an integer", seedEnv, env)
+			return 0
+		}
+		seed = n
+	}
+
+	seedsMu.Lock()
+	seeds[root] = seed
+	seedsMu.Unlock()
+
+	root.Cleanup(func() {
+		seedsMu.Lock()
+		defer seedsMu.Unlock()
+
+		delete(seeds, root)
+	})
+	return seed
+}
+
+// testSeed returns the seed of the test t, if drawn by Seed.
+func testSeed(t testing.TB) (int64, bool) {
+	seedsMu.Lock()
+	defer seedsMu.Unlock()
+
+	seed, ok := seeds[rootTB(t)]
+	return seed, ok
+}
+
+// rootTB returns the TB wrapped by the Assert values t may be made of.
+func rootTB(t testing.TB) testing.TB {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			return t
+		}
+		t = a.TB
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	t.Run("same seed for a test", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		seed := Seed(rec)
+
+		Equal(t, Seed(rec), seed)
+		Equal(t, Seed(New(rec).WithLabel("x")), seed)
+	})
+
+	t.Run("read from the environment", func(t *testing.T) {
+		t.Setenv(seedEnv, "42")
+		rec := NewTestRecorder(t)
+
+		Equal(t, Seed(rec), int64(42))
+	})
+
+	t.Run("invalid environment", func(t *testing.T) {
+		t.Setenv(seedEnv, "abc")
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { Seed(rec) })
+
+		if !strings.Contains(rec.ErrorMessage(), `invalid ASSERT_SEED "abc"`) {
+			t.Errorf("Seed() message = %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("shown in failure messages", func(t *testing.T) {
+		t.Setenv(seedEnv, "42")
+		rec := NewTestRecorder(t)
+
+		Equal(rec, 1, 2)
+		Seed(rec)
+		Equal(New(rec), 1, 2)
+
+		failures := rec.Failures()
+		if strings.Contains(failures[0], "Seed:") {
+			t.Errorf("failure before Seed() = %q, want no seed", failures[0])
+		}
+		if !strings.Contains(failures[1], "\n    Seed: 42 (ASSERT_SEED=42 to reproduce)\n") {
+			t.Errorf("failure after Seed() = %q, want seed", failures[1])
+		}
+	})
+
+	t.Run("forgotten at cleanup", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		Seed(rec)
+		rec.RunCleanups()
+
+		_, ok := testSeed(rec)
+		False(t, ok)
+	})
+
+	t.Run("ForAll reproduces inputs", func(t *testing.T) {
+		t.Setenv(seedEnv, "42")
+		var inputs [2][]int
+
+		for i := range inputs {
+			rec := NewTestRecorder(t)
+			ForAll(rec, func(r *rand.Rand) int { return r.Int() }, func(n int) bool {
+				inputs[i] = append(inputs[i], n)
+				return true
+			}, 5)
+		}
+
+		Equal(t, inputs[0], inputs[1])
+	})
+}
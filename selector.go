@@ -0,0 +1,334 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// HTMLSelectorCount checks the number of elements of an HTML document
+// matching a CSS selector:
+//
+//	assert.HTMLSelectorCount(t, body, "table.users > tbody > tr", 3)
+//
+// Supported selectors are made of type (div, *), id (#main), class (.error)
+// and attribute ([name], [type=email]) conditions, combined with the
+// descendant (space) and child (>) combinators. Several selectors can be
+// grouped with commas.
+func HTMLSelectorCount(t testing.TB, html, selector string, n int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	matches, ok := selectHTML(t, html, selector, msg)
+	if ok && len(matches) != n {
+		failCompare(t, len(matches), n, withMessage(fmt.Sprintf("unexpected number of elements matching %q", selector), msg)...)
+	}
+}
+
+// HTMLSelectorText checks the text of the first element of an HTML document
+// matching a CSS selector. The text of an element is the text of all its
+// descendants, with whitespace collapsed:
+//
+//	assert.HTMLSelectorText(t, body, "form .error > span", "invalid email")
+//
+// See HTMLSelectorCount for the supported selectors.
+func HTMLSelectorText(t testing.TB, html, selector, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	matches, ok := selectHTML(t, html, selector, msg)
+	if !ok {
+		return
+	}
+	if len(matches) == 0 {
+		failCompare(t, "no element", fmt.Sprintf("element matching %q", selector), withMessage("selector did not match", msg)...)
+		return
+	}
+	if actual := matches[0].textContent(); actual != expected {
+		failCompare(t, actual, expected, withMessage(fmt.Sprintf("unexpected text of %q", selector), msg)...)
+	}
+}
+
+// selectHTML parses html and returns the elements matching selector,
+// reporting a failure when either cannot be parsed.
+func selectHTML(t testing.TB, html, selector string, msg []string) ([]*htmlNode, bool) {
+	t.Helper()
+
+	sel, err := parseSelector(selector)
+	if err != nil {
+		failCompare(t, selector, "valid selector", withMessage(err.Error(), msg)...)
+		return nil, false
+	}
+	root, err := parseHTML(html)
+	if err != nil {
+		failCompare(t, html, "valid HTML", withMessage("invalid HTML: "+err.Error(), msg)...)
+		return nil, false
+	}
+	return sel.selectFrom(root), true
+}
+
+// textContent returns the text of the node and its descendants,
+// with whitespace collapsed as a browser renders it.
+func (n *htmlNode) textContent() string {
+	var b strings.Builder
+	n.writeText(&b)
+	return collapseSpace(b.String(), true)
+}
+
+// writeText writes the text nodes under n to b, in document order.
+func (n *htmlNode) writeText(b *strings.Builder) {
+	if n.tag == "" {
+		b.WriteString(n.text)
+		return
+	}
+	for _, c := range n.children {
+		c.writeText(b)
+	}
+}
+
+// attr returns the value of the named attribute.
+func (n *htmlNode) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// selectorGroup is a comma-separated list of selectors.
+type selectorGroup []complexSelector
+
+// complexSelector is a chain of compound selectors joined by combinators.
+type complexSelector []compoundSelector
+
+// compoundSelector holds the conditions on a single element, and the
+// combinator relating it to the previous compound: ' ' or '>'.
+type compoundSelector struct {
+	combinator byte
+	tag        string
+	id         string
+	classes    []string
+	attrs      []attrSelector
+}
+
+// attrSelector matches the presence or the exact value of an attribute.
+type attrSelector struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+// parseSelector parses the CSS selector subset described by HTMLSelectorCount.
+func parseSelector(s string) (selectorGroup, error) {
+	var group selectorGroup
+
+	for _, part := range strings.Split(s, ",") {
+		sel, err := parseComplexSelector(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %v", s, err)
+		}
+		group = append(group, sel)
+	}
+	return group, nil
+}
+
+func parseComplexSelector(s string) (complexSelector, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty selector")
+	}
+
+	var sel complexSelector
+	combinator := byte(' ')
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '>':
+			if len(sel) == 0 || combinator == '>' {
+				return nil, fmt.Errorf("unexpected '>'")
+			}
+			combinator = '>'
+			i++
+		default:
+			compound, n, err := parseCompound(s[i:])
+			if err != nil {
+				return nil, err
+			}
+			compound.combinator = combinator
+			sel = append(sel, compound)
+			combinator = ' '
+			i += n
+		}
+	}
+
+	if combinator == '>' {
+		return nil, fmt.Errorf("missing selector after '>'")
+	}
+	return sel, nil
+}
+
+// parseCompound parses a compound selector at the start of s
+// and returns it with the number of bytes consumed.
+func parseCompound(s string) (compoundSelector, int, error) {
+	var c compoundSelector
+	i := 0
+
+	if i < len(s) && s[i] == '*' {
+		i++
+	} else if n := identLen(s[i:]); n > 0 {
+		c.tag = strings.ToLower(s[i : i+n])
+		i += n
+	}
+
+	for i < len(s) {
+		switch s[i] {
+		case '#', '.':
+			n := identLen(s[i+1:])
+			if n == 0 {
+				return c, 0, fmt.Errorf("missing name after %q", s[i])
+			}
+			if s[i] == '#' {
+				c.id = s[i+1 : i+1+n]
+			} else {
+				c.classes = append(c.classes, s[i+1:i+1+n])
+			}
+			i += 1 + n
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return c, 0, fmt.Errorf("unclosed '['")
+			}
+			attr, err := parseAttrSelector(s[i+1 : i+end])
+			if err != nil {
+				return c, 0, err
+			}
+			c.attrs = append(c.attrs, attr)
+			i += end + 1
+		case ' ', '\t', '\n', '>':
+			return c, i, nil
+		default:
+			return c, 0, fmt.Errorf("unexpected %q", s[i])
+		}
+	}
+
+	if i == 0 {
+		return c, 0, fmt.Errorf("empty compound selector")
+	}
+	return c, i, nil
+}
+
+func parseAttrSelector(s string) (attrSelector, error) {
+	name, value, hasValue := s, "", false
+	if eq := strings.IndexByte(s, '='); eq >= 0 {
+		name, value, hasValue = s[:eq], s[eq+1:], true
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if identLen(name) != len(name) || name == "" {
+		return attrSelector{}, fmt.Errorf("invalid attribute selector [%s]", s)
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return attrSelector{name: name, value: value, hasValue: hasValue}, nil
+}
+
+// identLen returns the length of the CSS identifier at the start of s.
+func identLen(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c == '-' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// selectFrom returns the elements under root matching any selector of the
+// group, in document order.
+func (g selectorGroup) selectFrom(root *htmlNode) []*htmlNode {
+	var matches []*htmlNode
+
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, c := range n.children {
+			if c.tag == "" {
+				continue
+			}
+			for _, sel := range g {
+				if sel.matches(c, len(sel)-1) {
+					matches = append(matches, c)
+					break
+				}
+			}
+			walk(c)
+		}
+	}
+	walk(root)
+
+	return matches
+}
+
+// matches reports whether n matches the selector up to its i-th compound.
+func (sel complexSelector) matches(n *htmlNode, i int) bool {
+	if !sel[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	if sel[i].combinator == '>' {
+		return n.parent != nil && n.parent.tag != "" && sel.matches(n.parent, i-1)
+	}
+	for p := n.parent; p != nil && p.tag != ""; p = p.parent {
+		if sel.matches(p, i-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c compoundSelector) matches(n *htmlNode) bool {
+	if c.tag != "" && c.tag != n.tag {
+		return false
+	}
+	if c.id != "" {
+		if id, _ := n.attr("id"); id != c.id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		class, _ := n.attr("class")
+		classes := strings.Fields(class)
+		for _, want := range c.classes {
+			if !containsString(classes, want) {
+				return false
+			}
+		}
+	}
+	for _, a := range c.attrs {
+		value, ok := n.attr(a.name)
+		if !ok || (a.hasValue && value != a.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsString reports whether s is in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
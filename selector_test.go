@@ -0,0 +1,146 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const selectorPage = `
+<html>
+  <body>
+    <div id="main" class="page">
+      <form action="/signup">
+        <input type="email" name="email">
+        <div class="field error"><span>invalid  email</span></div>
+        <div class="field"><span>ok</span></div>
+      </form>
+      <ul class="menu">
+        <li><a href="/">Home</a></li>
+        <li class="active"><a href="/about">About <b>us</b></a></li>
+      </ul>
+    </div>
+  </body>
+</html>`
+
+func TestHTMLSelectorCount(t *testing.T) {
+	tests := []struct {
+		selector  string
+		n         int
+		wantError bool
+	}{
+		{"div", 3, false},
+		{"*", 15, false},
+		{"#main", 1, false},
+		{"div.field", 2, false},
+		{".field.error", 1, false},
+		{"form span", 2, false},
+		{"form > span", 0, false},
+		{"form > div > span", 2, false},
+		{"body li a", 2, false},
+		{"#main>ul>li.active", 1, false},
+		{"input[type=email]", 1, false},
+		{`input[name="email"]`, 1, false},
+		{"a[href]", 2, false},
+		{"a[href='/missing']", 0, false},
+		{"ul li, form input", 3, false},
+		{"li", 3, true},
+		{"div >", 0, true},
+		{"div..x", 0, true},
+		{"a[href", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			HTMLSelectorCount(rec, selectorPage, tt.selector, tt.n)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("HTMLSelectorCount() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestHTMLSelectorText(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		expected  string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "collapsed whitespace",
+			selector:  "form .error > span",
+			expected:  "invalid email",
+			wantError: false,
+		},
+		{
+			name:      "nested text",
+			selector:  "li.active",
+			expected:  "About us",
+			wantError: false,
+		},
+		{
+			name:      "first match",
+			selector:  "li a",
+			expected:  "Home",
+			wantError: false,
+		},
+		{
+			name:      "different text",
+			selector:  ".error span",
+			expected:  "invalid name",
+			wantError: true,
+			wantParts: []string{`unexpected text of ".error span"`, `"invalid email"`},
+		},
+		{
+			name:      "no match",
+			selector:  "table",
+			expected:  "x",
+			wantError: true,
+			wantParts: []string{"selector did not match"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			HTMLSelectorText(rec, selectorPage, tt.selector, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("HTMLSelectorText() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("HTMLSelectorText() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+
+	t.Run("inline elements", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		HTMLSelectorText(rec, `<p class="price">$<b>9</b>.99</p>`, ".price", "$9.99")
+
+		if rec.HasError() {
+			t.Errorf("HTMLSelectorText() failed for adjacent text nodes\n%s", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("invalid HTML", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		HTMLSelectorText(rec, "<div>", "div", "")
+
+		if !strings.Contains(rec.ErrorMessage(), "invalid HTML") {
+			t.Errorf("HTMLSelectorText() message missing reason\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
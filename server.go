@@ -0,0 +1,178 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is a test HTTP server recording the requests it receives,
+// with assertions on them. It embeds the underlying httptest.Server,
+// whose URL is the base URL of the server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+// RecordedRequest is a request received by a Server.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Response is a canned response served by a Server.
+// A zero Status means http.StatusOK.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+// NewServer starts a Server closed when the test completes. The handler
+// is an http.Handler, a func(http.ResponseWriter, *http.Request), or a
+// map[string]Response of canned responses keyed by "METHOD /path" or by
+// "/path" for any method, unknown routes getting a 404. A nil handler
+// replies 200 to every request:
+//
+//	srv := assert.NewServer(t, map[string]assert.Response{
+//	    "POST /v1/users": {Status: http.StatusCreated, Body: `{"id": 1}`},
+//	})
+//	client := NewClient(srv.URL)
+//	// ...
+//	srv.ReceivedBodyJSONEq(t, "POST", "/v1/users", `{"name": "alice"}`)
+func NewServer(t testing.TB, handler any) *Server {
+	t.Helper()
+
+	var h http.Handler
+	switch v := handler.(type) {
+	case nil:
+		h = http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	case http.Handler:
+		h = v
+	case func(http.ResponseWriter, *http.Request):
+		h = http.HandlerFunc(v)
+	case map[string]Response:
+		h = cannedResponses(v)
+	default:
+		t.Fatalf("\nNewServer called with unsupported handler type: %s", typeName(handler))
+	}
+
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+
+		s.mu.Lock()
+		s.requests = append(s.requests, RecordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		s.mu.Unlock()
+
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// cannedResponses serves responses keyed by "METHOD /path" or "/path".
+func cannedResponses(responses map[string]Response) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			resp, ok = responses[r.URL.Path]
+		}
+		if !ok {
+			http.Error(w, "no canned response for "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+			return
+		}
+
+		for k, values := range resp.Header {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+		if resp.Status != 0 {
+			w.WriteHeader(resp.Status)
+		}
+		_, _ = io.WriteString(w, resp.Body)
+	})
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// ReceivedRequest checks that the server received a request with the given
+// method and path, and returns the last one for further checks. On failure,
+// the requests received are listed.
+func (s *Server) ReceivedRequest(t testing.TB, method, path string, msg ...string) RecordedRequest {
+	t.Helper()
+	defer observe(t)()
+
+	r, _ := s.received(t, method, path, msg)
+	return r
+}
+
+// ReceivedBodyJSONEq checks that the server received a request with the
+// given method and path, whose body is a JSON document equal to expected,
+// as JSONEq would. The last matching request is checked.
+func (s *Server) ReceivedBodyJSONEq(t testing.TB, method, path, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	r, ok := s.received(t, method, path, msg)
+	if !ok {
+		return
+	}
+
+	var opts []Option
+	if len(msg) > 0 && msg[0] != "" {
+		opts = append(opts, Message(msg[0]))
+	}
+	JSONEq(t, string(r.Body), expected, opts...)
+}
+
+// received returns the last request with the given method and path,
+// failing when there is none.
+func (s *Server) received(t testing.TB, method, path string, msg []string) (RecordedRequest, bool) {
+	t.Helper()
+
+	requests := s.Requests()
+	for i := len(requests) - 1; i >= 0; i-- {
+		if requests[i].Method == method && requests[i].Path == path {
+			return requests[i], true
+		}
+	}
+
+	received := make([]string, len(requests))
+	for i, r := range requests {
+		received[i] = r.Method + " " + r.Path
+	}
+	failCompare(t, fmt.Sprintf("received %d requests %v", len(requests), received), method+" "+path,
+		withMessage("request not received", msg)...)
+
+	return RecordedRequest{}, false
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func doRequest(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestNewServer(t *testing.T) {
+	t.Run("canned responses", func(t *testing.T) {
+		srv := NewServer(t, map[string]Response{
+			"POST /v1/users": {Status: http.StatusCreated, Body: `{"id": 1}`},
+			"/health":        {Body: "ok"},
+		})
+
+		tests := []struct {
+			method, path string
+			status       int
+			body         string
+		}{
+			{"POST", "/v1/users", http.StatusCreated, `{"id": 1}`},
+			{"GET", "/health", http.StatusOK, "ok"},
+			{"HEAD", "/health", http.StatusOK, ""},
+			{"GET", "/v1/users", http.StatusNotFound, "no canned response for GET /v1/users\n"},
+		}
+
+		for _, tt := range tests {
+			status, body := doRequest(t, tt.method, srv.URL+tt.path, "")
+			if status != tt.status || body != tt.body {
+				t.Errorf("%s %s = %d %q, want %d %q", tt.method, tt.path, status, body, tt.status, tt.body)
+			}
+		}
+	})
+
+	t.Run("handler function sees the body", func(t *testing.T) {
+		srv := NewServer(t, func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(data)
+		})
+
+		if _, body := doRequest(t, "PUT", srv.URL+"/echo", "hello"); body != "hello" {
+			t.Errorf("body = %q, want %q", body, "hello")
+		}
+		if got := srv.Requests(); len(got) != 1 || string(got[0].Body) != "hello" {
+			t.Errorf("Requests() = %+v", got)
+		}
+	})
+
+	t.Run("unsupported handler", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		rec.Call(func() { NewServer(rec, 42) })
+
+		if !strings.Contains(rec.ErrorMessage(), "unsupported handler type: int") {
+			t.Errorf("NewServer() message missing error\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestServerReceivedRequest(t *testing.T) {
+	srv := NewServer(t, nil)
+	doRequest(t, "GET", srv.URL+"/v1/users?page=2", "")
+	doRequest(t, "POST", srv.URL+"/v1/users", `{"name": "alice"}`)
+
+	t.Run("received", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		r := srv.ReceivedRequest(rec, "GET", "/v1/users")
+
+		if rec.HasError() {
+			t.Fatalf("ReceivedRequest() recorded error\n%s", rec.ErrorMessage())
+		}
+		if r.Query.Get("page") != "2" {
+			t.Errorf("ReceivedRequest() query = %v", r.Query)
+		}
+	})
+
+	t.Run("not received", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		srv.ReceivedRequest(rec, "DELETE", "/v1/users/1")
+
+		for _, part := range []string{"request not received", `"received 2 requests [GET /v1/users POST /v1/users]"`, `"DELETE /v1/users/1"`} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("ReceivedRequest() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestServerReceivedBodyJSONEq(t *testing.T) {
+	srv := NewServer(t, nil)
+	doRequest(t, "POST", srv.URL+"/v1/users", `{"name": "alice", "roles": ["admin"]}`)
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		expected  string
+		wantError bool
+		wantPart  string
+	}{
+		{"equal body", "POST", "/v1/users", `{"roles": ["admin"], "name": "alice"}`, false, ""},
+		{"different body", "POST", "/v1/users", `{"name": "bob", "roles": ["admin"]}`, true, `$.name: expected "bob", got "alice"`},
+		{"no request", "PUT", "/v1/users", `{}`, true, "request not received"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			srv.ReceivedBodyJSONEq(rec, tt.method, tt.path, tt.expected, "create user")
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ReceivedBodyJSONEq() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if tt.wantError && !strings.Contains(rec.ErrorMessage(), "Message: create user: ") {
+				t.Errorf("ReceivedBodyJSONEq() message missing custom message\ngot: %s", rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("ReceivedBodyJSONEq() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Spy wraps a function value of type F to record its calls, checked with
+// CalledTimes and CalledWith. It is safe for concurrent use:
+//
+//	spy := assert.NewSpy(func(id int, name string) error { return nil })
+//	svc := NewService(spy.Func())
+//	svc.Rename(42, "alice")
+//
+//	assert.CalledTimes(t, spy, 1)
+//	assert.CalledWith(t, spy, 42, "alice")
+type Spy[F any] struct {
+	mu    sync.Mutex
+	fn    F
+	calls [][]any
+}
+
+// NewSpy creates a Spy of fn, which is called with the recorded arguments.
+// A nil fn returns the zero values of its results. It panics if F is not
+// a function type.
+func NewSpy[F any](fn F) *Spy[F] {
+	typ := reflect.TypeOf(&fn).Elem()
+	if typ.Kind() != reflect.Func {
+		panic(fmt.Sprintf("NewSpy called with non-function type %s", typ))
+	}
+
+	s := &Spy[F]{}
+	target := reflect.ValueOf(&fn).Elem()
+	wrapper := reflect.MakeFunc(typ, func(in []reflect.Value) []reflect.Value {
+		args := make([]any, len(in))
+		for i, v := range in {
+			args[i] = v.Interface()
+		}
+		s.mu.Lock()
+		s.calls = append(s.calls, args)
+		s.mu.Unlock()
+
+		if target.IsNil() {
+			out := make([]reflect.Value, typ.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(typ.Out(i))
+			}
+			return out
+		}
+		if typ.IsVariadic() {
+			return target.CallSlice(in)
+		}
+		return target.Call(in)
+	})
+	s.fn = wrapper.Interface().(F)
+	return s
+}
+
+// Func returns the function recording the calls, to inject in the tested code.
+func (s *Spy[F]) Func() F {
+	return s.fn
+}
+
+// Calls returns the arguments of each recorded call, in order.
+// The arguments of a variadic parameter are given as a slice.
+func (s *Spy[F]) Calls() [][]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]any(nil), s.calls...)
+}
+
+// CalledTimes checks if the function of spy was called n times.
+// On failure, the arguments of the recorded calls are listed.
+func CalledTimes[F any](t testing.TB, spy *Spy[F], n int, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	calls := spy.Calls()
+	if len(calls) != n {
+		failCompareNote(t, len(calls), n, formatCalls(calls), withMessage("unexpected number of calls", msg)...)
+	}
+}
+
+// CalledWith checks if the function of spy was called at least once with
+// args. Numbers and strings are converted to the types of the parameters
+// when the conversion is exact, so that CalledWith(t, spy, 42) matches a
+// call of func(int64) with 42. Inexact conversions are not made, so that
+// 1.9 does not match a call of func(int) with 1, nor 300 one of func(int8).
+func CalledWith[F any](t testing.TB, spy *Spy[F], args ...any) {
+	t.Helper()
+	defer observe(t)()
+
+	typ := reflect.TypeOf(&spy.fn).Elem()
+	expected := make([]any, len(args))
+	for i, arg := range args {
+		expected[i] = arg
+		if i < typ.NumIn() {
+			expected[i] = convertArg(arg, typ.In(i))
+		}
+	}
+
+	calls := spy.Calls()
+	for _, call := range calls {
+		if isEqual(call, expected) {
+			return
+		}
+	}
+
+	failCompareNote[any](t, fmt.Sprintf("%d calls", len(calls)), expected,
+		formatCalls(calls), withMessage("no call with expected arguments", nil)...)
+}
+
+// convertArg converts arg to the parameter type typ, when both are
+// numbers or both are strings, and the conversion loses nothing: the
+// converted value converts back to arg, with the same sign.
+func convertArg(arg any, typ reflect.Type) any {
+	if arg == nil {
+		switch typ.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(typ).Interface()
+		}
+		return arg
+	}
+
+	v := reflect.ValueOf(arg)
+	if v.Type() == typ || !v.Type().ConvertibleTo(typ) {
+		return arg
+	}
+	if !(isNumberKind(v.Kind()) && isNumberKind(typ.Kind())) &&
+		!(v.Kind() == reflect.String && typ.Kind() == reflect.String) {
+		return arg
+	}
+	converted := v.Convert(typ)
+	if converted.Convert(v.Type()).Interface() != arg || isNegative(converted) != isNegative(v) {
+		return arg
+	}
+	return converted.Interface()
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// isNegative reports whether v is a negative number, since conversions
+// between signed and unsigned integers round-trip with a sign change.
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+// formatCalls lists the arguments of calls, limited to maxSliceDiffs calls.
+func formatCalls(calls [][]any) string {
+	if len(calls) == 0 {
+		return "never called"
+	}
+
+	lines := make([]string, 0, len(calls))
+	for i, call := range calls {
+		if i == maxSliceDiffs {
+			lines = append(lines, fmt.Sprintf("and %d more", len(calls)-maxSliceDiffs))
+			break
+		}
+		lines = append(lines, formatArgs(call))
+	}
+	return "calls: " + strings.Join(lines, "; ")
+}
+
+// formatArgs formats the arguments of a call, such as (42, "alice").
+func formatArgs(args []any) string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = fmt.Sprintf("%#v", arg)
+	}
+	return "(" + strings.Join(s, ", ") + ")"
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewSpy(t *testing.T) {
+	t.Run("calls the function", func(t *testing.T) {
+		spy := NewSpy(func(a, b int) int { return a + b })
+
+		Equal(t, spy.Func()(1, 2), 3)
+		Equal(t, spy.Calls(), [][]any{{1, 2}})
+	})
+
+	t.Run("nil function returns zero values", func(t *testing.T) {
+		var fn func(string) (int, error)
+		spy := NewSpy(fn)
+
+		n, err := spy.Func()("x")
+
+		Equal(t, n, 0)
+		NoError(t, err)
+		Equal(t, spy.Calls(), [][]any{{"x"}})
+	})
+
+	t.Run("variadic function", func(t *testing.T) {
+		spy := NewSpy(func(prefix string, values ...int) int { return len(values) })
+
+		Equal(t, spy.Func()("a", 1, 2), 2)
+		Equal(t, spy.Calls(), [][]any{{"a", []int{1, 2}}})
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		spy := NewSpy(func() {})
+		fn := spy.Func()
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fn()
+			}()
+		}
+		wg.Wait()
+
+		Len(t, spy.Calls(), 10)
+	})
+
+	t.Run("non-function type", func(t *testing.T) {
+		Panics(t, func() { NewSpy(42) }, "NewSpy called with non-function type int")
+	})
+}
+
+func TestCalledTimes(t *testing.T) {
+	tests := []struct {
+		name      string
+		calls     []int
+		n         int
+		wantError bool
+		wantNote  string
+	}{
+		{"never called", nil, 0, false, ""},
+		{"called twice", []int{1, 2}, 2, false, ""},
+		{"not called", nil, 1, true, "Note: never called"},
+		{"called too often", []int{1, 2}, 1, true, "Note: calls: (1); (2)"},
+		{"many calls", []int{1, 2, 3, 4, 5, 6, 7}, 1, true, "(5); and 2 more"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			spy := NewSpy(func(int) {})
+			for _, arg := range tt.calls {
+				spy.Func()(arg)
+			}
+
+			CalledTimes(rec, spy, tt.n)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CalledTimes() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("CalledTimes() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestCalledWith(t *testing.T) {
+	spy := NewSpy(func(id int64, name string, err error) {})
+	spy.Func()(42, "alice", nil)
+	spy.Func()(7, "bob", errors.New("boom"))
+
+	tests := []struct {
+		name      string
+		args      []any
+		wantError bool
+		wantParts []string
+	}{
+		{"first call with converted constants", []any{42, "alice", nil}, false, nil},
+		{"second call", []any{int64(7), "bob", errors.New("boom")}, false, nil},
+		{
+			name:      "no matching call",
+			args:      []any{42, "bob", nil},
+			wantError: true,
+			wantParts: []string{
+				"no call with expected arguments",
+				`[]interface {}{42, "bob", interface {}(nil)}`,
+				`Actual: (string) "2 calls"`,
+				`calls: (42, "alice", <nil>); (7, "bob", &errors.errorString{s:"boom"})`,
+			},
+		},
+		{
+			name:      "different argument count",
+			args:      []any{42},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			CalledWith(rec, spy, tt.args...)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("CalledWith() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("CalledWith() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestCalledWithInexactConversions(t *testing.T) {
+	ints := NewSpy(func(n int) {})
+	ints.Func()(1)
+	int8s := NewSpy(func(n int8) {})
+	int8s.Func()(44)
+	floats := NewSpy(func(f float32) {})
+	floats.Func()(0.5)
+	uints := NewSpy(func(n uint) {})
+	uints.Func()(^uint(0))
+
+	tests := []struct {
+		name      string
+		assert    func(t testing.TB)
+		wantError bool
+	}{
+		{"exact float to int", func(t testing.TB) { CalledWith(t, ints, 1.0) }, false},
+		{"fraction dropped", func(t testing.TB) { CalledWith(t, ints, 1.9) }, true},
+		{"exact int to int8", func(t testing.TB) { CalledWith(t, int8s, 44) }, false},
+		{"overflow", func(t testing.TB) { CalledWith(t, int8s, 300) }, true},
+		{"negative to unsigned", func(t testing.TB) { CalledWith(t, uints, -1) }, true},
+		{"exact float64 to float32", func(t testing.TB) { CalledWith(t, floats, 0.5) }, false},
+		{"rounded float64 to float32", func(t testing.TB) { CalledWith(t, floats, 0.5000000001) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			Equal(t, rec.HasError(), tt.wantError, rec.ErrorMessage())
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// testState holds the bookkeeping of a test for opt-in features
+// such as RequireAssertions. States are keyed by test name, so that
+// assertions made in subtests or through wrappers of the test's TB
+// are attributed to it.
+type testState struct {
+	mu         sync.Mutex
+	assertions int
+}
+
+var (
+	statesMu    sync.RWMutex
+	states      = map[string]*testState{}
+	statesCount int32
+)
+
+// RequireAssertions fails the test if it completes without executing
+// any assertion of this package. Assertions made in its subtests count.
+// It catches tests that silently skip their checks because of early
+// returns or misconfigured table cases.
+func RequireAssertions(t testing.TB) {
+	t.Helper()
+
+	s := registerState(t)
+
+	t.Cleanup(func() {
+		s.mu.Lock()
+		n := s.assertions
+		s.mu.Unlock()
+
+		if n == 0 && !t.Skipped() {
+			t.Error("\ntest completed without executing any assertion")
+		}
+	})
+}
+
+// registerState returns the state of t, creating it if needed.
+// The state is forgotten when t completes.
+func registerState(t testing.TB) *testState {
+	name := t.Name()
+
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	if s, ok := states[name]; ok {
+		return s
+	}
+
+	s := &testState{}
+	states[name] = s
+	atomic.AddInt32(&statesCount, 1)
+
+	t.Cleanup(func() {
+		statesMu.Lock()
+		defer statesMu.Unlock()
+
+		delete(states, name)
+		atomic.AddInt32(&statesCount, -1)
+	})
+
+	return s
+}
+
+// lookupStates returns the states registered for t and its parent tests.
+func lookupStates(t testing.TB) []*testState {
+	if atomic.LoadInt32(&statesCount) == 0 {
+		return nil
+	}
+
+	statesMu.RLock()
+	defer statesMu.RUnlock()
+
+	var found []*testState
+	name := t.Name()
+
+	for {
+		if s, ok := states[name]; ok {
+			found = append(found, s)
+		}
+		i := strings.LastIndexByte(name, '/')
+		if i < 0 {
+			return found
+		}
+		name = name[:i]
+	}
+}
+
+// observe records that an assertion is being executed on t, and returns
+// the function to defer until it ends. It is called by every assertion
+// and costs nothing unless a feature relying on it is enabled.
+func observe(t testing.TB) func() {
+	for _, s := range lookupStates(t) {
+		s.mu.Lock()
+		s.assertions++
+		s.mu.Unlock()
+	}
+
+	tr, sm := lookupTracer(t), lookupSummary(t)
+	switch {
+	case tr != nil && sm != nil:
+		endTrace, endSummary := tr.begin(t, assertionName(1)), sm.begin(t)
+		return func() {
+			t.Helper()
+			endSummary()
+			endTrace()
+		}
+	case tr != nil:
+		return tr.begin(t, assertionName(1))
+	case sm != nil:
+		return sm.begin(t)
+	}
+	return noTrace
+}
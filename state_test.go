@@ -0,0 +1,57 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import "testing"
+
+func TestRequireAssertions(t *testing.T) {
+	t.Run("assertions executed", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RequireAssertions(rec)
+		Equal(rec, 1, 1)
+		rec.RunCleanups()
+
+		if rec.HasError() {
+			t.Errorf("RequireAssertions() reported %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("no assertion executed", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RequireAssertions(rec)
+		rec.RunCleanups()
+
+		if !rec.HasError() {
+			t.Error("RequireAssertions() did not report missing assertions")
+		}
+	})
+
+	t.Run("assertions in subtests and wrappers count", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RequireAssertions(rec)
+
+		t.Run("child", func(t *testing.T) {
+			True(New(t), true)
+		})
+		rec.RunCleanups()
+
+		if rec.HasError() {
+			t.Errorf("RequireAssertions() reported %q", rec.ErrorMessage())
+		}
+	})
+
+	t.Run("state is forgotten after cleanup", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		RequireAssertions(rec)
+		rec.RunCleanups()
+
+		if states := lookupStates(rec); len(states) != 0 {
+			t.Errorf("lookupStates() = %d states, want 0", len(states))
+		}
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// SuiteSetup is implemented by suites that need to run code
+// once before any of their tests.
+type SuiteSetup interface {
+	SetupSuite(a *Assert)
+}
+
+// SuiteTearDown is implemented by suites that need to run code
+// once after all of their tests.
+type SuiteTearDown interface {
+	TearDownSuite(a *Assert)
+}
+
+// TestSetup is implemented by suites that need to run code
+// before each test.
+type TestSetup interface {
+	SetupTest(a *Assert)
+}
+
+// TestTearDown is implemented by suites that need to run code
+// after each test.
+type TestTearDown interface {
+	TearDownTest(a *Assert)
+}
+
+// RunSuite runs every method of suite whose name starts with "Test"
+// as a subtest. Test methods must have the signature func(*Assert).
+// The optional lifecycle hooks SetupSuite, TearDownSuite, SetupTest
+// and TearDownTest are called around them.
+func RunSuite(t *testing.T, suite any) {
+	t.Helper()
+
+	a := New(t)
+
+	if s, ok := suite.(SuiteSetup); ok {
+		s.SetupSuite(a)
+	}
+	if s, ok := suite.(SuiteTearDown); ok {
+		defer s.TearDownSuite(a)
+	}
+
+	v := reflect.ValueOf(suite)
+
+	for i := 0; i < v.NumMethod(); i++ {
+		method := v.Type().Method(i)
+		if !strings.HasPrefix(method.Name, "Test") {
+			continue
+		}
+
+		fn := v.Method(i)
+		if !isSuiteTest(fn.Type()) {
+			t.Errorf("\nsuite method %s has signature %v, want func(*assert.Assert)", method.Name, fn.Type())
+			continue
+		}
+
+		t.Run(method.Name, func(t *testing.T) {
+			a := New(t)
+
+			if s, ok := suite.(TestSetup); ok {
+				s.SetupTest(a)
+			}
+			if s, ok := suite.(TestTearDown); ok {
+				defer s.TearDownTest(a)
+			}
+
+			fn.Call([]reflect.Value{reflect.ValueOf(a)})
+		})
+	}
+}
+
+// isSuiteTest reports whether a method type is func(*Assert).
+func isSuiteTest(typ reflect.Type) bool {
+	return typ.NumIn() == 1 &&
+		typ.In(0) == reflect.TypeOf((*Assert)(nil)) &&
+		typ.NumOut() == 0
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+// recordingSuite records the order in which RunSuite invokes its methods.
+type recordingSuite struct {
+	calls []string
+}
+
+func (s *recordingSuite) SetupSuite(*Assert)    { s.calls = append(s.calls, "SetupSuite") }
+func (s *recordingSuite) TearDownSuite(*Assert) { s.calls = append(s.calls, "TearDownSuite") }
+func (s *recordingSuite) SetupTest(*Assert)     { s.calls = append(s.calls, "SetupTest") }
+func (s *recordingSuite) TearDownTest(*Assert)  { s.calls = append(s.calls, "TearDownTest") }
+
+func (s *recordingSuite) TestFirst(a *Assert) {
+	s.calls = append(s.calls, "TestFirst")
+	NotNil(a, a.TB)
+}
+
+func (s *recordingSuite) TestSecond(a *Assert) {
+	s.calls = append(s.calls, "TestSecond")
+	Equal(a, a.Name(), "TestRunSuite/TestSecond")
+}
+
+// Helper is not a test method and must be ignored.
+func (s *recordingSuite) Helper() {
+	s.calls = append(s.calls, "Helper")
+}
+
+func TestRunSuite(t *testing.T) {
+	s := &recordingSuite{}
+
+	RunSuite(t, s)
+
+	want := []string{
+		"SetupSuite",
+		"SetupTest", "TestFirst", "TearDownTest",
+		"SetupTest", "TestSecond", "TearDownTest",
+		"TearDownSuite",
+	}
+
+	if !reflect.DeepEqual(s.calls, want) {
+		t.Errorf("RunSuite() calls = %v, want %v", s.calls, want)
+	}
+}
+
+func TestIsSuiteTest(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   any
+		want bool
+	}{
+		{
+			name: "assert parameter",
+			fn:   func(*Assert) {},
+			want: true,
+		},
+		{
+			name: "testing parameter",
+			fn:   func(*testing.T) {},
+			want: false,
+		},
+		{
+			name: "extra result",
+			fn:   func(*Assert) error { return nil },
+			want: false,
+		},
+		{
+			name: "no parameter",
+			fn:   func() {},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSuiteTest(reflect.TypeOf(tt.fn)); got != tt.want {
+				t.Errorf("isSuiteTest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
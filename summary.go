@@ -0,0 +1,129 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// summary counts the assertions made through an Assert, see WithSummary.
+type summary struct {
+	mu sync.Mutex
+	// depth is the number of assertions running, more than one when
+	// assertions are made by others.
+	depth int
+	// failures is the number of failures reported.
+	failures int
+	// assertions is the number of assertions made, and lines holds the
+	// lines of those that failed.
+	assertions int
+	lines      []int
+}
+
+// WithSummary returns a copy of a reporting each failure on a single line,
+// without its diff, and logging a summary of its assertions at the end of
+// the test, for table tests whose failures flood the log:
+//
+//	a := assert.New(t).WithSummary()
+//	for _, tc := range cases {
+//	    assert.Equal(a, Slugify(tc.in), tc.want)
+//	}
+//	// slug_test.go:42: Expected: (string) "a-b"; Actual: (string) "a_b"
+//	// slug_test.go:39: 120 assertions, 3 failed: lines 42, 42, 42
+//
+// Subtests of a are summarized with the test of a.
+func (a *Assert) WithSummary() *Assert {
+	a.TB.Helper()
+
+	child := *a
+	sm := &summary{}
+	child.summary = sm
+
+	a.Cleanup(func() {
+		a.Helper()
+
+		sm.mu.Lock()
+		n, lines := sm.assertions, sm.lines
+		sm.mu.Unlock()
+
+		if len(lines) == 0 {
+			a.Logf("%d assertions, all passed", n)
+			return
+		}
+		nums := make([]string, len(lines))
+		for i, line := range lines {
+			nums[i] = strconv.Itoa(line)
+		}
+		a.Logf("%d assertions, %d failed: lines %s", n, len(lines), strings.Join(nums, ", "))
+	})
+	return &child
+}
+
+// lookupSummary returns the summary of the assertions made through t,
+// or nil when they are not summarized.
+func lookupSummary(t testing.TB) *summary {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			return nil
+		}
+		if a.summary != nil {
+			return a.summary
+		}
+		t = a.TB
+	}
+}
+
+// begin starts counting an assertion made through t, and returns the
+// function recording whether it failed when the assertion ends.
+func (sm *summary) begin(t testing.TB) func() {
+	sm.mu.Lock()
+	sm.depth++
+	top, failures := sm.depth == 1, sm.failures
+	sm.mu.Unlock()
+
+	return func() {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+
+		sm.depth--
+		if !top {
+			return
+		}
+		sm.assertions++
+		if sm.failures > failures {
+			frame, _ := callerFrame(t)
+			sm.lines = append(sm.lines, frame.Line)
+		}
+	}
+}
+
+// fail records a failure of the running assertion.
+func (sm *summary) fail() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.failures++
+}
+
+// collapseFailure joins the lines of a failure message on a single line,
+// leaving out its diff.
+func collapseFailure(s string) string {
+	diff := strings.TrimSpace(newFailureLabels().diff)
+
+	var parts []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == diff {
+			break
+		}
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
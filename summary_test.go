@@ -0,0 +1,71 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestWithSummary(t *testing.T) {
+	t.Run("collapsed failures and summary", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec).WithSummary()
+
+		_, _, line, _ := runtime.Caller(0)
+		Equal(a, "a\nb", "a\nc", "text differs")
+		True(a, true)
+		Equal(a, 1, 2)
+		rec.RunCleanups()
+
+		Equal(t, rec.Failures(), []string{
+			`Message: text differs; Expected: (string) "a\nc"; Actual: (string) "a\nb"`,
+			"Expected: (int) 2; Actual: (int) 1",
+		})
+		Equal(t, rec.Logs(), []string{fmt.Sprintf("3 assertions, 2 failed: lines %d, %d", line+1, line+3)})
+	})
+
+	t.Run("all passed", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec).WithSummary()
+
+		Equal(a, 1, 1)
+		Nil(New(a).WithLabel("x"), nil)
+		rec.RunCleanups()
+
+		Equal(t, rec.Logs(), []string{"2 assertions, all passed"})
+	})
+
+	t.Run("with labels and trace", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+		a := New(rec).WithSummary().WithTrace().WithLabel("user 42")
+
+		_, _, line, _ := runtime.Caller(0)
+		Equal(a, 1, 2)
+		True(a, true)
+		rec.RunCleanups()
+
+		Equal(t, rec.Failures(), []string{"user 42: Expected: (int) 2; Actual: (int) 1"})
+		Equal(t, rec.Logs(), []string{"PASS True", "2 assertions, 1 failed: lines " + fmt.Sprint(line+1)})
+	})
+}
+
+func TestCollapseFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"single line", "value is nil", "value is nil"},
+		{"labels", "\n Message: m\nExpected: 1\n  Actual: 2\n", "Message: m; Expected: 1; Actual: 2"},
+		{"diff left out", "\nExpected: a\n    Diff:\n    -a\n    +b\n", "Expected: a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Equal(t, collapseFailure(tt.message), tt.want)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+// Case describes a single case of a table-driven test run by Table.
+type Case[T any] struct {
+	// Name is the name of the subtest created for the case.
+	Name string
+
+	// Run produces the value and error under test.
+	Run func() (T, error)
+
+	// Want is the value expected from Run when no error is expected.
+	Want T
+
+	// WantErr, when set, is the error expected from Run (matched with ErrorIs).
+	WantErr error
+
+	// Check, when set, replaces the default assertions on the result of Run.
+	Check func(t testing.TB, got T, err error)
+}
+
+// Table runs each case as a subtest. By default, a case passes when Run
+// returns Want with no error, or an error matching WantErr.
+// When some cases fail, a summary listing them is logged on the parent test.
+func Table[T any](t *testing.T, cases []Case[T]) {
+	t.Helper()
+
+	var failed []string
+
+	for _, c := range cases {
+		c := c
+
+		ok := t.Run(c.Name, func(t *testing.T) {
+			t.Helper()
+
+			got, err := c.Run()
+
+			switch {
+			case c.Check != nil:
+				c.Check(t, got, err)
+			case c.WantErr != nil:
+				ErrorIs(t, err, c.WantErr)
+			default:
+				NoError(t, err)
+				Equal(t, got, c.Want)
+			}
+		})
+
+		if !ok {
+			failed = append(failed, c.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		t.Logf("\n%d of %d cases failed:\n  - %s", len(failed), len(cases), strings.Join(failed, "\n  - "))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	errNegative := errors.New("negative")
+
+	parse := func(s string) func() (int, error) {
+		return func() (int, error) {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return 0, err
+			}
+			if n < 0 {
+				return 0, errNegative
+			}
+			return n, nil
+		}
+	}
+
+	var checked bool
+
+	Table(t, []Case[int]{
+		{
+			Name: "valid number",
+			Run:  parse("42"),
+			Want: 42,
+		},
+		{
+			Name:    "expected error",
+			Run:     parse("-1"),
+			WantErr: errNegative,
+		},
+		{
+			Name: "custom check",
+			Run:  parse("abc"),
+			Check: func(t testing.TB, got int, err error) {
+				checked = true
+
+				var numErr *strconv.NumError
+				ErrorAs(t, err, &numErr)
+				Equal(t, got, 0)
+			},
+		},
+	})
+
+	if !checked {
+		t.Error("Table() did not call Check")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// Template is the interface implemented by both text/template
+// and html/template templates.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateRenders executes tmpl with data and checks that its output equals
+// expected. Execution errors are reported as failures, and differing outputs
+// are shown as a line diff:
+//
+//	tmpl := template.Must(template.ParseFiles("testdata/email.tmpl"))
+//	assert.TemplateRenders(t, tmpl, user, "Hello alice,\n...")
+func TemplateRenders(t testing.TB, tmpl Template, data any, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		failCompare(t, err.Error(), "successful execution", withMessage("template execution failed", msg)...)
+		return
+	}
+
+	if actual := b.String(); actual != expected {
+		failCompareDiff(t, actual, expected, diffText(t, expected, actual), withMessage("unexpected template output", msg)...)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateRenders(t *testing.T) {
+	text := template.Must(template.New("email").Parse("Hello {{.Name}},\nYou have {{.Count}} messages.\n"))
+	html := htmltemplate.Must(htmltemplate.New("page").Parse(`<p>{{.Name}}</p>`))
+	failing := template.Must(template.New("fail").Parse("{{.Missing.Field}}"))
+
+	type user struct {
+		Name  string
+		Count int
+	}
+
+	tests := []struct {
+		name      string
+		tmpl      Template
+		data      any
+		expected  string
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "text template",
+			tmpl:      text,
+			data:      user{Name: "alice", Count: 2},
+			expected:  "Hello alice,\nYou have 2 messages.\n",
+			wantError: false,
+		},
+		{
+			name:      "html template escapes",
+			tmpl:      html,
+			data:      user{Name: "<b>"},
+			expected:  "<p>&lt;b&gt;</p>",
+			wantError: false,
+		},
+		{
+			name:      "different output",
+			tmpl:      text,
+			data:      user{Name: "alice", Count: 3},
+			expected:  "Hello alice,\nYou have 2 messages.\n",
+			wantError: true,
+			wantParts: []string{
+				"unexpected template output",
+				"Diff:",
+				"-You have 2 messages.",
+				"+You have 3 messages.",
+			},
+		},
+		{
+			name:      "execution error",
+			tmpl:      failing,
+			data:      user{},
+			expected:  "",
+			wantError: true,
+			wantParts: []string{"template execution failed", "can't evaluate field Missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			TemplateRenders(rec, tt.tmpl, tt.data, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("TemplateRenders() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("TemplateRenders() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// IsRFC3339 checks if a string is a valid RFC 3339 timestamp, as produced
+// by time.Time's MarshalJSON, and returns the parsed time.
+func IsRFC3339(t testing.TB, s string, msg ...string) time.Time {
+	t.Helper()
+	defer observe(t)()
+
+	return parseTime(t, s, time.RFC3339, msg)
+}
+
+// ParsesAsTime checks if a string parses with the given layout and returns
+// the parsed time, for further assertions. It returns the zero time on failure.
+//
+//	tm := assert.ParsesAsTime(t, rec.Header().Get("Last-Modified"), http.TimeFormat)
+//	assert.TimeBetween(t, tm, start, end)
+func ParsesAsTime(t testing.TB, s, layout string, msg ...string) time.Time {
+	t.Helper()
+	defer observe(t)()
+
+	return parseTime(t, s, layout, msg)
+}
+
+// TimeBetween checks if a time falls inside the window [start, end].
+// On failure, it reports how far outside the window the time was:
+//
+//	before := time.Now()
+//	user := CreateUser()
+//	assert.TimeBetween(t, user.CreatedAt, before, time.Now())
+func TimeBetween(t testing.TB, actual, start, end time.Time, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	window := fmt.Sprintf("between %s and %s", formatTime(start), formatTime(end))
+
+	switch {
+	case actual.Before(start):
+		failCompare(t, formatTime(actual), window,
+			withMessage(fmt.Sprintf("time is %v before start", start.Sub(actual)), msg)...)
+	case actual.After(end):
+		failCompare(t, formatTime(actual), window,
+			withMessage(fmt.Sprintf("time is %v after end", actual.Sub(end)), msg)...)
+	}
+}
+
+// TimesIncreasing checks if times are strictly increasing,
+// reporting the first time that is not after the previous one.
+func TimesIncreasing(t testing.TB, times []time.Time, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkTimesOrder(t, times, true, msg)
+}
+
+// TimesNonDecreasing checks if times are in chronological order,
+// allowing equal times, and reports the first time before the previous one.
+func TimesNonDecreasing(t testing.TB, times []time.Time, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	checkTimesOrder(t, times, false, msg)
+}
+
+// checkTimesOrder reports the first inversion in times.
+func checkTimesOrder(t testing.TB, times []time.Time, strict bool, msg []string) {
+	t.Helper()
+
+	for i := 1; i < len(times); i++ {
+		prev, cur := times[i-1], times[i]
+		if cur.After(prev) || (!strict && cur.Equal(prev)) {
+			continue
+		}
+
+		want := "after"
+		if !strict {
+			want = "not before"
+		}
+		inversion := fmt.Sprintf("time at index %d is %v before index %d", i, prev.Sub(cur), i-1)
+		if cur.Equal(prev) {
+			inversion = fmt.Sprintf("time at index %d equals index %d", i, i-1)
+		}
+		failCompare(t, formatTime(cur), want+" "+formatTime(prev), withMessage(inversion, msg)...)
+		return
+	}
+}
+
+// parseTime parses s with layout, reporting a failure when it does not parse.
+func parseTime(t testing.TB, s, layout string, msg []string) time.Time {
+	t.Helper()
+
+	tm, err := time.Parse(layout, s)
+	if err != nil {
+		failCompare(t, s, "time in layout "+layout, withMessage(err.Error(), msg)...)
+		return time.Time{}
+	}
+	return tm
+}
+
+// formatTime formats a time for failure messages.
+func formatTime(tm time.Time) string {
+	return tm.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsesAsTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		layout    string
+		want      time.Time
+		wantError bool
+	}{
+		{
+			name:      "date layout",
+			s:         "2025-03-14",
+			layout:    "2006-01-02",
+			want:      time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			wantError: false,
+		},
+		{
+			name:      "kitchen layout",
+			s:         "3:04PM",
+			layout:    time.Kitchen,
+			want:      time.Date(0, 1, 1, 15, 4, 0, 0, time.UTC),
+			wantError: false,
+		},
+		{
+			name:      "wrong layout",
+			s:         "14/03/2025",
+			layout:    "2006-01-02",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			got := ParsesAsTime(rec, tt.s, tt.layout)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("ParsesAsTime() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParsesAsTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("failure message", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		ParsesAsTime(rec, "2025-13-01", "2006-01-02", "created date")
+
+		for _, part := range []string{"Message: created date: parsing time", "month out of range", `"time in layout 2006-01-02"`} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("ParsesAsTime() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+}
+
+func TestIsRFC3339(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		wantError bool
+	}{
+		{"utc", "2025-01-01T12:00:00Z", false},
+		{"offset and fraction", "2025-01-01T12:00:00.123+02:00", false},
+		{"missing zone", "2025-01-01T12:00:00", true},
+		{"date only", "2025-01-01", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			IsRFC3339(rec, tt.s)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("IsRFC3339() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestTimeBetween(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		actual    time.Time
+		wantError bool
+		wantParts []string
+	}{
+		{
+			name:      "inside window",
+			actual:    start.Add(30 * time.Minute),
+			wantError: false,
+		},
+		{
+			name:      "at start",
+			actual:    start,
+			wantError: false,
+		},
+		{
+			name:      "at end",
+			actual:    end,
+			wantError: false,
+		},
+		{
+			name:      "before start",
+			actual:    start.Add(-90 * time.Second),
+			wantError: true,
+			wantParts: []string{
+				"time is 1m30s before start",
+				"between 2025-01-01T12:00:00Z and 2025-01-01T13:00:00Z",
+				"2025-01-01T11:58:30Z",
+			},
+		},
+		{
+			name:      "after end",
+			actual:    end.Add(time.Millisecond),
+			wantError: true,
+			wantParts: []string{"time is 1ms after end"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			TimeBetween(rec, tt.actual, start, end)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("TimeBetween() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(rec.ErrorMessage(), part) {
+					t.Errorf("TimeBetween() message missing %q\ngot: %s", part, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestTimesOrder(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds ...int) []time.Time {
+		times := make([]time.Time, len(seconds))
+		for i, s := range seconds {
+			times[i] = base.Add(time.Duration(s) * time.Second)
+		}
+		return times
+	}
+
+	tests := []struct {
+		name          string
+		times         []time.Time
+		wantStrict    bool
+		wantNonStrict bool
+	}{
+		{"empty", nil, false, false},
+		{"single", at(1), false, false},
+		{"increasing", at(1, 2, 3), false, false},
+		{"equal times", at(1, 2, 2, 3), true, false},
+		{"inversion", at(1, 3, 2), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+			TimesIncreasing(rec, tt.times)
+			if tt.wantStrict != rec.HasError() {
+				t.Errorf("TimesIncreasing() error = %v, want %v", rec.HasError(), tt.wantStrict)
+			}
+
+			rec = NewTestRecorder(t)
+			TimesNonDecreasing(rec, tt.times)
+			if tt.wantNonStrict != rec.HasError() {
+				t.Errorf("TimesNonDecreasing() error = %v, want %v", rec.HasError(), tt.wantNonStrict)
+			}
+		})
+	}
+
+	t.Run("first inversion is reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		TimesIncreasing(rec, at(1, 5, 3, 2))
+
+		if rec.FailureCount() != 1 {
+			t.Errorf("TimesIncreasing() reported %d failures, want 1", rec.FailureCount())
+		}
+		for _, part := range []string{"time at index 2 is 2s before index 1", "after 2025-01-01T00:00:05Z"} {
+			if !strings.Contains(rec.ErrorMessage(), part) {
+				t.Errorf("TimesIncreasing() message missing %q\ngot: %s", part, rec.ErrorMessage())
+			}
+		}
+	})
+
+	t.Run("equal times are reported", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		TimesIncreasing(rec, at(1, 1))
+
+		if !strings.Contains(rec.ErrorMessage(), "time at index 1 equals index 0") {
+			t.Errorf("TimesIncreasing() message missing equality\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
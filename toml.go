@@ -0,0 +1,781 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TOMLEq checks if two TOML documents are semantically equal, ignoring
+// formatting, comments, the order of keys and the way tables are written:
+// a table defined with a header equals the same table written inline or
+// with dotted keys. Differences are reported by key path, as in
+// "servers[1].port: expected 8080, got 80":
+//
+//	assert.TOMLEq(t, string(config), `
+//	    [server]
+//	    host = "localhost"
+//	    port = 8080
+//	`)
+//
+// Values keep their TOML types: the integer 1 differs from the float 1.0.
+// Offset date-times are equal when they denote the same instant.
+func TOMLEq(t testing.TB, actual, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	e, err := readTOML(expected)
+	if err != nil {
+		failCompare(t, expected, "valid TOML", withMessage("invalid expected TOML: "+err.Error(), msg)...)
+		return
+	}
+	a, err := readTOML(actual)
+	if err != nil {
+		failCompare(t, actual, "valid TOML", withMessage("invalid actual TOML: "+err.Error(), msg)...)
+		return
+	}
+
+	if diffs := diffTOML(a, e, ""); len(diffs) > 0 {
+		if len(diffs) > maxSliceDiffs {
+			diffs = append(diffs[:maxSliceDiffs], fmt.Sprintf("and %d more", len(diffs)-maxSliceDiffs))
+		}
+		failCompareNote(t, actual, expected, strings.Join(diffs, "; "), withMessage("TOML documents differ", msg)...)
+	}
+}
+
+// tomlLocal is a local date-time, date or time, which has no offset
+// and is compared by its normalized text: an uppercase T separator and
+// fractional seconds without trailing zeros.
+type tomlLocal struct {
+	kind string
+	text string
+}
+
+// diffTOML compares two decoded TOML values, and describes
+// their differences prefixed by their key path.
+func diffTOML(a, e any, path string) []string {
+	switch ev := e.(type) {
+	case map[string]any:
+		av, ok := a.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected table, got %s", path, tomlKind(a))}
+		}
+		return diffTOMLTables(av, ev, path)
+	case []any:
+		av, ok := a.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %s", path, tomlKind(a))}
+		}
+		var diffs []string
+		if len(av) != len(ev) {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(ev), len(av)))
+		}
+		for i := 0; i < len(av) && i < len(ev); i++ {
+			diffs = append(diffs, diffTOML(av[i], ev[i], fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return diffs
+	}
+
+	if tomlKind(a) != tomlKind(e) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, tomlKind(e), tomlKind(a))}
+	}
+	if !equalTOMLScalars(a, e) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, formatTOML(e), formatTOML(a))}
+	}
+	return nil
+}
+
+func diffTOMLTables(a, e map[string]any, path string) []string {
+	keys := make([]string, 0, len(a)+len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	for k := range a {
+		if _, ok := e[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []string
+	for _, k := range keys {
+		av, inActual := a[k]
+		ev, inExpected := e[k]
+		p := tomlPath(path, k)
+
+		switch {
+		case !inActual:
+			diffs = append(diffs, fmt.Sprintf("%s: missing, expected %s", p, formatTOML(ev)))
+		case !inExpected:
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected, got %s", p, formatTOML(av)))
+		default:
+			diffs = append(diffs, diffTOML(av, ev, p)...)
+		}
+	}
+	return diffs
+}
+
+// equalTOMLScalars reports whether two scalar values of the same kind
+// are equal. NaN floats are equal, as they are written the same.
+func equalTOMLScalars(a, e any) bool {
+	switch ev := e.(type) {
+	case float64:
+		av := a.(float64)
+		return av == ev || (math.IsNaN(av) && math.IsNaN(ev))
+	case time.Time:
+		return a.(time.Time).Equal(ev)
+	}
+	return a == e
+}
+
+// tomlKind returns the TOML type of a decoded value.
+func tomlKind(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case int64:
+		return "integer"
+	case float64:
+		return "float"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "offset date-time"
+	case tomlLocal:
+		return v.kind
+	case []any:
+		return "array"
+	case map[string]any:
+		return "table"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// formatTOML formats a decoded value as inline TOML.
+func formatTOML(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "nan"
+		case math.IsInf(v, 1):
+			return "inf"
+		case math.IsInf(v, -1):
+			return "-inf"
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case tomlLocal:
+		return v.text
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatTOML(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]any:
+		keys := sortedKeys(v)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = tomlPath("", k) + " = " + formatTOML(v[k])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+// tomlBareKey matches the keys written without quotes.
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlPath appends a key to a dotted key path.
+func tomlPath(path, key string) string {
+	if !tomlBareKey.MatchString(key) {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// readTOML decodes a TOML document into a table.
+func readTOML(s string) (map[string]any, error) {
+	p := &tomlParser{src: s, line: 1, root: map[string]any{},
+		explicit: map[string]bool{}, arrays: map[string]bool{}}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", p.line, err)
+	}
+	return p.root, nil
+}
+
+// tomlParser decodes TOML documents, as specified by TOML 1.0.
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+
+	root    map[string]any
+	current map[string]any
+
+	// explicit holds the paths of the tables defined by a header,
+	// and arrays those of the arrays of tables.
+	explicit map[string]bool
+	arrays   map[string]bool
+}
+
+var (
+	tomlInteger  = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	tomlPrefixed = regexp.MustCompile(`^0(x[0-9A-Fa-f](_?[0-9A-Fa-f])*|o[0-7](_?[0-7])*|b[01](_?[01])*)$`)
+	tomlFloat    = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	tomlSpecial  = regexp.MustCompile(`^[+-]?(inf|nan)$`)
+	tomlDate     = `[0-9]{4}-[0-9]{2}-[0-9]{2}`
+	tomlTime     = `[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?`
+	tomlOffsetDT = regexp.MustCompile(`^` + tomlDate + `[Tt ]` + tomlTime + `([Zz]|[+-][0-9]{2}:[0-9]{2})$`)
+	tomlLocalDT  = regexp.MustCompile(`^` + tomlDate + `[Tt ]` + tomlTime + `$`)
+	tomlLocalD   = regexp.MustCompile(`^` + tomlDate + `$`)
+	tomlLocalT   = regexp.MustCompile(`^` + tomlTime + `$`)
+)
+
+func (p *tomlParser) parse() error {
+	p.current = p.root
+
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return nil
+		}
+
+		var err error
+		if p.peek() == '[' {
+			err = p.parseHeader()
+		} else {
+			err = p.parseKeyValue(p.current)
+		}
+		if err != nil {
+			return err
+		}
+
+		p.skipBlank(false)
+		if !p.eof() && !p.consumeNewline() {
+			return fmt.Errorf("unexpected %q after value", p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *tomlParser) peek() byte { return p.src[p.pos] }
+
+func (p *tomlParser) hasPrefix(s string) bool { return strings.HasPrefix(p.src[p.pos:], s) }
+
+// skipBlank skips spaces and comments, and newlines when multiline is set.
+func (p *tomlParser) skipBlank(multiline bool) {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t':
+			p.pos++
+		case c == '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		case multiline && (c == '\n' || p.hasPrefix("\r\n")):
+			p.consumeNewline()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) consumeNewline() bool {
+	switch {
+	case p.hasPrefix("\n"):
+		p.pos++
+	case p.hasPrefix("\r\n"):
+		p.pos += 2
+	default:
+		return false
+	}
+	p.line++
+	return true
+}
+
+// parseHeader parses a [table] or [[array.of.tables]] header.
+func (p *tomlParser) parseHeader() error {
+	array := p.hasPrefix("[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	p.skipBlank(false)
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipBlank(false)
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !p.hasPrefix(closing) {
+		return fmt.Errorf("expected %q after table name", closing)
+	}
+	p.pos += len(closing)
+
+	parent, err := p.table(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	name := strings.Join(keys, "\x00")
+
+	if array {
+		existing, ok := parent[last]
+		if ok && !p.arrays[name] {
+			return fmt.Errorf("cannot redefine %q as an array of tables", strings.Join(keys, "."))
+		}
+		p.arrays[name] = true
+		// Tables under the previous element may be defined again.
+		for k := range p.explicit {
+			if strings.HasPrefix(k, name+"\x00") {
+				delete(p.explicit, k)
+			}
+		}
+
+		list, _ := existing.([]any)
+		table := map[string]any{}
+		parent[last] = append(list, table)
+		p.current = table
+		return nil
+	}
+
+	if p.explicit[name] || p.arrays[name] {
+		return fmt.Errorf("table %q defined twice", strings.Join(keys, "."))
+	}
+	p.explicit[name] = true
+
+	switch existing := parent[last].(type) {
+	case nil:
+		table := map[string]any{}
+		parent[last] = table
+		p.current = table
+	case map[string]any:
+		p.current = existing
+	default:
+		return fmt.Errorf("cannot redefine %q as a table", strings.Join(keys, "."))
+	}
+	return nil
+}
+
+// table returns the table at a key path from t, creating missing tables.
+// The last table of an array of tables is used.
+func (p *tomlParser) table(t map[string]any, keys []string) (map[string]any, error) {
+	for i, k := range keys {
+		switch v := t[k].(type) {
+		case nil:
+			next := map[string]any{}
+			t[k] = next
+			t = next
+		case map[string]any:
+			t = v
+		case []any:
+			var last map[string]any
+			if len(v) > 0 {
+				last, _ = v[len(v)-1].(map[string]any)
+			}
+			if last == nil {
+				return nil, fmt.Errorf("%q is not a table", strings.Join(keys[:i+1], "."))
+			}
+			t = last
+		default:
+			return nil, fmt.Errorf("%q is not a table", strings.Join(keys[:i+1], "."))
+		}
+	}
+	return t, nil
+}
+
+// parseKeyValue parses a key = value pair into table t.
+func (p *tomlParser) parseKeyValue(t map[string]any) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipBlank(false)
+	if p.eof() || p.peek() != '=' {
+		return fmt.Errorf("expected '=' after key %q", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipBlank(false)
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := p.table(t, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, ok := parent[last]; ok {
+		return fmt.Errorf("key %q defined twice", strings.Join(keys, "."))
+	}
+	parent[last] = value
+	return nil
+}
+
+// parseKey parses a dotted key made of bare and quoted keys.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		if p.eof() {
+			return nil, errors.New("expected key")
+		}
+
+		var key string
+		var err error
+		switch p.peek() {
+		case '"':
+			key, err = p.parseBasicString()
+		case '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && tomlBareKey.MatchString(p.src[p.pos:p.pos+1]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, fmt.Errorf("unexpected %q in key", p.peek())
+			}
+			key = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipBlank(false)
+		if p.eof() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+		p.skipBlank(false)
+	}
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	if p.eof() {
+		return nil, errors.New("expected value")
+	}
+
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineString(`"""`)
+	case p.hasPrefix("'''"):
+		return p.parseMultilineString("'''")
+	case p.peek() == '"':
+		return p.parseBasicString()
+	case p.peek() == '\'':
+		return p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	case p.hasPrefix("true"):
+		p.pos += 4
+		return true, nil
+	case p.hasPrefix("false"):
+		p.pos += 5
+		return false, nil
+	}
+	return p.parseScalar()
+}
+
+// parseScalar parses numbers and date-times.
+func (p *tomlParser) parseScalar() (any, error) {
+	start := p.pos
+	for !p.eof() && strings.IndexByte("0123456789abcdefABCDEFinxoTZz+-_.:", p.peek()) >= 0 {
+		p.pos++
+	}
+	// A date and a time may be separated by a space.
+	if tomlLocalD.MatchString(p.src[start:p.pos]) && p.hasPrefix(" ") &&
+		p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9' {
+		p.pos++
+		for !p.eof() && strings.IndexByte("0123456789Zz+-.:", p.peek()) >= 0 {
+			p.pos++
+		}
+	}
+	token := p.src[start:p.pos]
+
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected %q, expected value", p.peek())
+	case tomlInteger.MatchString(token):
+		n, err := strconv.ParseInt(strings.ReplaceAll(token, "_", ""), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("integer %s out of range", token)
+		}
+		return n, nil
+	case tomlPrefixed.MatchString(token):
+		n, err := strconv.ParseInt(token, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("integer %s out of range", token)
+		}
+		return n, nil
+	case tomlFloat.MatchString(token):
+		f, err := strconv.ParseFloat(strings.ReplaceAll(token, "_", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("float %s out of range", token)
+		}
+		return f, nil
+	case tomlSpecial.MatchString(token):
+		if strings.HasSuffix(token, "nan") {
+			return math.NaN(), nil
+		}
+		f, _ := strconv.ParseFloat(token, 64)
+		return f, nil
+	case tomlOffsetDT.MatchString(token):
+		ts, err := time.Parse(time.RFC3339Nano, strings.ToUpper(strings.Replace(token, " ", "T", 1)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date-time %s", token)
+		}
+		return ts, nil
+	case tomlLocalDT.MatchString(token):
+		const layout = "2006-01-02T15:04:05.999999999"
+		ts, err := time.Parse(layout, strings.ToUpper(strings.Replace(token, " ", "T", 1)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date-time %s", token)
+		}
+		return tomlLocal{kind: "local date-time", text: ts.Format(layout)}, nil
+	case tomlLocalD.MatchString(token):
+		if _, err := time.Parse("2006-01-02", token); err != nil {
+			return nil, fmt.Errorf("invalid date %s", token)
+		}
+		return tomlLocal{kind: "local date", text: token}, nil
+	case tomlLocalT.MatchString(token):
+		const layout = "15:04:05.999999999"
+		ts, err := time.Parse(layout, token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %s", token)
+		}
+		return tomlLocal{kind: "local time", text: ts.Format(layout)}, nil
+	}
+	return nil, fmt.Errorf("invalid value %s", token)
+}
+
+func (p *tomlParser) parseArray() (any, error) {
+	p.pos++
+	list := []any{}
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return nil, errors.New("unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return list, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+
+		p.skipBlank(true)
+		switch {
+		case p.eof():
+			return nil, errors.New("unterminated array")
+		case p.peek() == ',':
+			p.pos++
+		case p.peek() != ']':
+			return nil, fmt.Errorf("unexpected %q in array", p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.pos++
+	table := map[string]any{}
+
+	p.skipBlank(false)
+	if !p.eof() && p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		p.skipBlank(false)
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipBlank(false)
+		switch {
+		case p.eof():
+			return nil, errors.New("unterminated inline table")
+		case p.peek() == ',':
+			p.pos++
+		case p.peek() == '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, fmt.Errorf("unexpected %q in inline table", p.peek())
+		}
+	}
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", errors.New("unterminated string")
+		}
+		switch c := p.peek(); c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.parseEscape(&b, false); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.src[p.pos:], "'\n")
+	if end < 0 || p.src[p.pos+end] != '\'' {
+		return "", errors.New("unterminated string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseMultilineString parses a string delimited by three quotes or
+// apostrophes, where escapes are only processed in basic strings.
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	p.pos += len(delim)
+	p.consumeNewline()
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", errors.New("unterminated string")
+		}
+		if p.hasPrefix(delim) {
+			// Up to two quotes may precede the closing delimiter.
+			extra := 0
+			for extra < 2 && p.pos+len(delim)+extra < len(p.src) && p.src[p.pos+len(delim)+extra] == delim[0] {
+				extra++
+			}
+			b.WriteString(p.src[p.pos : p.pos+extra])
+			p.pos += len(delim) + extra
+			return b.String(), nil
+		}
+
+		switch c := p.peek(); {
+		case c == '\\' && delim == `"""`:
+			if err := p.parseEscape(&b, true); err != nil {
+				return "", err
+			}
+		case c == '\n' || p.hasPrefix("\r\n"):
+			p.consumeNewline()
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseEscape parses an escape sequence of a basic string into b.
+func (p *tomlParser) parseEscape(b *strings.Builder, multiline bool) error {
+	p.pos++
+	if p.eof() {
+		return errors.New("unterminated string")
+	}
+
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.src) {
+			return errors.New("invalid unicode escape")
+		}
+		n, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(n)) {
+			return fmt.Errorf("invalid unicode escape \\%c%s", c, p.src[p.pos:p.pos+size])
+		}
+		b.WriteRune(rune(n))
+		p.pos += size
+	case ' ', '\t', '\r', '\n':
+		// A line ending backslash trims the whitespace that follows,
+		// in multiline strings only.
+		if !multiline {
+			return fmt.Errorf("invalid escape \\%c", c)
+		}
+		p.pos--
+		save := p.pos
+		for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+			p.pos++
+		}
+		if !p.consumeNewline() {
+			p.pos = save
+			return fmt.Errorf("invalid escape \\%c", c)
+		}
+		for !p.eof() {
+			if p.peek() == ' ' || p.peek() == '\t' {
+				p.pos++
+			} else if !p.consumeNewline() {
+				break
+			}
+		}
+	default:
+		return fmt.Errorf("invalid escape \\%c", c)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const serverTOML = `
+# Server configuration
+title = "api"
+
+[server]
+host = "localhost"
+port = 8080
+timeout = 1.5
+
+[[servers]]
+name = "a"
+tags = ["blue", "green"]
+
+[[servers]]
+name = "b"
+`
+
+func TestTOMLEq(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    string
+		expected  string
+		wantError bool
+		wantNote  string
+	}{
+		{
+			name:   "tables written differently",
+			actual: serverTOML,
+			expected: `title = 'api'
+server = { port = 8_080, host = "localhost", timeout = 15e-1 }
+servers = [{ name = "a", tags = [ "blue",
+  "green", ] }, { name = "b" }]`,
+		},
+		{
+			name:     "dotted keys",
+			actual:   "[a]\nb.c = 1\n\"d e\".f = true",
+			expected: "a.b = { c = 0x1 }\n[a.\"d e\"]\nf = true",
+		},
+		{
+			name:      "different values",
+			actual:    serverTOML,
+			expected:  strings.Replace(serverTOML, "port = 8080", "port = 80", 1),
+			wantError: true,
+			wantNote:  "server.port: expected 80, got 8080",
+		},
+		{
+			name:      "different types",
+			actual:    "n = 1",
+			expected:  "n = 1.0",
+			wantError: true,
+			wantNote:  "n: expected float, got integer",
+		},
+		{
+			name:      "array of tables",
+			actual:    serverTOML,
+			expected:  strings.Replace(serverTOML, `"green"`, `"red"`, 1),
+			wantError: true,
+			wantNote:  `servers[0].tags[1]: expected "red", got "green"`,
+		},
+		{
+			name:      "missing and unexpected keys",
+			actual:    "a = 1\nc = 3",
+			expected:  "a = 1\n\"b.x\" = [1]",
+			wantError: true,
+			wantNote:  `"b.x": missing, expected [1]; c: unexpected, got 3`,
+		},
+		{
+			name:     "strings",
+			actual:   "s = \"tab\\there \\u00e9\"\nm = \"\"\"\nline \\\n   joined\"\"\"\nl = '''\nraw \\n'''",
+			expected: "s = 'tab\there é'\nm = \"line joined\"\nl = 'raw \\n'",
+		},
+		{
+			name:     "offset date-times by instant",
+			actual:   "at = 1979-05-27T07:32:00Z",
+			expected: "at = 1979-05-27 00:32:00-07:00",
+		},
+		{
+			name:      "local dates",
+			actual:    "d = 1979-05-27\nt = 07:32:00",
+			expected:  "d = 1979-05-28\nt = 07:32:00",
+			wantError: true,
+			wantNote:  "d: expected 1979-05-28, got 1979-05-27",
+		},
+		{
+			name:     "local times by value",
+			actual:   "t = 07:32:00.5\ndt = 1979-05-27T07:32:00.000",
+			expected: "t = 07:32:00.500\ndt = 1979-05-27 07:32:00",
+		},
+		{
+			name:      "different local times",
+			actual:    "t = 07:32:00.5",
+			expected:  "t = 07:32:00.25",
+			wantError: true,
+			wantNote:  "t: expected 07:32:00.25, got 07:32:00.5",
+		},
+		{
+			name:     "special floats",
+			actual:   "a = nan\nb = -inf",
+			expected: "a = +nan\nb = -inf",
+		},
+		{
+			name:      "duplicate key",
+			actual:    "a = 1\na = 2",
+			expected:  "a = 2",
+			wantError: true,
+			wantNote:  `invalid actual TOML: line 2: key "a" defined twice`,
+		},
+		{
+			name:      "table defined twice",
+			actual:    "[a]\n[a]",
+			expected:  "",
+			wantError: true,
+			wantNote:  `line 2: table "a" defined twice`,
+		},
+		{
+			name:      "invalid value",
+			actual:    "",
+			expected:  "a = 012",
+			wantError: true,
+			wantNote:  "invalid expected TOML: line 1: invalid value 012",
+		},
+		{
+			name:      "unterminated string",
+			actual:    "a = \"x\nb = 1",
+			expected:  "",
+			wantError: true,
+			wantNote:  "line 1: unterminated string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			TOMLEq(rec, tt.actual, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("TOMLEq() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantNote) {
+				t.Errorf("TOMLEq() message missing %q\ngot: %s", tt.wantNote, rec.ErrorMessage())
+			}
+		})
+	}
+}
+
+func TestReadTOML(t *testing.T) {
+	tests := []struct {
+		doc     string
+		want    string
+		wantErr string
+	}{
+		{"a = -0b1", "", "invalid value -0b1"},
+		{"a = 0o17\nb = 0xff", "{a = 15, b = 255}", ""},
+		{"a = 1_000.5e1_0", "{a = 1.0005e+13}", ""},
+		{"a = 1__0", "", "invalid value 1__0"},
+		{"a = 9223372036854775808", "", "out of range"},
+		{"[[a]]\n[a.b]\nc = 1\n[[a]]\n[a.b]\nc = 2", "{a = [{b = {c = 1}}, {b = {c = 2}}]}", ""},
+		{"a = [1]\n[[a]]", "", `cannot redefine "a" as an array of tables`},
+		{"a = 1\n[a.b]", "", `"a" is not a table`},
+		{"a = 1 b = 2", "", `unexpected 'b' after value`},
+		{"a = \"\\x\"", "", `invalid escape \x`},
+		{"a = {b = 1", "", "unterminated inline table"},
+		{"a = 1979-02-30", "", "invalid date"},
+		{"a = \"\"\"x\"\"\"\"\"", `{a = "x\"\""}`, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := readTOML(tt.doc)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readTOML(%q) error = %v, want %q", tt.doc, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("readTOML(%q) error = %v", tt.doc, err)
+			continue
+		}
+		if s := formatTOML(got); s != tt.want {
+			t.Errorf("readTOML(%q) = %s, want %s", tt.doc, s, tt.want)
+		}
+	}
+}
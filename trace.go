@@ -0,0 +1,128 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// maxTraceValue is the maximum length of the values of trace entries.
+const maxTraceValue = 40
+
+// tracer logs the assertions made through an Assert, see WithTrace.
+type tracer struct {
+	mu sync.Mutex
+	// depth is the number of assertions running, more than one when
+	// assertions are made by others.
+	depth int
+	// failures is the number of failures reported.
+	failures int
+	// values are the values compared by the running assertion.
+	values string
+}
+
+// WithTrace returns a copy of a logging a PASS entry for each assertion
+// that passes, with its name and the compared values when available, to
+// see how far long scenarios got and with what intermediate values:
+//
+//	a := assert.New(t).WithTrace()
+//	assert.Equal(a, order.Status, "paid")
+//	// order_test.go:42: PASS Equal actual="paid" expected="paid"
+//
+// Entries are logged with t.Log, shown by go test -v or on failure, and
+// located on the line of the assertion. Subtests of a are traced too.
+func (a *Assert) WithTrace() *Assert {
+	child := *a
+	child.tracer = &tracer{}
+	return &child
+}
+
+// lookupTracer returns the tracer of the assertions made through t,
+// or nil when they are not traced.
+func lookupTracer(t testing.TB) *tracer {
+	for {
+		a, ok := t.(*Assert)
+		if !ok {
+			return nil
+		}
+		if a.tracer != nil {
+			return a.tracer
+		}
+		t = a.TB
+	}
+}
+
+// noTrace ends the assertions that are not traced.
+func noTrace() {}
+
+// begin starts tracing the assertion name made through t, and returns
+// the function logging it when the assertion ends.
+func (tr *tracer) begin(t testing.TB, name string) func() {
+	tr.mu.Lock()
+	tr.depth++
+	top, failures := tr.depth == 1, tr.failures
+	if top {
+		tr.values = ""
+	}
+	tr.mu.Unlock()
+
+	return func() {
+		t.Helper()
+
+		tr.mu.Lock()
+		tr.depth--
+		passed, values := tr.failures == failures, tr.values
+		tr.mu.Unlock()
+
+		if top && passed {
+			t.Log(strings.TrimSpace("PASS " + name + " " + values))
+		}
+	}
+}
+
+// fail records a failure of the running assertion.
+func (tr *tracer) fail() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.failures++
+}
+
+// record records the values compared by the running assertion.
+func (tr *tracer) record(actual, expected any) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.depth == 1 {
+		tr.values = "actual=" + shortValue(actual) + " expected=" + shortValue(expected)
+	}
+}
+
+// shortValue formats value for trace entries, truncated to maxTraceValue.
+func shortValue(value any) string {
+	s := formatValue(value)
+	if runes := []rune(s); len(runes) > maxTraceValue {
+		return string(runes[:maxTraceValue]) + "..."
+	}
+	return s
+}
+
+// assertionName returns the name of the function skip frames above
+// the caller, such as "Equal" or "(*Goroutine).Wait".
+func assertionName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		assert   func(a *Assert)
+		wantLogs []string
+	}{
+		{
+			name:     "compared values",
+			assert:   func(a *Assert) { Equal(a, 42, 42) },
+			wantLogs: []string{"PASS Equal actual=42 expected=42"},
+		},
+		{
+			name:     "no values",
+			assert:   func(a *Assert) { True(a, true) },
+			wantLogs: []string{"PASS True"},
+		},
+		{
+			name:     "failure not logged",
+			assert:   func(a *Assert) { True(a, true); Equal(a, 1, 2); Nil(a, nil) },
+			wantLogs: []string{"PASS True", "PASS Nil"},
+		},
+		{
+			name:     "nested assertions logged once",
+			assert:   func(a *Assert) { Within(a, time.Second, func(a *Assert) { Equal(a, 1, 1) }) },
+			wantLogs: []string{"PASS Within"},
+		},
+		{
+			name:     "short values",
+			assert:   func(a *Assert) { Equal(a, strings.Repeat("a", 50), strings.Repeat("a", 50)) },
+			wantLogs: []string{`PASS Equal actual="` + strings.Repeat("a", 39) + `... expected="` + strings.Repeat("a", 39) + `...`},
+		},
+		{
+			name:     "method",
+			assert:   func(a *Assert) { Go(a, func() {}).Wait(time.Second) },
+			wantLogs: []string{"PASS (*Goroutine).Wait"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(New(rec).WithTrace())
+
+			Equal(t, rec.Logs(), tt.wantLogs)
+		})
+	}
+}
+
+func TestWithTraceDisabled(t *testing.T) {
+	rec := NewTestRecorder(t)
+
+	Equal(New(rec), 42, 42)
+
+	Len(t, rec.Logs(), 0)
+}
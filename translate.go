@@ -0,0 +1,109 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"sync"
+)
+
+// Translator rewords the phrases of failure messages: the labels such as
+// "Expected" and "Actual", and the default messages of assertions such as
+// "value not within expected range". Compared values, notes and custom
+// messages are never translated.
+type Translator interface {
+	// Translate returns the text of phrase, or "" to keep it.
+	Translate(phrase string) string
+}
+
+// Catalog is a Translator looking phrases up in a map.
+// Phrases missing from the map are kept.
+type Catalog map[string]string
+
+// Translate returns the text of phrase in c.
+func (c Catalog) Translate(phrase string) string {
+	return c[phrase]
+}
+
+var (
+	translatorMu sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator registers the translator of the failure messages of all
+// tests, or removes it when tr is nil. It returns the previous translator:
+//
+//	func TestMain(m *testing.M) {
+//	    assert.SetTranslator(assert.Catalog{
+//	        "Expected": "Attendu",
+//	        "Actual":   "Obtenu",
+//	        "value not within expected range": "valeur hors de l'intervalle attendu",
+//	    })
+//	    os.Exit(m.Run())
+//	}
+//
+// Labels stay aligned whatever their translated lengths.
+func SetTranslator(tr Translator) Translator {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+
+	prev := translator
+	translator = tr
+	return prev
+}
+
+// translate returns the text of phrase given by the registered translator.
+func translate(phrase string) string {
+	translatorMu.RLock()
+	tr := translator
+	translatorMu.RUnlock()
+
+	if tr != nil {
+		if text := tr.Translate(phrase); text != "" {
+			return text
+		}
+	}
+	return phrase
+}
+
+// failureLabels holds the labels of a failure message, translated and
+// right-aligned with their colon, such as "  Actual:".
+type failureLabels struct {
+	expected, actual, message, note, seed, diff, location string
+}
+
+// labelNames are the phrases of the labels of failure messages.
+var labelNames = [...]string{"Expected", "Actual", "Message", "Note", "Seed", "Diff", "Location"}
+
+// defaultLabels are the labels of failure messages without translator,
+// aligned once rather than for every failure.
+var defaultLabels = alignLabels(labelNames)
+
+// newFailureLabels returns the labels of failure messages.
+func newFailureLabels() failureLabels {
+	translatorMu.RLock()
+	tr := translator
+	translatorMu.RUnlock()
+
+	if tr == nil {
+		return defaultLabels
+	}
+	names := labelNames
+	for i, name := range names {
+		names[i] = translate(name)
+	}
+	return alignLabels(names)
+}
+
+// alignLabels right-aligns the label names with their colon.
+func alignLabels(names [len(labelNames)]string) failureLabels {
+	width := 0
+	for _, name := range names {
+		width = maxInt(width, runeCount(name))
+	}
+	for i, name := range names {
+		names[i] = strings.Repeat(" ", width-runeCount(name)) + name + ":"
+	}
+	return failureLabels{names[0], names[1], names[2], names[3], names[4], names[5], names[6]}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetTranslator(t *testing.T) {
+	defer SetTranslator(SetTranslator(Catalog{
+		"Expected":                        "Attendu",
+		"Actual":                          "Obtenu",
+		"Message":                         "Message",
+		"Note":                            "Remarque",
+		"value not within expected range": "valeur hors de l'intervalle attendu",
+	}))
+
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		want   []string
+	}{
+		{
+			name:   "labels aligned",
+			assert: func(t testing.TB) { Equal(t, 1, 2) },
+			want:   []string{"\n Attendu: (int) 2\n  Obtenu: (int) 1\n"},
+		},
+		{
+			name:   "default message",
+			assert: func(t testing.TB) { Between(t, 5, 1, 3, "score") },
+			want: []string{
+				"\n Message: score: valeur hors de l'intervalle attendu\n",
+				"Remarque: over by 2\n",
+			},
+		},
+		{
+			name:   "untranslated phrases kept",
+			assert: func(t testing.TB) { Greater(t, 1, 2) },
+			want:   []string{"Message: value not greater than minimum"},
+		},
+		{
+			name:   "diff",
+			assert: func(t testing.TB) { FileContentEquals(t, TempFileWith(t, "a"), "b") },
+			want:   []string{"    Diff:\n    --- Attendu\n    +++ Obtenu\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			tt.assert(rec)
+
+			for _, want := range tt.want {
+				if !strings.Contains(rec.ErrorMessage(), want) {
+					t.Errorf("message missing %q\ngot: %s", want, rec.ErrorMessage())
+				}
+			}
+		})
+	}
+
+	t.Run("removed", func(t *testing.T) {
+		prev := SetTranslator(nil)
+		defer SetTranslator(prev)
+
+		if got := translate("Expected"); got != "Expected" {
+			t.Errorf("translate() = %q, want %q", got, "Expected")
+		}
+	})
+}
+
+func TestNewFailureLabels(t *testing.T) {
+	want := failureLabels{"Expected:", "  Actual:", " Message:", "    Note:", "    Seed:", "    Diff:", "Location:"}
+
+	if got := newFailureLabels(); got != want {
+		t.Errorf("newFailureLabels() = %q, want %q", got, want)
+	}
+
+	t.Run("translated", func(t *testing.T) {
+		defer SetTranslator(SetTranslator(Catalog{"Expected": "Attendu", "Actual": "Obtenu"}))
+
+		want := failureLabels{" Attendu:", "  Obtenu:", " Message:", "    Note:", "    Seed:", "    Diff:", "Location:"}
+		if got := newFailureLabels(); got != want {
+			t.Errorf("newFailureLabels() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package verify exposes the comparisons of the assert package as functions
+// returning an error instead of failing a test. It can be used outside tests,
+// for runtime invariant checks or example programs, with the same detailed
+// messages as the assertions:
+//
+//	if err := verify.Between(load, 0.0, 1.0); err != nil {
+//	    log.Printf("invalid load: %v", err)
+//	}
+package verify
+
+import (
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+// That runs assertions and returns their failures as an error.
+// The TB passed to fn records failures instead of reporting them.
+func That(fn func(t testing.TB)) error {
+	return assert.Check(fn)
+}
+
+// Equal returns an error if actual and expected are not equal.
+func Equal[T any](actual, expected T) error {
+	return assert.CheckEqual(actual, expected)
+}
+
+// NotEqual returns an error if actual and expected are equal.
+func NotEqual[T any](actual, expected T) error {
+	return assert.CheckNotEqual(actual, expected)
+}
+
+// True returns an error if value is false.
+func True(value bool) error {
+	return assert.CheckTrue(value)
+}
+
+// False returns an error if value is true.
+func False(value bool) error {
+	return assert.CheckFalse(value)
+}
+
+// Nil returns an error if value is not nil.
+func Nil(value any) error {
+	return assert.CheckNil(value)
+}
+
+// NotNil returns an error if value is nil.
+func NotNil(value any) error {
+	return assert.CheckNotNil(value)
+}
+
+// NoError returns an error if err is not nil.
+func NoError(err error) error {
+	return assert.CheckNoError(err)
+}
+
+// ErrorIs returns an error if err does not match target.
+func ErrorIs(err, target error) error {
+	return assert.CheckErrorIs(err, target)
+}
+
+// Contains returns an error if slice does not contain element.
+func Contains[T any](slice []T, element T) error {
+	return assert.CheckContains(slice, element)
+}
+
+// Len returns an error if collection does not have the expected length.
+func Len(collection any, expected int) error {
+	return assert.CheckLen(collection, expected)
+}
+
+// Between returns an error if actual is not within [min, max].
+func Between[T assert.Ordered](actual, min, max T) error {
+	return assert.CheckBetween(actual, min, max)
+}
+
+// Greater returns an error if actual is not greater than min.
+func Greater[T assert.Ordered](actual, min T) error {
+	return assert.Check(func(t testing.TB) { assert.Greater(t, actual, min) })
+}
+
+// GreaterOrEqual returns an error if actual is less than min.
+func GreaterOrEqual[T assert.Ordered](actual, min T) error {
+	return assert.Check(func(t testing.TB) { assert.GreaterOrEqual(t, actual, min) })
+}
+
+// Less returns an error if actual is not less than max.
+func Less[T assert.Ordered](actual, max T) error {
+	return assert.Check(func(t testing.TB) { assert.Less(t, actual, max) })
+}
+
+// LessOrEqual returns an error if actual is greater than max.
+func LessOrEqual[T assert.Ordered](actual, max T) error {
+	return assert.Check(func(t testing.TB) { assert.LessOrEqual(t, actual, max) })
+}
+
+// Match returns an error if value does not satisfy matcher.
+func Match(value any, matcher assert.Matcher) error {
+	return assert.Check(func(t testing.TB) { assert.Match(t, value, matcher) })
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package verify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nanoninja/assert"
+)
+
+func TestVerify(t *testing.T) {
+	errBase := errors.New("base")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "Equal pass", err: Equal(1, 1)},
+		{name: "Equal fail", err: Equal(1, 2), wantErr: true},
+		{name: "NotEqual fail", err: NotEqual("a", "a"), wantErr: true},
+		{name: "True pass", err: True(true)},
+		{name: "False fail", err: False(true), wantErr: true},
+		{name: "Nil fail", err: Nil(42), wantErr: true},
+		{name: "NotNil pass", err: NotNil(42)},
+		{name: "NoError pass", err: NoError(nil)},
+		{name: "ErrorIs fail", err: ErrorIs(errBase, errors.New("other")), wantErr: true},
+		{name: "Contains pass", err: Contains([]string{"a"}, "a")},
+		{name: "Len fail", err: Len([]int{1}, 2), wantErr: true},
+		{name: "Between pass", err: Between(0.5, 0.0, 1.0)},
+		{name: "Greater fail", err: Greater(1, 2), wantErr: true},
+		{name: "GreaterOrEqual pass", err: GreaterOrEqual(2, 2)},
+		{name: "Less fail", err: Less(2, 2), wantErr: true},
+		{name: "LessOrEqual fail", err: LessOrEqual(3, 2), wantErr: true},
+		{name: "Match pass", err: Match([]int{1, 2}, assert.HaveLen(2))},
+		{name: "That fail", err: That(func(t testing.TB) { assert.Empty(t, "x") }), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", tt.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	err := Equal("got", "want")
+
+	if err == nil || !strings.Contains(err.Error(), `Expected: (string) "want"`) {
+		t.Errorf("Equal() error = %v", err)
+	}
+}
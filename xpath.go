@@ -0,0 +1,369 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// XMLPath checks the value of the first node of an XML document selected
+// by an XPath-like path. The value of an element is its text content, and
+// the value of an attribute its text, with leading and trailing spaces
+// ignored:
+//
+//	assert.XMLPath(t, doc, "/order/items/item[2]/price", "9.99")
+//	assert.XMLPath(t, doc, "//item[@sku='A1']/@currency", "EUR")
+//
+// Paths are absolute, made of element steps separated by / (child) or //
+// (descendant). A step is a local name or *, optionally followed by a
+// 1-based position [2], or by an attribute condition [@id] or [@id='3'].
+// The last step may select an attribute (@name) or the text() of elements.
+func XMLPath(t testing.TB, doc, path, expected string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	values, ok := selectXML(t, doc, path, msg)
+	if !ok {
+		return
+	}
+	if len(values) == 0 {
+		failCompare(t, "no node", fmt.Sprintf("node matching %q", path), withMessage("path did not match", msg)...)
+		return
+	}
+	if actual := strings.TrimSpace(values[0]); actual != expected {
+		failCompare(t, actual, expected, withMessage(fmt.Sprintf("unexpected value at %q", path), msg)...)
+	}
+}
+
+// XMLPathExists checks that an XPath-like path selects at least one node
+// of an XML document. See XMLPath for the supported paths.
+func XMLPathExists(t testing.TB, doc, path string, msg ...string) {
+	t.Helper()
+	defer observe(t)()
+
+	values, ok := selectXML(t, doc, path, msg)
+	if ok && len(values) == 0 {
+		failCompare(t, "no node", fmt.Sprintf("node matching %q", path), withMessage("path did not match", msg)...)
+	}
+}
+
+// selectXML parses doc and returns the values of the nodes selected by
+// path, reporting a failure when either cannot be parsed.
+func selectXML(t testing.TB, doc, path string, msg []string) ([]string, bool) {
+	t.Helper()
+
+	p, err := parseXPath(path)
+	if err != nil {
+		failCompare(t, path, "valid path", withMessage(err.Error(), msg)...)
+		return nil, false
+	}
+	root, err := parseXML(doc)
+	if err != nil {
+		failCompare(t, doc, "valid XML", withMessage("invalid XML: "+err.Error(), msg)...)
+		return nil, false
+	}
+	return p.evaluate(root), true
+}
+
+// xmlNode is an element of an XML document. Its content holds
+// its text (as strings) and child elements, in document order.
+type xmlNode struct {
+	name    string
+	attrs   []xml.Attr
+	content []any
+}
+
+// elements returns the child elements of the node.
+func (n *xmlNode) elements() []*xmlNode {
+	var children []*xmlNode
+	for _, c := range n.content {
+		if child, ok := c.(*xmlNode); ok {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// attr returns the value of the named attribute, if present.
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// text returns the text content of the node and its descendants.
+func (n *xmlNode) text() string {
+	var b strings.Builder
+	for _, c := range n.content {
+		switch c := c.(type) {
+		case string:
+			b.WriteString(c)
+		case *xmlNode:
+			b.WriteString(c.text())
+		}
+	}
+	return b.String()
+}
+
+// ownText returns the text directly held by the node.
+func (n *xmlNode) ownText() string {
+	var b strings.Builder
+	for _, c := range n.content {
+		if s, ok := c.(string); ok {
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// parseXML parses an XML document into a tree of elements, under
+// a root node without name. Element names are kept without namespace.
+func parseXML(doc string) (*xmlNode, error) {
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		top := stack[len(stack)-1]
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: tok.Name.Local, attrs: tok.Attr}
+			top.content = append(top.content, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if top != root {
+				top.content = append(top.content, string(tok))
+			}
+		}
+	}
+
+	if len(root.elements()) != 1 {
+		return nil, errors.New("document must have a single root element")
+	}
+	return root, nil
+}
+
+// xpath is a parsed XPath-like path.
+type xpath struct {
+	steps []xpathStep
+	// attr selects an attribute of the selected elements, when not empty.
+	attr string
+	// text selects the own text of the selected elements.
+	text bool
+}
+
+// xpathStep selects elements by name, position or attribute.
+type xpathStep struct {
+	descendant bool
+	name       string
+	position   int
+	attrName   string
+	attrValue  *string
+}
+
+// parseXPath parses an absolute path such as /a//b[2]/@id.
+func parseXPath(path string) (*xpath, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q: must start with /", path)
+	}
+
+	p := &xpath{}
+	rest := path
+	for rest != "" {
+		descendant := strings.HasPrefix(rest, "//")
+		rest = strings.TrimPrefix(rest, "/")
+		if descendant {
+			rest = rest[1:]
+		}
+
+		end := stepEnd(rest)
+		step := rest[:end]
+		rest = rest[end:]
+
+		switch {
+		case step == "":
+			return nil, fmt.Errorf("invalid path %q: empty step", path)
+		case strings.HasPrefix(step, "@") || step == "text()":
+			if rest != "" || descendant || len(p.steps) == 0 {
+				return nil, fmt.Errorf("invalid path %q: %s must be the last step after an element", path, step)
+			}
+			if step == "text()" {
+				p.text = true
+			} else {
+				p.attr = step[1:]
+			}
+		default:
+			s, err := parseXPathStep(step)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %v", path, err)
+			}
+			s.descendant = descendant
+			p.steps = append(p.steps, s)
+		}
+	}
+	return p, nil
+}
+
+// stepEnd returns the index of the / ending the first step of s,
+// ignoring those inside predicates.
+func stepEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+// parseXPathStep parses a step such as item, *[2] or item[@id='3'].
+func parseXPathStep(step string) (xpathStep, error) {
+	var s xpathStep
+
+	i := strings.IndexByte(step, '[')
+	if i < 0 {
+		s.name = step
+		return s, nil
+	}
+	if !strings.HasSuffix(step, "]") {
+		return s, fmt.Errorf("unterminated predicate in %q", step)
+	}
+	s.name = step[:i]
+	pred := step[i+1 : len(step)-1]
+
+	if strings.HasPrefix(pred, "@") {
+		name, value, hasValue := strings.Cut(pred[1:], "=")
+		s.attrName = name
+		if hasValue {
+			unquoted, ok := unquoteXPath(value)
+			if !ok {
+				return s, fmt.Errorf("invalid attribute value %s in %q", value, step)
+			}
+			s.attrValue = &unquoted
+		}
+		return s, nil
+	}
+
+	n, err := strconv.Atoi(pred)
+	if err != nil || n < 1 {
+		return s, fmt.Errorf("unsupported predicate [%s] in %q", pred, step)
+	}
+	s.position = n
+	return s, nil
+}
+
+// unquoteXPath removes the single or double quotes around an XPath literal.
+func unquoteXPath(s string) (string, bool) {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+// evaluate returns the values of the nodes selected from root.
+func (p *xpath) evaluate(root *xmlNode) []string {
+	nodes := []*xmlNode{root}
+	for _, step := range p.steps {
+		nodes = step.apply(nodes)
+	}
+
+	var values []string
+	for _, n := range nodes {
+		switch {
+		case p.attr != "":
+			if v, ok := n.attr(p.attr); ok {
+				values = append(values, v)
+			}
+		case p.text:
+			values = append(values, n.ownText())
+		default:
+			values = append(values, n.text())
+		}
+	}
+	return values
+}
+
+// apply returns the elements selected by the step from each of nodes.
+// As in XPath, positions count among the children of a same parent,
+// including for descendant steps.
+func (s xpathStep) apply(nodes []*xmlNode) []*xmlNode {
+	var result []*xmlNode
+	seen := map[*xmlNode]bool{}
+
+	for _, n := range nodes {
+		parents := []*xmlNode{n}
+		if s.descendant {
+			parents = append(parents, descendants(n)...)
+		}
+
+		for _, parent := range parents {
+			position := 0
+			for _, c := range parent.elements() {
+				if !s.matches(c) {
+					continue
+				}
+				position++
+				if s.position != 0 && position != s.position {
+					continue
+				}
+				if !seen[c] {
+					seen[c] = true
+					result = append(result, c)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// matches reports whether an element satisfies the name
+// and attribute conditions of the step.
+func (s xpathStep) matches(n *xmlNode) bool {
+	if s.name != "*" && n.name != s.name {
+		return false
+	}
+	if s.attrName != "" {
+		v, ok := n.attr(s.attrName)
+		if !ok || (s.attrValue != nil && v != *s.attrValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// descendants returns the descendant elements of n, in document order.
+func descendants(n *xmlNode) []*xmlNode {
+	var result []*xmlNode
+	for _, c := range n.elements() {
+		result = append(result, c)
+		result = append(result, descendants(c)...)
+	}
+	return result
+}
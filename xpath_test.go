@@ -0,0 +1,104 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const orderXML = `<?xml version="1.0"?>
+<order id="42" xmlns="urn:shop">
+  <customer>alice</customer>
+  <items>
+    <item sku="A1" currency="EUR">
+      <name>Pen</name>
+      <price>1.50</price>
+    </item>
+    <item sku="B2" currency="USD">
+      <name>Ink</name>
+      <price>9.99</price>
+    </item>
+  </items>
+  <note>Leave at <b>door</b> please</note>
+</order>`
+
+func TestXMLPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		wantError bool
+		wantPart  string
+	}{
+		{"child steps", "/order/customer", "alice", false, ""},
+		{"position", "/order/items/item[2]/price", "9.99", false, ""},
+		{"first of several", "/order/items/item/name", "Pen", false, ""},
+		{"attribute", "/order/@id", "42", false, ""},
+		{"attribute predicate", "//item[@sku='B2']/@currency", "USD", false, ""},
+		{"attribute presence", "/order/items/item[@currency]/name", "Pen", false, ""},
+		{"descendant", "//price", "1.50", false, ""},
+		{"descendant position", "//item[2]/name", "Ink", false, ""},
+		{"wildcard", "/order/*[1]", "alice", false, ""},
+		{"text content", "/order/note", "Leave at door please", false, ""},
+		{"own text", "/order/note/text()", "Leave at  please", false, ""},
+		{"different value", "/order/items/item[1]/price", "9.99", true, `unexpected value at "/order/items/item[1]/price"`},
+		{"no match", "/order/items/item[3]", "", true, "path did not match"},
+		{"relative path", "order", "", true, "must start with /"},
+		{"bad predicate", "/order/item[last()]", "", true, "unsupported predicate [last()]"},
+		{"attribute not last", "/order/@id/x", "", true, "must be the last step"},
+		{"empty step", "/order/", "", true, "empty step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			XMLPath(rec, orderXML, tt.path, tt.expected)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("XMLPath() error = %v, want %v\n%s", rec.HasError(), tt.wantError, rec.ErrorMessage())
+			}
+			if !strings.Contains(rec.ErrorMessage(), tt.wantPart) {
+				t.Errorf("XMLPath() message missing %q\ngot: %s", tt.wantPart, rec.ErrorMessage())
+			}
+		})
+	}
+
+	t.Run("invalid XML", func(t *testing.T) {
+		rec := NewTestRecorder(t)
+
+		XMLPath(rec, "<a><b></a>", "/a", "")
+
+		if !strings.Contains(rec.ErrorMessage(), "invalid XML") {
+			t.Errorf("XMLPath() message missing error\ngot: %s", rec.ErrorMessage())
+		}
+	})
+}
+
+func TestXMLPathExists(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantError bool
+	}{
+		{"element", "/order/items/item[2]", false},
+		{"attribute", "//item/@sku", false},
+		{"missing element", "/order/invoice", true},
+		{"missing attribute", "/order/@status", true},
+		{"invalid path", "/order/item[", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewTestRecorder(t)
+
+			XMLPathExists(rec, orderXML, tt.path)
+
+			if tt.wantError != rec.HasError() {
+				t.Errorf("XMLPathExists() error = %v, want %v", rec.HasError(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,689 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// yamlKind is the kind of a YAML node.
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlMapping
+	yamlSequence
+)
+
+// yamlNode is a node of a YAML document, with the line it starts on.
+// Scalars hold nil, a bool, a string or a json.Number, mappings hold
+// their keys in order with their values in items, and sequences hold
+// their entries in items.
+type yamlNode struct {
+	kind  yamlKind
+	line  int
+	value any
+	keys  []string
+	items []*yamlNode
+}
+
+// yamlError is an error of a YAML document, at a line.
+type yamlError struct {
+	line int
+	msg  string
+}
+
+func (e *yamlError) Error() string { return e.msg }
+
+// yamlLine is a line of a YAML document. Its text starts after the
+// indentation and has no trailing comment.
+type yamlLine struct {
+	raw    string
+	indent int
+	text   string
+	tab    bool
+}
+
+// yamlMark is the line of the node starting at an offset of the JSON
+// form of a document.
+type yamlMark struct {
+	offset int
+	line   int
+}
+
+// readYAML decodes a YAML document made of block and flow collections,
+// plain, quoted and block scalars. Anchors, aliases, tags, complex keys
+// and multiple documents are not supported.
+func readYAML(s string) (*yamlNode, error) {
+	p := &yamlParser{}
+	for _, raw := range strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n") {
+		p.lines = append(p.lines, newYAMLLine(raw))
+	}
+	return p.parse()
+}
+
+// newYAMLLine splits a raw line into its indentation and text.
+func newYAMLLine(raw string) yamlLine {
+	body := strings.TrimLeft(raw, " ")
+	l := yamlLine{raw: raw, indent: len(raw) - len(body)}
+	l.tab = strings.HasPrefix(body, "\t")
+	l.text = strings.TrimRight(stripYAMLComment(strings.TrimLeft(body, "\t")), " \t")
+	return l
+}
+
+// stripYAMLComment removes the comment ending a line, which starts with
+// a # at the start of the line or after a space, outside of quotes.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			if quote == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			quote = 0
+		case quote != 0:
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t[{,:-", s[i-1]) >= 0):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// yamlParser decodes the lines of a YAML document.
+type yamlParser struct {
+	lines []yamlLine
+	i     int
+}
+
+func (p *yamlParser) errorf(line int, format string, args ...any) error {
+	return &yamlError{line: line, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *yamlParser) eof() bool { return p.i >= len(p.lines) }
+
+// line returns the number of the current line.
+func (p *yamlParser) line() int { return p.i + 1 }
+
+// atEnd reports whether the current line ends the block collections:
+// the end of the document, or a document marker.
+func (p *yamlParser) atEnd(indent int) bool {
+	if p.eof() {
+		return true
+	}
+	l := p.lines[p.i]
+	return l.indent < indent || (l.indent == 0 && (l.text == "---" || l.text == "..."))
+}
+
+// skipBlank skips empty and comment lines.
+func (p *yamlParser) skipBlank() {
+	for !p.eof() && p.lines[p.i].text == "" {
+		p.i++
+	}
+}
+
+func (p *yamlParser) parse() (*yamlNode, error) {
+	p.skipBlank()
+	if !p.eof() && p.lines[p.i].indent == 0 && p.lines[p.i].text == "---" {
+		p.i++
+		p.skipBlank()
+	}
+	if p.eof() || p.lines[p.i].text == "..." {
+		return &yamlNode{line: p.line()}, nil
+	}
+
+	n, err := p.parseBlock(p.lines[p.i].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if !p.eof() && p.lines[p.i].text == "..." {
+		p.i++
+		p.skipBlank()
+	}
+	if !p.eof() {
+		if p.lines[p.i].text == "---" {
+			return nil, p.errorf(p.line(), "multiple documents are not supported")
+		}
+		return nil, p.errorf(p.line(), "unexpected indentation")
+	}
+	return n, nil
+}
+
+// parseBlock parses the node starting on the current line, which is
+// indented by at least min.
+func (p *yamlParser) parseBlock(min int) (*yamlNode, error) {
+	l := p.lines[p.i]
+	if l.tab {
+		return nil, p.errorf(p.line(), "tabs are not allowed in indentation")
+	}
+	if isYAMLEntry(l.text) {
+		return p.parseSequence(l.indent)
+	}
+	if _, _, ok := splitYAMLKey(l.text); ok {
+		return p.parseMapping(l.indent)
+	}
+	return p.parseValue(l.text, min-1)
+}
+
+// parseMapping parses the entries of a block mapping indented by indent.
+func (p *yamlParser) parseMapping(indent int) (*yamlNode, error) {
+	n := &yamlNode{kind: yamlMapping, line: p.line()}
+	seen := make(map[string]bool)
+
+	for p.skipBlank(); !p.atEnd(indent); p.skipBlank() {
+		l := p.lines[p.i]
+		if l.tab {
+			return nil, p.errorf(p.line(), "tabs are not allowed in indentation")
+		}
+		if l.indent > indent {
+			return nil, p.errorf(p.line(), "unexpected indentation")
+		}
+		key, rest, ok := splitYAMLKey(l.text)
+		if !ok {
+			return nil, p.errorf(p.line(), "expected a mapping key, got %q", l.text)
+		}
+		if seen[key] {
+			return nil, p.errorf(p.line(), "duplicate key %q", key)
+		}
+		seen[key] = true
+
+		value, err := p.parseEntryValue(rest, indent, true)
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.items = append(n.items, value)
+	}
+	return n, nil
+}
+
+// parseSequence parses the entries of a block sequence indented by indent.
+func (p *yamlParser) parseSequence(indent int) (*yamlNode, error) {
+	n := &yamlNode{kind: yamlSequence, line: p.line()}
+
+	for p.skipBlank(); !p.atEnd(indent); p.skipBlank() {
+		l := p.lines[p.i]
+		if l.tab {
+			return nil, p.errorf(p.line(), "tabs are not allowed in indentation")
+		}
+		if l.indent > indent {
+			return nil, p.errorf(p.line(), "unexpected indentation")
+		}
+		if !isYAMLEntry(l.text) {
+			break
+		}
+		rest := strings.TrimLeft(l.text[1:], " ")
+
+		var value *yamlNode
+		var err error
+		if rest == "" || rest[0] == '|' || rest[0] == '>' {
+			value, err = p.parseEntryValue(rest, indent, false)
+		} else {
+			// The entry holds a compact collection or a scalar, indented
+			// by the column following the dash.
+			p.lines[p.i].indent += len(l.text) - len(rest)
+			p.lines[p.i].text = rest
+			value, err = p.parseBlock(p.lines[p.i].indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, value)
+	}
+	return n, nil
+}
+
+// parseEntryValue parses the value of a mapping or sequence entry indented
+// by indent, given after the key or the dash of the current line or, when
+// rest is empty, on the next lines. A mapping value can be a sequence
+// indented like its key.
+func (p *yamlParser) parseEntryValue(rest string, indent int, inMapping bool) (*yamlNode, error) {
+	if rest != "" {
+		return p.parseValue(rest, indent)
+	}
+
+	line := p.line()
+	p.i++
+	p.skipBlank()
+	if p.eof() {
+		return &yamlNode{line: line}, nil
+	}
+	next := p.lines[p.i]
+	switch {
+	case next.indent > indent:
+		return p.parseBlock(indent + 1)
+	case inMapping && next.indent == indent && isYAMLEntry(next.text):
+		return p.parseSequence(indent)
+	}
+	return &yamlNode{line: line}, nil
+}
+
+// parseValue parses a value starting with text on the current line, and
+// continued on the next lines indented by more than parent.
+func (p *yamlParser) parseValue(text string, parent int) (*yamlNode, error) {
+	line := p.line()
+	switch text[0] {
+	case '|', '>':
+		return p.parseBlockScalar(text, parent)
+	case '[', '{':
+		return p.parseFlow(text, parent)
+	case '"', '\'':
+		s, err := p.parseQuoted(text, parent)
+		if err != nil {
+			return nil, err
+		}
+		return &yamlNode{line: line, value: s}, nil
+	case '&', '*', '!', '%', '@', '`', '?':
+		return nil, p.errorf(line, "unsupported YAML syntax %q", text)
+	}
+
+	// Plain scalars continue on more indented lines, joined by spaces.
+	p.i++
+	for p.skipBlank(); !p.eof() && p.lines[p.i].indent > parent; p.skipBlank() {
+		if _, _, ok := splitYAMLKey(p.lines[p.i].text); ok {
+			return nil, p.errorf(p.line(), "unexpected mapping key in a scalar")
+		}
+		text += " " + p.lines[p.i].text
+		p.i++
+	}
+	value, err := resolveYAML(text)
+	if err != nil {
+		return nil, p.errorf(line, "%s", err)
+	}
+	return &yamlNode{line: line, value: value}, nil
+}
+
+// parseQuoted parses a single or double-quoted scalar starting with text,
+// which may continue on the next lines, where line breaks fold to spaces.
+func (p *yamlParser) parseQuoted(text string, parent int) (string, error) {
+	line := p.line()
+	for {
+		p.i++
+		if s, rest, ok := unquoteYAML(text); ok {
+			if rest != "" {
+				return "", p.errorf(line, "unexpected %q after quoted scalar", rest)
+			}
+			return s, nil
+		}
+		if p.eof() || (p.lines[p.i].text != "" && p.lines[p.i].indent <= parent) {
+			return "", p.errorf(line, "unterminated quoted scalar")
+		}
+		text += " " + strings.TrimSpace(p.lines[p.i].raw)
+	}
+}
+
+// unquoteYAML decodes the quoted scalar starting s, and returns the text
+// following it. It reports false when s has no closing quote.
+func unquoteYAML(s string) (string, string, bool) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			body := s[1:i]
+			if quote == '\'' {
+				return strings.ReplaceAll(body, "''", "'"), strings.TrimSpace(s[i+1:]), true
+			}
+			v, err := strconv.Unquote(`"` + body + `"`)
+			if err != nil {
+				v = body
+			}
+			return v, strings.TrimSpace(s[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) scalar, with the
+// header text, made of lines indented by more than parent.
+func (p *yamlParser) parseBlockScalar(header string, parent int) (*yamlNode, error) {
+	line := p.line()
+	folded := header[0] == '>'
+	chomp := byte(0)
+	indent := 0
+	for _, c := range header[1:] {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = byte(c)
+		case c >= '1' && c <= '9' && indent == 0:
+			indent = maxInt(parent, 0) + int(c-'0')
+		default:
+			return nil, p.errorf(line, "invalid block scalar header %q", header)
+		}
+	}
+
+	// The content is indented like its first non-empty line.
+	p.i++
+	var lines []string
+	for ; !p.eof(); p.i++ {
+		raw := p.lines[p.i].raw
+		body := strings.TrimLeft(raw, " ")
+		if body == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if indent == 0 {
+			indent = len(raw) - len(body)
+			if indent <= parent {
+				break
+			}
+		}
+		if len(raw)-len(body) < indent {
+			break
+		}
+		lines = append(lines, raw[indent:])
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var b strings.Builder
+	more := func(s string) bool { return s != "" && (s[0] == ' ' || s[0] == '\t') }
+	for i, l := range lines {
+		switch {
+		case i == 0:
+		case !folded, more(l), lines[i-1] != "" && more(lines[i-1]):
+			b.WriteByte('\n')
+		case l == "":
+			b.WriteByte('\n')
+		case lines[i-1] != "":
+			b.WriteByte(' ')
+		}
+		b.WriteString(l)
+	}
+
+	s := b.String()
+	switch {
+	case s == "" || chomp == '-':
+	case chomp == '+':
+		s += strings.Repeat("\n", trailing+1)
+	default:
+		s += "\n"
+	}
+	return &yamlNode{line: line, value: s}, nil
+}
+
+// parseFlow parses a flow collection starting with text, which may
+// continue on the next lines indented by more than parent.
+func (p *yamlParser) parseFlow(text string, parent int) (*yamlNode, error) {
+	line := p.line()
+	for {
+		p.i++
+		f := &yamlFlow{src: text, line: line}
+		n, err := f.parseValue()
+		if err == nil {
+			f.skipSpace()
+			if f.pos < len(f.src) {
+				return nil, f.errorf("unexpected %q after flow collection", f.src[f.pos:])
+			}
+			return n, nil
+		}
+		if err != errYAMLFlowEnd {
+			return nil, err
+		}
+		p.skipBlank()
+		if p.eof() || p.lines[p.i].indent <= parent {
+			return nil, p.errorf(line, "unterminated flow collection")
+		}
+		text += "\n" + p.lines[p.i].text
+	}
+}
+
+// errYAMLFlowEnd is returned for flow collections continued on the next line.
+var errYAMLFlowEnd = &yamlError{msg: "unexpected end of flow collection"}
+
+// yamlFlow parses a flow collection, from its text starting on a line.
+type yamlFlow struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (f *yamlFlow) errorf(format string, args ...any) error {
+	line := f.line + strings.Count(f.src[:minInt(f.pos, len(f.src))], "\n")
+	return &yamlError{line: line, msg: fmt.Sprintf(format, args...)}
+}
+
+func (f *yamlFlow) skipSpace() {
+	for f.pos < len(f.src) && strings.IndexByte(" \t\n", f.src[f.pos]) >= 0 {
+		f.pos++
+	}
+}
+
+func (f *yamlFlow) parseValue() (*yamlNode, error) {
+	f.skipSpace()
+	if f.pos >= len(f.src) {
+		return nil, errYAMLFlowEnd
+	}
+	line := f.line + strings.Count(f.src[:f.pos], "\n")
+
+	switch c := f.src[f.pos]; c {
+	case '[', '{':
+		n := &yamlNode{kind: yamlSequence, line: line}
+		end := byte(']')
+		if c == '{' {
+			n.kind, end = yamlMapping, '}'
+		}
+		f.pos++
+		seen := make(map[string]bool)
+		for {
+			f.skipSpace()
+			if f.pos >= len(f.src) {
+				return nil, errYAMLFlowEnd
+			}
+			if f.src[f.pos] == end {
+				f.pos++
+				return n, nil
+			}
+			if n.kind == yamlMapping {
+				key, err := f.parseKey()
+				if err != nil {
+					return nil, err
+				}
+				if seen[key] {
+					return nil, f.errorf("duplicate key %q", key)
+				}
+				seen[key] = true
+				n.keys = append(n.keys, key)
+			}
+			item, err := f.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+
+			f.skipSpace()
+			switch {
+			case f.pos >= len(f.src):
+				return nil, errYAMLFlowEnd
+			case f.src[f.pos] == ',':
+				f.pos++
+			case f.src[f.pos] != end:
+				return nil, f.errorf("expected ',' or '%c' in flow collection", end)
+			}
+		}
+	case '"', '\'':
+		s, rest, ok := unquoteYAML(f.src[f.pos:])
+		if !ok {
+			return nil, errYAMLFlowEnd
+		}
+		f.pos = len(f.src) - len(rest)
+		return &yamlNode{line: line, value: s}, nil
+	case ']', '}', ',':
+		return nil, f.errorf("unexpected %q in flow collection", c)
+	}
+
+	start := f.pos
+	for f.pos < len(f.src) && strings.IndexByte(",[]{}\n", f.src[f.pos]) < 0 {
+		f.pos++
+	}
+	value, err := resolveYAML(strings.TrimSpace(f.src[start:f.pos]))
+	if err != nil {
+		return nil, f.errorf("%s", err)
+	}
+	return &yamlNode{line: line, value: value}, nil
+}
+
+// parseKey parses the key of a flow mapping entry, and its colon.
+func (f *yamlFlow) parseKey() (string, error) {
+	var key string
+	if c := f.src[f.pos]; c == '"' || c == '\'' {
+		s, rest, ok := unquoteYAML(f.src[f.pos:])
+		if !ok {
+			return "", errYAMLFlowEnd
+		}
+		key = s
+		f.pos = len(f.src) - len(rest)
+	} else {
+		start := f.pos
+		for f.pos < len(f.src) && f.src[f.pos] != ':' && strings.IndexByte(",[]{}\n", f.src[f.pos]) < 0 {
+			f.pos++
+		}
+		key = strings.TrimSpace(f.src[start:f.pos])
+	}
+	f.skipSpace()
+	if f.pos >= len(f.src) {
+		return "", errYAMLFlowEnd
+	}
+	if f.src[f.pos] != ':' {
+		return "", f.errorf("expected ':' after key %q in flow mapping", key)
+	}
+	f.pos++
+	return key, nil
+}
+
+// isYAMLEntry reports whether text starts a block sequence entry.
+func isYAMLEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits the text of a block mapping entry into its key and
+// the text of its value. It reports false when text is not an entry.
+func splitYAMLKey(text string) (string, string, bool) {
+	if text == "" || strings.IndexByte("[{|>&*!%@`#", text[0]) >= 0 || isYAMLEntry(text) {
+		return "", "", false
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		key, rest, ok := unquoteYAML(text)
+		if !ok || !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+var (
+	yamlInt   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloat = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAML returns the value of a plain scalar with the YAML 1.2 core
+// schema: null, booleans, integers and floats, or else a string. Numbers
+// are returned as json.Number.
+func resolveYAML(s string) (any, error) {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF", "-.inf", "-.Inf", "-.INF", ".nan", ".NaN", ".NAN":
+		return nil, fmt.Errorf("unsupported float %s", s)
+	}
+
+	base, digits := 10, strings.TrimPrefix(s, "+")
+	switch {
+	case strings.HasPrefix(s, "0x"):
+		base, digits = 16, s[2:]
+	case strings.HasPrefix(s, "0o"):
+		base, digits = 8, s[2:]
+	case yamlFloat.MatchString(s) && !yamlInt.MatchString(s):
+		f, ok := new(big.Float).SetPrec(256).SetString(strings.TrimPrefix(s, "+"))
+		if !ok {
+			return s, nil
+		}
+		return json.Number(f.Text('g', -1)), nil
+	case !yamlInt.MatchString(s):
+		return s, nil
+	}
+	i, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return s, nil
+	}
+	return json.Number(i.String()), nil
+}
+
+// writeJSON writes the JSON form of n to b, and the offset of each
+// of its nodes to marks.
+func (n *yamlNode) writeJSON(b *bytes.Buffer, marks *[]yamlMark) {
+	*marks = append(*marks, yamlMark{offset: b.Len(), line: n.line})
+
+	switch n.kind {
+	case yamlMapping:
+		b.WriteByte('{')
+		for i, key := range n.keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			b.Write(k)
+			b.WriteByte(':')
+			n.items[i].writeJSON(b, marks)
+		}
+		b.WriteByte('}')
+	case yamlSequence:
+		b.WriteByte('[')
+		for i, item := range n.items {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			item.writeJSON(b, marks)
+		}
+		b.WriteByte(']')
+	default:
+		v, _ := json.Marshal(n.value)
+		b.Write(v)
+	}
+}
+
+// yamlLineAt returns the line of the node of the JSON form of a document
+// holding offset, the offset of a decoding error.
+func yamlLineAt(marks []yamlMark, offset int64) int {
+	i := sort.Search(len(marks), func(i int) bool { return int64(marks[i].offset) >= offset })
+	if i > 0 {
+		i--
+	}
+	if len(marks) == 0 {
+		return 1
+	}
+	return marks[i].line
+}
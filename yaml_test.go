@@ -0,0 +1,79 @@
+// Copyright 2025 The Nanoninja Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package assert
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"empty", "# nothing\n", `null`},
+		{"scalars", "a: 1\nb: -2.50\nc: true\nd: ~\ne: hello world\nf: 0x1F\ng: 0755\nh: yes", `{"a":1,"b":-2.5,"c":true,"d":null,"e":"hello world","f":31,"g":755,"h":"yes"}`},
+		{"quoted", "a: \"x: \\\"y\\\" # z\"\nb: 'it''s'\nc: '1'", `{"a":"x: \"y\" # z","b":"it's","c":"1"}`},
+		{"comments", "---\n# header\na: b # trailing\nc: d#e\n...\n", `{"a":"b","c":"d#e"}`},
+		{"nested mappings", "a:\n  b:\n    c: 1\n  d: 2\ne: 3", `{"a":{"b":{"c":1},"d":2},"e":3}`},
+		{"sequences", "a:\n  - 1\n  - x\nb:\n- 2\n-\n  - 3\n  - - 4", `{"a":[1,"x"],"b":[2,[3,[4]]]}`},
+		{"compact mappings", "- name: alice\n  roles: [admin]\n- name: bob\n  age: 30", `[{"name":"alice","roles":["admin"]},{"age":30,"name":"bob"}]`},
+		{"empty values", "a:\nb: []\nc: {}\nd:", `{"a":null,"b":[],"c":{},"d":null}`},
+		{"flow collections", "a: [1, [2, 3], {b: c, 'd e': \"f\"}]\nb: {x: [1,\n  2], y: z}", `{"a":[1,[2,3],{"b":"c","d e":"f"}],"b":{"x":[1,2],"y":"z"}}`},
+		{"literal block", "a: |\n  line 1\n    indented\n\n  line 3\nb: |-\n  x\n\nc: |+\n  y\n\n", `{"a":"line 1\n  indented\n\nline 3\n","b":"x","c":"y\n\n"}`},
+		{"folded block", "a: >\n  one\n  two\n\n  three\n    kept\n  four\nb: 1", `{"a":"one two\nthree\n  kept\nfour\n","b":1}`},
+		{"multi-line plain", "a: one\n  two\nb: 2", `{"a":"one two","b":2}`},
+		{"top-level sequence", "- a\n- b", `["a","b"]`},
+		{"keys with colons", "url: http://example.com:8080/x\n\"k: 1\": v", `{"k: 1":"v","url":"http://example.com:8080/x"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := readYAML(tt.yaml)
+			if err != nil {
+				t.Fatalf("readYAML() error = %v", err)
+			}
+			var b bytes.Buffer
+			var marks []yamlMark
+			n.writeJSON(&b, &marks)
+			JSONEq(t, b.String(), tt.want)
+		})
+	}
+}
+
+func TestReadYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		line int
+		msg  string
+	}{
+		{"key in a scalar", "a:\n  b: 1\n    c: 2", 3, "unexpected mapping key in a scalar"},
+		{"dedented value", "a:\n    b: 1\n  c: 2", 3, "unexpected indentation"},
+		{"duplicate key", "a: 1\nb: 2\na: 3", 3, `duplicate key "a"`},
+		{"tab indentation", "a:\n\tb: 1", 2, "tabs are not allowed in indentation"},
+		{"missing key", "a: 1\nplain", 2, `expected a mapping key, got "plain"`},
+		{"anchor", "a: &x 1", 1, `unsupported YAML syntax "&x 1"`},
+		{"unterminated flow", "a: [1, 2\nb: 3", 1, "unterminated flow collection"},
+		{"flow separator", "a: [1\n  2]", 2, "expected ',' or ']' in flow collection"},
+		{"unterminated quote", "a: \"x\nb: 1", 1, "unterminated quoted scalar"},
+		{"multiple documents", "a: 1\n---\nb: 2", 2, "multiple documents are not supported"},
+		{"infinity", "a:\n  - .inf", 2, "unsupported float .inf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readYAML(tt.yaml)
+			if err == nil {
+				t.Fatal("readYAML() error = nil")
+			}
+			yamlErr := err.(*yamlError)
+			if yamlErr.line != tt.line || yamlErr.msg != tt.msg {
+				t.Errorf("readYAML() error = %d: %s, want %d: %s", yamlErr.line, yamlErr.msg, tt.line, tt.msg)
+			}
+		})
+	}
+}